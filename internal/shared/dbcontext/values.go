@@ -0,0 +1,49 @@
+// Package dbcontext carries request-scoped values, such as the acting user and the tenant,
+// through a context.Context under unexported typed keys, so callers and unit of work
+// implementations share one convention and cannot collide with string keys set elsewhere.
+package dbcontext
+
+import "context"
+
+// actorKey, tenantKey and requestIDKey are distinct types so their values cannot be read or
+// overwritten through keys defined in other packages
+type (
+	actorKey     struct{}
+	tenantKey    struct{}
+	requestIDKey struct{}
+)
+
+// WithActor returns a copy of ctx carrying the ID of the user performing the operation,
+// as recorded in the CreatedBy and UpdatedBy audit fields
+func WithActor(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the user ID set by WithActor, and false when there is none
+func ActorFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(actorKey{}).(int)
+	return userID, ok
+}
+
+// WithTenant returns a copy of ctx carrying the tenant the operation is scoped to
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant set by WithTenant, and false when there is none
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(string)
+	return tenantID, ok
+}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being served, for
+// correlating statements and audit entries with it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID set by WithRequestID, and false when there is none
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok
+}
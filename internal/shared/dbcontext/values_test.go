@@ -0,0 +1,47 @@
+package dbcontext
+
+import (
+	"context"
+	"testing"
+)
+
+// TestValues_RoundTrip validates that each value is retrieved as set and that the values do
+// not interfere with each other
+func TestValues_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := WithRequestID(WithTenant(WithActor(context.Background(), 7), "acme"), "req-1")
+
+	// Act
+	actor, actorOK := ActorFromContext(ctx)
+	tenant, tenantOK := TenantFromContext(ctx)
+	requestID, requestIDOK := RequestIDFromContext(ctx)
+
+	// Assert
+	if !actorOK || actor != 7 {
+		t.Errorf("Expected actor 7, got %d (found %v)", actor, actorOK)
+	}
+	if !tenantOK || tenant != "acme" {
+		t.Errorf("Expected tenant acme, got %q (found %v)", tenant, tenantOK)
+	}
+	if !requestIDOK || requestID != "req-1" {
+		t.Errorf("Expected request ID req-1, got %q (found %v)", requestID, requestIDOK)
+	}
+}
+
+// TestValues_Missing validates that absent values and values stored under colliding string
+// keys are reported as missing
+func TestValues_Missing(t *testing.T) {
+	// Arrange
+	type stringKey string
+	ctx := context.WithValue(context.Background(), stringKey("tenant"), "acme")
+
+	// Act
+	_, actorOK := ActorFromContext(ctx)
+	_, tenantOK := TenantFromContext(ctx)
+	_, requestIDOK := RequestIDFromContext(ctx)
+
+	// Assert
+	if actorOK || tenantOK || requestIDOK {
+		t.Errorf("Expected no values, got actor %v, tenant %v, request ID %v", actorOK, tenantOK, requestIDOK)
+	}
+}
@@ -17,6 +17,16 @@ func NewIdentifier() IIdentifier {
 	}
 }
 
+// IdentifierFromCriteria creates a builder seeded with criteria, such as a saved filter
+// deserialized from JSON, preserving operators, values, logical operators and groups.
+// It is the inverse of ToFilterCriteria; the criteria are deep-copied, so later changes
+// to the slice do not affect the identifier.
+func IdentifierFromCriteria(criteria []FilterCriteria) IIdentifier {
+	return &IdentifierBuilder{
+		criteria: cloneCriteria(criteria),
+	}
+}
+
 // clone creates a deep copy of the current builder state to maintain immutability
 func (ib *IdentifierBuilder) clone() *IdentifierBuilder {
 	ib.mutex.RLock()
@@ -118,6 +128,34 @@ func (ib *IdentifierBuilder) NotIn(field string, values []interface{}) IIdentifi
 	})
 }
 
+// InSubquery adds a filter condition that checks if field value is among the values selected by
+// sub, as in "field IN (SELECT column FROM table WHERE ...)"
+func (ib *IdentifierBuilder) InSubquery(field string, sub Subquery) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    field,
+		Operator: FilterOperatorInSubquery,
+		Subquery: &sub,
+	})
+}
+
+// NotInSubquery adds a filter condition that checks if field value is not among the values
+// selected by sub, for exclusion filters such as "users not in the banned set". SQL gives
+// NOT IN no match as soon as the subquery yields a NULL, so the SQL applier only selects
+// non-NULL values; rows whose own field is NULL are still excluded.
+func (ib *IdentifierBuilder) NotInSubquery(field string, sub Subquery) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    field,
+		Operator: FilterOperatorNotInSubquery,
+		Subquery: &sub,
+	})
+}
+
+// InOrNull adds a grouped "(field IN values OR field IS NULL)" condition, so nullable columns
+// can be filtered by a set of values while also matching unset rows with correct precedence
+func (ib *IdentifierBuilder) InOrNull(field string, values []interface{}) IIdentifier {
+	return ib.AndGroup(NewIdentifier().In(field, values).Or(NewIdentifier().IsNull(field)))
+}
+
 // IsNull adds a filter condition that checks if field value is NULL
 func (ib *IdentifierBuilder) IsNull(field string) IIdentifier {
 	return ib.addCriteria(FilterCriteria{
@@ -143,7 +181,59 @@ func (ib *IdentifierBuilder) Between(field string, start, end interface{}) IIden
 	})
 }
 
-// Contains adds a filter condition for JSON/array field containment
+// ValueInColumnRange adds a filter condition that checks if value lies between the values of
+// two columns, as in "? BETWEEN start_col AND end_col" for schedule and availability tables
+func (ib *IdentifierBuilder) ValueInColumnRange(value interface{}, startCol, endCol string) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    startCol,
+		EndField: endCol,
+		Operator: FilterOperatorValueInColumnRange,
+		Value:    value,
+	})
+}
+
+// Regex adds a case-sensitive regular expression filter condition.
+// Unlike Like, the pattern is passed through unescaped so callers control it.
+func (ib *IdentifierBuilder) Regex(field string, pattern string) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    field,
+		Operator: FilterOperatorRegex,
+		Value:    pattern,
+	})
+}
+
+// RegexInsensitive adds a case-insensitive regular expression filter condition
+func (ib *IdentifierBuilder) RegexInsensitive(field string, pattern string) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    field,
+		Operator: FilterOperatorRegexInsensitive,
+		Value:    pattern,
+	})
+}
+
+// IsDistinctFrom adds a NULL-safe inequality filter condition.
+// Unlike NotEqual, a NULL column is distinct from any non-NULL value.
+func (ib *IdentifierBuilder) IsDistinctFrom(field string, value interface{}) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    field,
+		Operator: FilterOperatorIsDistinctFrom,
+		Value:    value,
+	})
+}
+
+// IsNotDistinctFrom adds a NULL-safe equality filter condition.
+// Unlike Equal, a nil value matches rows where the column is NULL.
+func (ib *IdentifierBuilder) IsNotDistinctFrom(field string, value interface{}) IIdentifier {
+	return ib.addCriteria(FilterCriteria{
+		Field:    field,
+		Operator: FilterOperatorIsNotDistinctFrom,
+		Value:    value,
+	})
+}
+
+// Contains adds a filter condition for JSON/array field containment.
+// The SQL applier renders it as "field @> value", so the value must have the same shape
+// as the column: to test membership of a single element, pass it wrapped in an array.
 func (ib *IdentifierBuilder) Contains(field string, value interface{}) IIdentifier {
 	return ib.addCriteria(FilterCriteria{
 		Field:    field,
@@ -200,6 +290,39 @@ func (ib *IdentifierBuilder) Or(other IIdentifier) IIdentifier {
 	return newBuilder
 }
 
+// AndGroup combines the current builder with another identifier using AND logic,
+// wrapping the other identifier's criteria in a single parenthesized group
+func (ib *IdentifierBuilder) AndGroup(other IIdentifier) IIdentifier {
+	return ib.addGroup(other, LogicalOperatorAnd)
+}
+
+// OrGroup combines the current builder with another identifier using OR logic,
+// wrapping the other identifier's criteria in a single parenthesized group.
+// Unlike Or, precedence is preserved: a.OrGroup(b.Or(c)) yields "a OR (b OR c)".
+func (ib *IdentifierBuilder) OrGroup(other IIdentifier) IIdentifier {
+	return ib.addGroup(other, LogicalOperatorOr)
+}
+
+// addGroup appends the other identifier's criteria as a nested group joined with op
+func (ib *IdentifierBuilder) addGroup(other IIdentifier, op LogicalOperator) IIdentifier {
+	if other == nil {
+		return ib
+	}
+
+	otherCriteria := other.ToFilterCriteria()
+	if len(otherCriteria) == 0 {
+		return ib
+	}
+
+	newBuilder := ib.clone()
+	if len(newBuilder.criteria) > 0 {
+		newBuilder.criteria[len(newBuilder.criteria)-1].LogicalOp = op
+	}
+
+	newBuilder.criteria = append(newBuilder.criteria, FilterCriteria{Group: otherCriteria})
+	return newBuilder
+}
+
 // ToFilterCriteria returns the accumulated filter criteria as a slice
 func (ib *IdentifierBuilder) ToFilterCriteria() []FilterCriteria {
 	ib.mutex.RLock()
@@ -214,6 +337,37 @@ func (ib *IdentifierBuilder) ToFilterCriteria() []FilterCriteria {
 	return result
 }
 
+// Clone returns an independent deep copy of the builder, including nested groups and
+// value slices, so a base identifier can be built once and extended per request
+func (ib *IdentifierBuilder) Clone() IIdentifier {
+	ib.mutex.RLock()
+	defer ib.mutex.RUnlock()
+
+	return &IdentifierBuilder{
+		criteria: cloneCriteria(ib.criteria),
+	}
+}
+
+// cloneCriteria deep-copies criteria, their Values and nested Groups
+func cloneCriteria(criteria []FilterCriteria) []FilterCriteria {
+	cloned := make([]FilterCriteria, len(criteria))
+	for i, c := range criteria {
+		cloned[i] = c
+		if c.Values != nil {
+			cloned[i].Values = append([]interface{}(nil), c.Values...)
+		}
+		if c.Group != nil {
+			cloned[i].Group = cloneCriteria(c.Group)
+		}
+		if c.Subquery != nil {
+			sub := *c.Subquery
+			sub.Filters = cloneCriteria(sub.Filters)
+			cloned[i].Subquery = &sub
+		}
+	}
+	return cloned
+}
+
 // Reset clears all filter criteria and returns a fresh builder
 func (ib *IdentifierBuilder) Reset() IIdentifier {
 	return NewIdentifier()
@@ -1,6 +1,7 @@
 package identifier
 
 import (
+	"encoding/json"
 	"reflect"
 	"sync"
 	"testing"
@@ -226,6 +227,45 @@ func TestIdentifierBuilder_NotIn(t *testing.T) {
 	}
 }
 
+// TestIdentifierBuilder_NotInSubquery validates that the subquery is stored with its filters
+func TestIdentifierBuilder_NotInSubquery(t *testing.T) {
+	// Arrange
+	sub := NewSubquery("bans", "user_id", NewIdentifier().Equal("active", true))
+
+	// Act
+	filters := NewIdentifier().NotInSubquery("id", sub).ToFilterCriteria()
+
+	// Assert
+	if len(filters) != 1 {
+		t.Fatalf("Expected 1 filter, got %d", len(filters))
+	}
+	filter := filters[0]
+	if filter.Operator != FilterOperatorNotInSubquery || filter.Field != "id" {
+		t.Errorf("Expected a not_in_subquery filter on id, got %+v", filter)
+	}
+	if filter.Subquery == nil || !reflect.DeepEqual(*filter.Subquery, sub) {
+		t.Errorf("Expected subquery %+v, got %+v", sub, filter.Subquery)
+	}
+}
+
+// TestFilterCriteria_SubqueryNotBound validates that a subquery cannot be supplied through JSON
+func TestFilterCriteria_SubqueryNotBound(t *testing.T) {
+	// Arrange
+	body := `{"field":"id","operator":"in_subquery","subquery":{"Table":"secrets","Column":"user_id"}}`
+
+	// Act
+	var criteria FilterCriteria
+	err := json.Unmarshal([]byte(body), &criteria)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if criteria.Subquery != nil {
+		t.Errorf("Expected the subquery to be ignored, got %+v", criteria.Subquery)
+	}
+}
+
 func TestIdentifierBuilder_Between(t *testing.T) {
 	// Arrange
 	identifier := NewIdentifier()
@@ -261,6 +301,31 @@ func TestIdentifierBuilder_Between(t *testing.T) {
 	}
 }
 
+// TestIdentifierBuilder_ValueInColumnRange validates that the value and both columns are recorded
+func TestIdentifierBuilder_ValueInColumnRange(t *testing.T) {
+	// Arrange
+	identifier := NewIdentifier()
+
+	// Act
+	result := identifier.ValueInColumnRange(15, "starts_at", "ends_at")
+
+	// Assert
+	filters := result.ToFilterCriteria()
+	if len(filters) != 1 {
+		t.Fatalf("Expected 1 filter, got %d", len(filters))
+	}
+	filter := filters[0]
+	if filter.Operator != FilterOperatorValueInColumnRange {
+		t.Errorf("Expected operator %s, got %s", FilterOperatorValueInColumnRange, filter.Operator)
+	}
+	if filter.Field != "starts_at" || filter.EndField != "ends_at" {
+		t.Errorf("Expected columns starts_at and ends_at, got %s and %s", filter.Field, filter.EndField)
+	}
+	if filter.Value != 15 {
+		t.Errorf("Expected value 15, got %v", filter.Value)
+	}
+}
+
 func TestIdentifierBuilder_NullChecks(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -361,6 +426,94 @@ func TestIdentifierBuilder_JSONOperators(t *testing.T) {
 	}
 }
 
+func TestIdentifierBuilder_RegexOperators(t *testing.T) {
+	tests := []struct {
+		name             string
+		operation        func(IIdentifier) IIdentifier
+		expectedOperator FilterOperator
+	}{
+		{
+			name: "Regex",
+			operation: func(id IIdentifier) IIdentifier {
+				return id.Regex("name", "^[A-Z].*%")
+			},
+			expectedOperator: FilterOperatorRegex,
+		},
+		{
+			name: "RegexInsensitive",
+			operation: func(id IIdentifier) IIdentifier {
+				return id.RegexInsensitive("name", "^[A-Z].*%")
+			},
+			expectedOperator: FilterOperatorRegexInsensitive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := tt.operation(NewIdentifier())
+
+			// Assert
+			filters := result.ToFilterCriteria()
+			if len(filters) != 1 {
+				t.Fatalf("Expected 1 filter, got %d", len(filters))
+			}
+			if filters[0].Operator != tt.expectedOperator {
+				t.Errorf("Expected operator %s, got %s", tt.expectedOperator, filters[0].Operator)
+			}
+			// Pattern must be passed through unescaped
+			if filters[0].Value != "^[A-Z].*%" {
+				t.Errorf("Expected unescaped pattern, got %v", filters[0].Value)
+			}
+		})
+	}
+}
+
+func TestIdentifierBuilder_NullSafeOperators(t *testing.T) {
+	tests := []struct {
+		name             string
+		operation        func(IIdentifier) IIdentifier
+		expectedOperator FilterOperator
+	}{
+		{
+			name: "IsDistinctFrom",
+			operation: func(id IIdentifier) IIdentifier {
+				return id.IsDistinctFrom("email", nil)
+			},
+			expectedOperator: FilterOperatorIsDistinctFrom,
+		},
+		{
+			name: "IsNotDistinctFrom",
+			operation: func(id IIdentifier) IIdentifier {
+				return id.IsNotDistinctFrom("email", nil)
+			},
+			expectedOperator: FilterOperatorIsNotDistinctFrom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := tt.operation(NewIdentifier())
+
+			// Assert
+			filters := result.ToFilterCriteria()
+			if len(filters) != 1 {
+				t.Fatalf("Expected 1 filter, got %d", len(filters))
+			}
+			if filters[0].Operator != tt.expectedOperator {
+				t.Errorf("Expected operator %s, got %s", tt.expectedOperator, filters[0].Operator)
+			}
+			if filters[0].Field != "email" {
+				t.Errorf("Expected field 'email', got %s", filters[0].Field)
+			}
+			if filters[0].Value != nil {
+				t.Errorf("Expected nil value, got %v", filters[0].Value)
+			}
+		})
+	}
+}
+
 func TestIdentifierBuilder_And(t *testing.T) {
 	// Arrange
 	id1 := NewIdentifier().Equal("name", "test")
@@ -404,6 +557,95 @@ func TestIdentifierBuilder_Or(t *testing.T) {
 	// The exact structure depends on how OR is implemented in the builder
 }
 
+func TestIdentifierBuilder_InOrNull(t *testing.T) {
+	// Arrange
+	base := NewIdentifier().Equal("name", "A")
+	values := []interface{}{"active", "pending"}
+
+	// Act
+	result := base.InOrNull("status", values)
+
+	// Assert
+	filters := result.ToFilterCriteria()
+	if len(filters) != 2 {
+		t.Fatalf("Expected 2 top-level filters, got %d", len(filters))
+	}
+	if filters[0].LogicalOp != LogicalOperatorAnd {
+		t.Errorf("Expected AND before the group, got %s", filters[0].LogicalOp)
+	}
+	group := filters[1].Group
+	if len(group) != 2 {
+		t.Fatalf("Expected a group of 2 criteria, got %d", len(group))
+	}
+	if group[0].Operator != FilterOperatorIn || len(group[0].Values) != 2 || group[0].LogicalOp != LogicalOperatorOr {
+		t.Errorf("Expected IN joined with OR, got %+v", group[0])
+	}
+	if group[1].Operator != FilterOperatorIsNull || group[1].Field != "status" {
+		t.Errorf("Expected IS NULL on status, got %+v", group[1])
+	}
+}
+
+func TestIdentifierBuilder_GroupOperators(t *testing.T) {
+	tests := []struct {
+		name       string
+		operation  func(base, other IIdentifier) IIdentifier
+		expectedOp LogicalOperator
+	}{
+		{
+			name:       "AndGroup",
+			operation:  func(base, other IIdentifier) IIdentifier { return base.AndGroup(other) },
+			expectedOp: LogicalOperatorAnd,
+		},
+		{
+			name:       "OrGroup",
+			operation:  func(base, other IIdentifier) IIdentifier { return base.OrGroup(other) },
+			expectedOp: LogicalOperatorOr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			base := NewIdentifier().Equal("a", 1)
+			other := NewIdentifier().Equal("b", 2).Or(NewIdentifier().Equal("c", 3))
+
+			// Act
+			result := tt.operation(base, other)
+
+			// Assert
+			filters := result.ToFilterCriteria()
+			if len(filters) != 2 {
+				t.Fatalf("Expected 2 top-level filters, got %d", len(filters))
+			}
+			if filters[0].LogicalOp != tt.expectedOp {
+				t.Errorf("Expected logical operator %s, got %s", tt.expectedOp, filters[0].LogicalOp)
+			}
+			if len(filters[1].Group) != 2 {
+				t.Fatalf("Expected a group of 2 criteria, got %d", len(filters[1].Group))
+			}
+			if filters[1].Group[0].LogicalOp != LogicalOperatorOr {
+				t.Errorf("Expected OR inside the group, got %s", filters[1].Group[0].LogicalOp)
+			}
+			if len(base.ToFilterCriteria()) != 1 {
+				t.Error("Expected the original identifier to be unchanged")
+			}
+		})
+	}
+}
+
+func TestIdentifierBuilder_GroupWithEmpty(t *testing.T) {
+	// Arrange
+	identifier := NewIdentifier().Equal("field", "value")
+
+	// Act & Assert
+	if identifier.OrGroup(nil) != identifier {
+		t.Error("OrGroup with nil should return the original identifier")
+	}
+	if identifier.AndGroup(NewIdentifier()) != identifier {
+		t.Error("AndGroup with an empty identifier should return the original identifier")
+	}
+}
+
 func TestIdentifierBuilder_AndWithNil(t *testing.T) {
 	// Arrange
 	identifier := NewIdentifier().Equal("field", "value")
@@ -584,3 +826,65 @@ func TestIdentifierBuilder_ThreadSafety(t *testing.T) {
 		t.Errorf("Original identifier was modified during concurrent access")
 	}
 }
+
+func TestIdentifierBuilder_Clone(t *testing.T) {
+	// Arrange
+	var base IIdentifier = NewIdentifier().
+		Equal("tenant_id", 1).
+		In("status", []interface{}{"active", "pending"}).
+		AndGroup(NewIdentifier().Equal("a", 1).Or(NewIdentifier().Equal("b", 2)))
+
+	// Act
+	clone := base.Clone()
+	extended := clone.Equal("name", "John")
+	cloneCriteria := clone.ToFilterCriteria()
+	cloneCriteria[1].Values[0] = "mutated"
+	cloneCriteria[2].Group[0].Field = "mutated"
+
+	// Assert
+	original := base.ToFilterCriteria()
+	if len(original) != 3 {
+		t.Fatalf("Expected original to keep 3 filters, got %d", len(original))
+	}
+	if len(extended.ToFilterCriteria()) != 4 {
+		t.Errorf("Expected extended clone to have 4 filters, got %d", len(extended.ToFilterCriteria()))
+	}
+	if original[1].Values[0] != "active" {
+		t.Errorf("Expected original values to be unchanged, got %v", original[1].Values[0])
+	}
+	if original[2].Group[0].Field != "a" {
+		t.Errorf("Expected original group to be unchanged, got %s", original[2].Group[0].Field)
+	}
+}
+
+// TestIdentifierFromCriteria validates that criteria round-trip through ToFilterCriteria and
+// that the rebuilt identifier is independent of the source slice
+func TestIdentifierFromCriteria(t *testing.T) {
+	// Arrange
+	original := NewIdentifier().
+		Equal("tenant_id", 1).
+		Between("age", 18, 65).
+		AndGroup(NewIdentifier().Equal("status", "active").Or(NewIdentifier().IsNull("manager"))).
+		OrGroup(NewIdentifier().In("role", []interface{}{"admin", "owner"}))
+	criteria := original.ToFilterCriteria()
+
+	// Act
+	rebuilt := IdentifierFromCriteria(criteria)
+	roundTripped := rebuilt.ToFilterCriteria()
+	extended := rebuilt.Equal("name", "John")
+
+	// Assert
+	if !reflect.DeepEqual(roundTripped, criteria) {
+		t.Errorf("Expected %+v, got %+v", criteria, roundTripped)
+	}
+	criteria[2].Group[0].Field = "mutated"
+	if rebuilt.ToFilterCriteria()[2].Group[0].Field != "status" {
+		t.Errorf("Expected the rebuilt identifier to be independent of the source criteria")
+	}
+	if len(extended.ToFilterCriteria()) != 5 {
+		t.Errorf("Expected the rebuilt identifier to keep chaining, got %d filters", len(extended.ToFilterCriteria()))
+	}
+	if IdentifierFromCriteria(nil).ToFilterCriteria() != nil {
+		t.Errorf("Expected no criteria from a nil slice")
+	}
+}
@@ -12,9 +12,16 @@ type FilterCriteria struct {
 	// Value is the value to compare against (can be nil for null checks)
 	Value interface{} `json:"value,omitempty"`
 
+	// EndField is the second column for operators comparing against a column-defined range
+	EndField string `json:"endField,omitempty"`
+
 	// Values is used for operators that require multiple values (IN, NOT_IN, BETWEEN)
 	Values []interface{} `json:"values,omitempty"`
 
+	// Subquery selects the values compared by the subquery operators (IN_SUBQUERY, NOT_IN_SUBQUERY).
+	// It is deliberately not bound from requests, since it names an arbitrary table to read.
+	Subquery *Subquery `json:"-"`
+
 	// LogicalOp defines how this criteria combines with the next one (AND/OR)
 	// This is used when multiple criteria are present in a list
 	LogicalOp LogicalOperator `json:"logicalOp,omitempty"`
@@ -16,12 +16,26 @@ type IIdentifier interface {
 	Like(field string, pattern string) IIdentifier
 	In(field string, values []interface{}) IIdentifier
 	NotIn(field string, values []interface{}) IIdentifier
+	InOrNull(field string, values []interface{}) IIdentifier
 	Between(field string, start, end interface{}) IIdentifier
+	ValueInColumnRange(value interface{}, startCol, endCol string) IIdentifier
+
+	// Membership in the values selected by a subquery
+	InSubquery(field string, sub Subquery) IIdentifier
+	NotInSubquery(field string, sub Subquery) IIdentifier
+
+	// Regular expression matching (case-sensitive and case-insensitive)
+	Regex(field string, pattern string) IIdentifier
+	RegexInsensitive(field string, pattern string) IIdentifier
 
 	// Null checks
 	IsNull(field string) IIdentifier
 	IsNotNull(field string) IIdentifier
 
+	// NULL-safe comparisons, where NULL equals NULL and differs from any non-NULL value
+	IsDistinctFrom(field string, value interface{}) IIdentifier
+	IsNotDistinctFrom(field string, value interface{}) IIdentifier
+
 	// JSON and advanced operations
 	Contains(field string, value interface{}) IIdentifier
 	Has(field string) IIdentifier
@@ -30,7 +44,12 @@ type IIdentifier interface {
 	And(other IIdentifier) IIdentifier
 	Or(other IIdentifier) IIdentifier
 
+	// Grouped logical operations that keep the other identifier's criteria parenthesized
+	AndGroup(other IIdentifier) IIdentifier
+	OrGroup(other IIdentifier) IIdentifier
+
 	// Conversion and utility methods
 	ToFilterCriteria() []FilterCriteria
+	Clone() IIdentifier
 	Reset() IIdentifier
 }
@@ -0,0 +1,366 @@
+package identifier
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+)
+
+// MatchCriteria evaluates criteria against an already-loaded struct (or pointer to struct)
+// with the same semantics the SQL appliers give them, so a single filter spec can be used
+// both in queries and on in-memory slices. Criteria are combined through the LogicalOp of
+// the preceding criterion, with AND binding tighter than OR, and groups are evaluated as
+// parenthesized sub-expressions. An empty criteria list matches every entity.
+//
+// Fields are resolved by column tag, JSON name or Go name, ignoring case and underscores
+// ("created_at" and "createdAt" both find CreatedAt), including fields of embedded structs.
+// NULL follows SQL rules: a NULL field (nil pointer or a driver.Valuer returning nil, such
+// as an invalid gorm.DeletedAt) never satisfies a comparison, only IsNull and the
+// distinct-from operators. The JSON operators contains and has, and the subquery operators,
+// are not supported.
+func MatchCriteria(entity interface{}, criteria []FilterCriteria) (bool, error) {
+	value := reflect.ValueOf(entity)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return false, domainerrors.NewValidationError("entity", "cannot match criteria against a nil entity")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return false, domainerrors.NewValidationError("entity", fmt.Sprintf("cannot match criteria against %s", value.Kind()))
+	}
+	return matchAll(value, criteria)
+}
+
+// matchAll evaluates a criteria list as a disjunction of AND-chains
+func matchAll(entity reflect.Value, criteria []FilterCriteria) (bool, error) {
+	if len(criteria) == 0 {
+		return true, nil
+	}
+
+	result := false
+	chain := true
+	for i, criterion := range criteria {
+		if i > 0 && criteria[i-1].LogicalOp == LogicalOperatorOr {
+			result = result || chain
+			chain = true
+		}
+
+		matched, err := matchOne(entity, criterion)
+		if err != nil {
+			return false, err
+		}
+		chain = chain && matched
+	}
+	return result || chain, nil
+}
+
+// matchOne evaluates a single criterion or group
+func matchOne(entity reflect.Value, criterion FilterCriteria) (bool, error) {
+	if len(criterion.Group) > 0 {
+		return matchAll(entity, criterion.Group)
+	}
+
+	field, ok := lookupStructField(entity, criterion.Field)
+	if !ok {
+		return false, domainerrors.NewValidationError(criterion.Field, "unknown field")
+	}
+	actual, err := fieldValue(field)
+	if err != nil {
+		return false, err
+	}
+
+	switch criterion.Operator {
+	case FilterOperatorIsNull:
+		return actual == nil, nil
+	case FilterOperatorIsNotNull:
+		return actual != nil, nil
+	case FilterOperatorIsDistinctFrom, FilterOperatorIsNotDistinctFrom:
+		expected := normalizeMatchValue(criterion.Value)
+		same := actual == nil && expected == nil
+		if actual != nil && expected != nil {
+			same = valuesEqual(actual, expected)
+		}
+		return same == (criterion.Operator == FilterOperatorIsNotDistinctFrom), nil
+	}
+
+	if actual == nil {
+		// NULL never satisfies a comparison
+		return false, nil
+	}
+
+	switch criterion.Operator {
+	case FilterOperatorEqual:
+		return valuesEqual(actual, normalizeMatchValue(criterion.Value)), nil
+	case FilterOperatorNotEqual:
+		expected := normalizeMatchValue(criterion.Value)
+		return expected != nil && !valuesEqual(actual, expected), nil
+	case FilterOperatorGreaterThan, FilterOperatorGreaterEqual, FilterOperatorLessThan, FilterOperatorLessEqual:
+		cmp, err := compareValues(criterion.Field, actual, normalizeMatchValue(criterion.Value))
+		if err != nil {
+			return false, err
+		}
+		switch criterion.Operator {
+		case FilterOperatorGreaterThan:
+			return cmp > 0, nil
+		case FilterOperatorGreaterEqual:
+			return cmp >= 0, nil
+		case FilterOperatorLessThan:
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+	case FilterOperatorIn, FilterOperatorNotIn:
+		found := false
+		for _, candidate := range criterion.Values {
+			if valuesEqual(actual, normalizeMatchValue(candidate)) {
+				found = true
+				break
+			}
+		}
+		return found == (criterion.Operator == FilterOperatorIn), nil
+	case FilterOperatorBetween:
+		if len(criterion.Values) < 2 {
+			return false, domainerrors.NewValidationError(criterion.Field, "between requires two values")
+		}
+		low, err := compareValues(criterion.Field, actual, normalizeMatchValue(criterion.Values[0]))
+		if err != nil {
+			return false, err
+		}
+		high, err := compareValues(criterion.Field, actual, normalizeMatchValue(criterion.Values[1]))
+		if err != nil {
+			return false, err
+		}
+		return low >= 0 && high <= 0, nil
+	case FilterOperatorValueInColumnRange:
+		endField, ok := lookupStructField(entity, criterion.EndField)
+		if !ok {
+			return false, domainerrors.NewValidationError(criterion.EndField, "unknown field")
+		}
+		end, err := fieldValue(endField)
+		if err != nil || end == nil {
+			return false, err
+		}
+		expected := normalizeMatchValue(criterion.Value)
+		low, err := compareValues(criterion.Field, expected, actual)
+		if err != nil {
+			return false, err
+		}
+		high, err := compareValues(criterion.EndField, expected, end)
+		if err != nil {
+			return false, err
+		}
+		return low >= 0 && high <= 0, nil
+	case FilterOperatorLike:
+		return matchPattern(criterion.Field, actual, criterion.Value, likePattern)
+	case FilterOperatorRegex:
+		return matchPattern(criterion.Field, actual, criterion.Value, func(p string) string { return p })
+	case FilterOperatorRegexInsensitive:
+		return matchPattern(criterion.Field, actual, criterion.Value, func(p string) string { return "(?i)" + p })
+	}
+	return false, domainerrors.NewValidationError(criterion.Field, fmt.Sprintf("operator %q cannot be evaluated in memory", criterion.Operator))
+}
+
+// lookupStructField finds the field named name, searching embedded structs depth-first
+func lookupStructField(entity reflect.Value, name string) (reflect.Value, bool) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	key := matchKey(name)
+
+	entityType := entity.Type()
+	for i := 0; i < entityType.NumField(); i++ {
+		structField := entityType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		if structField.Anonymous && structField.Type.Kind() == reflect.Struct {
+			if found, ok := lookupStructField(entity.Field(i), name); ok {
+				return found, true
+			}
+			continue
+		}
+		for _, candidate := range fieldNames(structField) {
+			if matchKey(candidate) == key {
+				return entity.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// fieldNames lists the names a struct field answers to: Go name, column tag and JSON name
+func fieldNames(field reflect.StructField) []string {
+	names := []string{field.Name}
+	for _, setting := range strings.Split(field.Tag.Get("gorm"), ";") {
+		if column, ok := strings.CutPrefix(strings.TrimSpace(setting), "column:"); ok {
+			names = append(names, column)
+		}
+	}
+	if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName != "" && jsonName != "-" {
+		names = append(names, jsonName)
+	}
+	return names
+}
+
+// matchKey normalizes a field name so snake_case, camelCase and Go names compare equal
+func matchKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// fieldValue dereferences pointers and driver.Valuer types, returning nil for NULL
+func fieldValue(field reflect.Value) (interface{}, error) {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return nil, nil
+		}
+		if valuer, ok := field.Interface().(driver.Valuer); ok {
+			return valuerValue(valuer)
+		}
+		field = field.Elem()
+	}
+	if valuer, ok := field.Interface().(driver.Valuer); ok {
+		return valuerValue(valuer)
+	}
+	return normalizeMatchValue(field.Interface()), nil
+}
+
+// valuerValue resolves a driver.Valuer such as sql.NullString to its plain value
+func valuerValue(valuer driver.Valuer) (interface{}, error) {
+	value, err := valuer.Value()
+	if err != nil {
+		return nil, err
+	}
+	return normalizeMatchValue(value), nil
+}
+
+// normalizeMatchValue widens numbers to int64 or float64 and dereferences pointers so values
+// of different Go types compare the way the database would compare them
+func normalizeMatchValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if t, ok := value.(time.Time); ok {
+		return t
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return normalizeMatchValue(v.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return value
+}
+
+// valuesEqual compares two normalized values, treating integers and floats as numbers
+func valuesEqual(a, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	if af, ok := asFloat(a); ok {
+		bf, ok := asFloat(b)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareValues orders two normalized values of comparable kinds
+func compareValues(field string, a, b interface{}) (int, error) {
+	if b == nil {
+		return 0, domainerrors.NewValidationError(field, "cannot compare against NULL")
+	}
+	switch av := a.(type) {
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Compare(bv), nil
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), nil
+		}
+	default:
+		if af, ok := asFloat(a); ok {
+			if bf, ok := asFloat(b); ok {
+				switch {
+				case af < bf:
+					return -1, nil
+				case af > bf:
+					return 1, nil
+				}
+				return 0, nil
+			}
+		}
+	}
+	return 0, domainerrors.NewValidationError(field, fmt.Sprintf("cannot compare %T with %T", a, b))
+}
+
+// asFloat returns numeric values as float64
+func asFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// matchPattern matches a string field against a LIKE or regular expression pattern
+func matchPattern(field string, actual, pattern interface{}, toRegexp func(string) string) (bool, error) {
+	text, ok := actual.(string)
+	if !ok {
+		return false, domainerrors.NewValidationError(field, fmt.Sprintf("cannot pattern match %T", actual))
+	}
+	patternText, ok := normalizeMatchValue(pattern).(string)
+	if !ok {
+		return false, domainerrors.NewValidationError(field, "pattern must be a string")
+	}
+	re, err := regexp.Compile(toRegexp(patternText))
+	if err != nil {
+		return false, domainerrors.NewValidationError(field, fmt.Sprintf("invalid pattern: %v", err))
+	}
+	return re.MatchString(text), nil
+}
+
+// likePattern converts a SQL LIKE pattern (% and _ wildcards, backslash escapes) to an anchored regexp
+func likePattern(pattern string) string {
+	var re strings.Builder
+	re.WriteString("(?s)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			re.WriteString(".*")
+		case r == '_':
+			re.WriteString(".")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString("$")
+	return re.String()
+}
@@ -0,0 +1,126 @@
+package identifier
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+)
+
+// MatchBase mimics an embedded base entity; it is exported so its promoted fields are readable via reflection
+type MatchBase struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// matchEntity is the struct evaluated by the MatchCriteria tests
+type matchEntity struct {
+	MatchBase
+	Name      string         `gorm:"column:name" json:"name"`
+	Age       int            `gorm:"column:age" json:"age"`
+	Status    string         `gorm:"column:status" json:"status"`
+	Manager   *string        `gorm:"column:manager_name" json:"manager"`
+	Nickname  sql.NullString `gorm:"column:nickname" json:"nickname"`
+	Score     float64        `json:"score"`
+	IsPremium bool           `json:"is_premium"`
+}
+
+// TestMatchCriteria validates in-memory evaluation of the core operators and their combination
+func TestMatchCriteria(t *testing.T) {
+	created := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	entity := &matchEntity{
+		MatchBase: MatchBase{ID: 7, CreatedAt: created},
+		Name:      "Alice_Smith",
+		Age:       30,
+		Status:    "active",
+		Score:     4.5,
+		IsPremium: true,
+	}
+
+	tests := []struct {
+		name     string
+		ident    IIdentifier
+		expected bool
+	}{
+		{"Empty criteria", NewIdentifier(), true},
+		{"Equal", NewIdentifier().Equal("status", "active"), true},
+		{"Equal mismatch", NewIdentifier().Equal("status", "inactive"), false},
+		{"Equal across numeric types", NewIdentifier().Equal("age", int64(30)), true},
+		{"Equal bool", NewIdentifier().Equal("isPremium", true), true},
+		{"Embedded field", NewIdentifier().Equal("id", 7), true},
+		{"Not equal", NewIdentifier().NotEqual("status", "inactive"), true},
+		{"Greater than", NewIdentifier().GreaterThan("age", 25), true},
+		{"Greater than float", NewIdentifier().GreaterThan("score", 4.5), false},
+		{"Less or equal", NewIdentifier().LessOrEqual("age", 30), true},
+		{"Time comparison", NewIdentifier().GreaterThan("createdAt", created.Add(-time.Hour)), true},
+		{"In", NewIdentifier().In("status", []interface{}{"pending", "active"}), true},
+		{"In mismatch", NewIdentifier().In("status", []interface{}{"pending"}), false},
+		{"Not in", NewIdentifier().NotIn("age", []interface{}{18, 21}), true},
+		{"Between", NewIdentifier().Between("age", 18, 30), true},
+		{"Value in column range", NewIdentifier().ValueInColumnRange(10, "score", "age"), true},
+		{"Value outside column range", NewIdentifier().ValueInColumnRange(31, "score", "age"), false},
+		{"Is null pointer", NewIdentifier().IsNull("manager_name"), true},
+		{"Is null valuer", NewIdentifier().IsNull("nickname"), true},
+		{"Is not null", NewIdentifier().IsNotNull("name"), true},
+		{"Null never equals", NewIdentifier().NotEqual("manager", "Bob"), false},
+		{"Like", NewIdentifier().Like("name", "Alice%"), true},
+		{"Like escaped wildcard", NewIdentifier().Like("name", `Alice\_S%`), true},
+		{"Like is anchored", NewIdentifier().Like("name", "Smith"), false},
+		{"AND chain", NewIdentifier().Equal("status", "active").GreaterThan("age", 40), false},
+		{"OR", NewIdentifier().Equal("status", "inactive").Or(NewIdentifier().Equal("age", 30)), true},
+		{
+			"AND binds tighter than OR",
+			NewIdentifier().Equal("status", "inactive").Or(NewIdentifier().Equal("age", 30).Equal("name", "Bob")),
+			false,
+		},
+		{
+			"Group",
+			NewIdentifier().Equal("status", "active").AndGroup(NewIdentifier().Equal("age", 18).Or(NewIdentifier().IsNull("manager_name"))),
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			matched, err := MatchCriteria(entity, tt.ident.ToFilterCriteria())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if matched != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, matched)
+			}
+		})
+	}
+}
+
+// TestMatchCriteria_Errors validates that criteria that cannot be evaluated are reported
+func TestMatchCriteria_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		entity interface{}
+		ident  IIdentifier
+	}{
+		{"Unknown field", matchEntity{}, NewIdentifier().Equal("missing", 1)},
+		{"Incomparable types", matchEntity{}, NewIdentifier().GreaterThan("name", 3)},
+		{"Unsupported operator", matchEntity{}, NewIdentifier().Contains("name", "x")},
+		{"Not a struct", 42, NewIdentifier().Equal("id", 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := MatchCriteria(tt.entity, tt.ident.ToFilterCriteria())
+
+			// Assert
+			var validationErr *domainerrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected validation error, got: %v", err)
+			}
+		})
+	}
+}
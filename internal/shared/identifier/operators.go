@@ -19,6 +19,21 @@ const (
 	FilterOperatorBetween      FilterOperator = "between"
 	FilterOperatorContains     FilterOperator = "contains"
 	FilterOperatorHas          FilterOperator = "has"
+
+	// Regular expression operators (pattern is passed through unescaped)
+	FilterOperatorRegex            FilterOperator = "regex"
+	FilterOperatorRegexInsensitive FilterOperator = "iregex"
+
+	// NULL-safe comparison operators (NULL is treated as a comparable value)
+	FilterOperatorIsDistinctFrom    FilterOperator = "is_distinct_from"
+	FilterOperatorIsNotDistinctFrom FilterOperator = "is_not_distinct_from"
+
+	// Range containment of a value within two columns (Field and EndField)
+	FilterOperatorValueInColumnRange FilterOperator = "value_in_column_range"
+
+	// Membership in the column values selected by a Subquery
+	FilterOperatorInSubquery    FilterOperator = "in_subquery"
+	FilterOperatorNotInSubquery FilterOperator = "not_in_subquery"
 )
 
 // LogicalOperator defines how multiple filter criteria are combined
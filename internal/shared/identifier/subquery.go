@@ -0,0 +1,25 @@
+package identifier
+
+// Subquery describes "SELECT Column FROM Table WHERE Filters" for the InSubquery and
+// NotInSubquery filters. It is applied as written, so soft-deleted rows of Table are only
+// excluded when Filters say so, e.g. with IsNull("deleted_at").
+type Subquery struct {
+	// Table is the table the values are selected from
+	Table string `json:"table"`
+
+	// Column is the single column selected from Table
+	Column string `json:"column"`
+
+	// Filters restrict the rows of Table whose Column is selected, all rows when empty
+	Filters []FilterCriteria `json:"filters,omitempty"`
+}
+
+// NewSubquery creates a subquery selecting column from table for the rows matching filters,
+// which may be nil to select from every row
+func NewSubquery(table, column string, filters IIdentifier) Subquery {
+	sub := Subquery{Table: table, Column: column}
+	if filters != nil {
+		sub.Filters = filters.ToFilterCriteria()
+	}
+	return sub
+}
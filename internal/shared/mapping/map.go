@@ -0,0 +1,28 @@
+// Package mapping provides small generic helpers for transforming query results,
+// such as converting entities into DTOs in service layers.
+package mapping
+
+// Map applies fn to every item and returns the results in the same order.
+// A nil input yields a nil result so JSON encoding of empty results is unchanged.
+func Map[T, R any](items []T, fn func(T) R) []R {
+	if items == nil {
+		return nil
+	}
+
+	result := make([]R, len(items))
+	for i, item := range items {
+		result[i] = fn(item)
+	}
+	return result
+}
+
+// Values dereferences every pointer, turning []*T into []T. Nil pointers become zero values.
+func Values[T any](items []*T) []T {
+	return Map(items, func(item *T) T {
+		if item == nil {
+			var zero T
+			return zero
+		}
+		return *item
+	})
+}
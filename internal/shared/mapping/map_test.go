@@ -0,0 +1,69 @@
+package mapping
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// entityDTO is a transport representation of testutil.TestEntity
+type entityDTO struct {
+	ID    int
+	Label string
+}
+
+// TestMap validates mapping entities to DTOs
+func TestMap(t *testing.T) {
+	// Arrange
+	entities := []*testutil.TestEntity{{Name: "first"}, {Name: "second"}}
+	entities[0].ID, entities[1].ID = 1, 2
+
+	// Act
+	dtos := Map(entities, func(e *testutil.TestEntity) entityDTO {
+		return entityDTO{ID: e.GetID(), Label: strings.ToUpper(e.Name)}
+	})
+
+	// Assert
+	expected := []entityDTO{{ID: 1, Label: "FIRST"}, {ID: 2, Label: "SECOND"}}
+	if len(dtos) != len(expected) {
+		t.Fatalf("Expected %d DTOs, got %d", len(expected), len(dtos))
+	}
+	for i := range expected {
+		if dtos[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], dtos[i])
+		}
+	}
+}
+
+// TestMap_Nil validates that a nil input stays nil while an empty input stays empty
+func TestMap_Nil(t *testing.T) {
+	// Act
+	fromNil := Map[int, int](nil, func(i int) int { return i })
+	fromEmpty := Map([]int{}, func(i int) int { return i })
+
+	// Assert
+	if fromNil != nil {
+		t.Errorf("Expected nil result, got %v", fromNil)
+	}
+	if fromEmpty == nil || len(fromEmpty) != 0 {
+		t.Errorf("Expected empty non-nil result, got %v", fromEmpty)
+	}
+}
+
+// TestValues validates dereferencing pointer slices
+func TestValues(t *testing.T) {
+	// Arrange
+	entities := []*testutil.TestEntity{{Name: "first"}, nil}
+
+	// Act
+	values := Values(entities)
+
+	// Assert
+	if len(values) != 2 {
+		t.Fatalf("Expected 2 values, got %d", len(values))
+	}
+	if values[0].Name != "first" || values[1].Name != "" {
+		t.Errorf("Unexpected values: %+v", values)
+	}
+}
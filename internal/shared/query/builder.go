@@ -1,6 +1,9 @@
 package query
 
 import (
+	"sort"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
 )
@@ -37,6 +40,24 @@ func (qp *QueryParams[T]) PrepareDefaults() *QueryParams[T] {
 		qp.PageSize = 200
 	}
 
+	// Merge the compact sort string into Sort, skipping fields that are already sorted on
+	if qp.SortString != "" {
+		sorts, err := parseSortString(qp.SortString)
+		if err != nil && qp.err == nil {
+			qp.err = err
+		}
+		for _, sortField := range sorts {
+			if !qp.hasSortField(sortField.Field) {
+				qp.Sort = append(qp.Sort, sortField)
+			}
+		}
+	}
+
+	// OnlyDeleted takes precedence over IncludeDeleted, as it does in the appliers
+	if qp.OnlyDeleted {
+		qp.IncludeDeleted = false
+	}
+
 	// Calculate offset and limit for database queries
 	qp.Offset = (qp.Page - 1) * qp.PageSize
 	qp.Limit = qp.PageSize
@@ -55,7 +76,20 @@ func (qp *QueryParams[T]) PrepareDefaults() *QueryParams[T] {
 	return qp
 }
 
-// WithFilters applies filter criteria from an IIdentifier to the QueryParams
+// TotalPages returns how many pages of pageSize items are needed for totalItems.
+// A non-positive page size falls back to the default of 50.
+func TotalPages(totalItems int64, pageSize int) int {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if totalItems <= 0 {
+		return 0
+	}
+	return int((totalItems + int64(pageSize) - 1) / int64(pageSize))
+}
+
+// WithFilters applies filter criteria from an IIdentifier to the QueryParams, replacing any
+// existing filters. OR-combined identifiers such as a.OrGroup(b) keep their grouping.
 func (qp *QueryParams[T]) WithFilters(identifier identifier.IIdentifier) *QueryParams[T] {
 	if identifier != nil {
 		qp.Filters = identifier.ToFilterCriteria()
@@ -63,15 +97,87 @@ func (qp *QueryParams[T]) WithFilters(identifier identifier.IIdentifier) *QueryP
 	return qp
 }
 
-// AddSort adds a sort field to the query parameters
+// WithFiltersMap appends an Equal filter for each key/value pair of m, ANDed with the existing
+// filters in key order. Existing filters that contain an OR are first wrapped in a group, so
+// "a OR b" becomes "(a OR b) AND ...". It is a shortcut for simple equality filters only; use WithFilters with
+// an identifier for any other operator. Keys must be plain or dotted field names; an invalid
+// key is skipped and recorded as a ValidationError returned by Err.
+func (qp *QueryParams[T]) WithFiltersMap(m map[string]interface{}) *QueryParams[T] {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	filters := identifier.NewIdentifier()
+	for _, key := range keys {
+		if !queryFieldPattern.MatchString(key) {
+			if qp.err == nil {
+				qp.err = domainerrors.NewValidationError(key, "invalid field name")
+			}
+			continue
+		}
+		filters = filters.Equal(key, m[key])
+	}
+
+	for _, criteria := range qp.Filters {
+		if criteria.LogicalOp == identifier.LogicalOperatorOr {
+			qp.Filters = []identifier.FilterCriteria{{Group: qp.Filters}}
+			break
+		}
+	}
+	qp.Filters = append(qp.Filters, filters.ToFilterCriteria()...)
+	return qp
+}
+
+// WhereHas restricts results to entities with at least one related row matching the identifier.
+// A nil identifier matches any related row.
+func (qp *QueryParams[T]) WhereHas(relation string, identifier identifier.IIdentifier) *QueryParams[T] {
+	relationFilter := RelationFilter{Relation: relation}
+	if identifier != nil {
+		relationFilter.Filters = identifier.ToFilterCriteria()
+	}
+	qp.RelationFilters = append(qp.RelationFilters, relationFilter)
+	return qp
+}
+
+// WhereDoesntHave restricts results to entities without any related row matching the identifier.
+// A nil identifier excludes entities that have any related row at all.
+func (qp *QueryParams[T]) WhereDoesntHave(relation string, identifier identifier.IIdentifier) *QueryParams[T] {
+	qp.WhereHas(relation, identifier)
+	qp.RelationFilters[len(qp.RelationFilters)-1].Negate = true
+	return qp
+}
+
+// WhereRaw adds a raw SQL condition, ANDed with the other filters.
+// The caller is responsible for the safety of sql; values must be passed as args so they
+// are bound as parameters.
+func (qp *QueryParams[T]) WhereRaw(sql string, args ...interface{}) *QueryParams[T] {
+	qp.RawConditions = append(qp.RawConditions, RawCondition{SQL: sql, Args: args})
+	return qp
+}
+
+// AddSort adds a sort field to the query parameters.
+// The order is normalized (case and surrounding spaces); an invalid order is kept as given
+// so that query appliers reject it instead of interpolating it into SQL.
 func (qp *QueryParams[T]) AddSort(field string, order SortOrder) *QueryParams[T] {
 	qp.Sort = append(qp.Sort, SortField{
 		Field: field,
-		Order: order,
+		Order: order.Normalize(),
 	})
 	return qp
 }
 
+// hasSortField reports whether field is already among the sort fields
+func (qp *QueryParams[T]) hasSortField(field string) bool {
+	for _, sortField := range qp.Sort {
+		if sortField.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
 // AddSortAsc adds an ascending sort field
 func (qp *QueryParams[T]) AddSortAsc(field string) *QueryParams[T] {
 	return qp.AddSort(field, SortOrderAsc)
@@ -88,12 +194,42 @@ func (qp *QueryParams[T]) ClearSort() *QueryParams[T] {
 	return qp
 }
 
+// ClearFilters removes all filters, relation filters and raw conditions, leaving the
+// slices empty rather than nil, so the params can be reused for another query
+func (qp *QueryParams[T]) ClearFilters() *QueryParams[T] {
+	qp.Filters = make([]identifier.FilterCriteria, 0)
+	qp.RelationFilters = make([]RelationFilter, 0)
+	qp.RawConditions = make([]RawCondition, 0)
+	return qp
+}
+
+// ClearPreloads removes all preloaded relations, with or without conditions
+func (qp *QueryParams[T]) ClearPreloads() *QueryParams[T] {
+	qp.Preloads = make([]string, 0)
+	qp.PreloadSpecs = make([]PreloadSpec, 0)
+	return qp
+}
+
+// WithCountOnlyFirstPage computes the total count on the first page only;
+// later pages report a total of -1
+func (qp *QueryParams[T]) WithCountOnlyFirstPage() *QueryParams[T] {
+	qp.CountOnlyFirstPage = true
+	return qp
+}
+
 // WithSearch sets the search term
 func (qp *QueryParams[T]) WithSearch(searchTerm string) *QueryParams[T] {
 	qp.Search = searchTerm
 	return qp
 }
 
+// WithSearchFields sets the columns the search term is matched against.
+// Without search fields, the search term is matched against the ID.
+func (qp *QueryParams[T]) WithSearchFields(fields ...string) *QueryParams[T] {
+	qp.SearchFields = fields
+	return qp
+}
+
 // WithPreloads sets the preload relations
 func (qp *QueryParams[T]) WithPreloads(preloads []string) *QueryParams[T] {
 	qp.Preloads = preloads
@@ -106,9 +242,21 @@ func (qp *QueryParams[T]) AddPreload(preload string) *QueryParams[T] {
 	return qp
 }
 
-// WithDeletedVisibility sets the soft-delete visibility options
+// WithPreload adds a preload relation with optional conditions.
+// Nested relations use dot notation (e.g. "Orders.Items") and args are applied
+// to the preloaded relation (e.g. WithPreload("Orders", "status = ?", "paid")).
+func (qp *QueryParams[T]) WithPreload(relation string, args ...interface{}) *QueryParams[T] {
+	qp.PreloadSpecs = append(qp.PreloadSpecs, PreloadSpec{
+		Relation: relation,
+		Args:     args,
+	})
+	return qp
+}
+
+// WithDeletedVisibility sets the soft-delete visibility options. Setting both resolves to only
+// soft-deleted records, since onlyDeleted takes precedence over includeDeleted.
 func (qp *QueryParams[T]) WithDeletedVisibility(includeDeleted, onlyDeleted bool) *QueryParams[T] {
-	qp.IncludeDeleted = includeDeleted
+	qp.IncludeDeleted = includeDeleted && !onlyDeleted
 	qp.OnlyDeleted = onlyDeleted
 	return qp
 }
@@ -134,6 +282,25 @@ func (qp *QueryParams[T]) ExcludeDeletedRecords() *QueryParams[T] {
 	return qp
 }
 
+// WithoutDefaultScope skips the entity's default scope for this query
+func (qp *QueryParams[T]) WithoutDefaultScope() *QueryParams[T] {
+	qp.IgnoreDefaultScope = true
+	return qp
+}
+
+// WithForcePrimary routes this read to the primary database instead of a read replica
+func (qp *QueryParams[T]) WithForcePrimary() *QueryParams[T] {
+	qp.ForcePrimary = true
+	return qp
+}
+
+// WithLocking locks the returned rows with the given strength and option, for example
+// WithLocking(LockStrengthUpdate, LockOptionSkipLocked) for work queue consumers
+func (qp *QueryParams[T]) WithLocking(strength LockStrength, options LockOption) *QueryParams[T] {
+	qp.Locking = &Locking{Strength: strength, Options: options}
+	return qp
+}
+
 // HasSearch returns true if a search term is provided
 func (qp *QueryParams[T]) HasSearch() bool {
 	return qp.Search != ""
@@ -141,7 +308,7 @@ func (qp *QueryParams[T]) HasSearch() bool {
 
 // HasFilters returns true if any filters are applied
 func (qp *QueryParams[T]) HasFilters() bool {
-	return len(qp.Filters) > 0
+	return len(qp.Filters) > 0 || len(qp.RelationFilters) > 0
 }
 
 // HasSort returns true if any sort fields are specified
@@ -151,7 +318,7 @@ func (qp *QueryParams[T]) HasSort() bool {
 
 // HasPreloads returns true if any preload relations are specified
 func (qp *QueryParams[T]) HasPreloads() bool {
-	return len(qp.Preloads) > 0
+	return len(qp.Preloads) > 0 || len(qp.PreloadSpecs) > 0
 }
 
 // Clone creates a deep copy of the QueryParams
@@ -162,11 +329,28 @@ func (qp *QueryParams[T]) Clone() *QueryParams[T] {
 		Offset:         qp.Offset,
 		Limit:          qp.Limit,
 		Search:         qp.Search,
+		SortString:     qp.SortString,
 		IncludeDeleted: qp.IncludeDeleted,
 		OnlyDeleted:    qp.OnlyDeleted,
+
+		CountOnlyFirstPage: qp.CountOnlyFirstPage,
+		IgnoreDefaultScope: qp.IgnoreDefaultScope,
+		ForcePrimary:       qp.ForcePrimary,
+
+		err: qp.err,
+	}
+
+	if qp.Locking != nil {
+		locking := *qp.Locking
+		newParams.Locking = &locking
 	}
 
 	// Deep copy slices
+	if qp.SearchFields != nil {
+		newParams.SearchFields = make([]string, len(qp.SearchFields))
+		copy(newParams.SearchFields, qp.SearchFields)
+	}
+
 	if qp.Sort != nil {
 		newParams.Sort = make([]SortField, len(qp.Sort))
 		copy(newParams.Sort, qp.Sort)
@@ -176,11 +360,42 @@ func (qp *QueryParams[T]) Clone() *QueryParams[T] {
 		newParams.Filters = make([]identifier.FilterCriteria, len(qp.Filters))
 		copy(newParams.Filters, qp.Filters)
 	}
+	if qp.RelationFilters != nil {
+		newParams.RelationFilters = make([]RelationFilter, len(qp.RelationFilters))
+		for i, relationFilter := range qp.RelationFilters {
+			newParams.RelationFilters[i] = RelationFilter{Relation: relationFilter.Relation, Negate: relationFilter.Negate}
+			if relationFilter.Filters != nil {
+				newParams.RelationFilters[i].Filters = make([]identifier.FilterCriteria, len(relationFilter.Filters))
+				copy(newParams.RelationFilters[i].Filters, relationFilter.Filters)
+			}
+		}
+	}
+
+	if qp.RawConditions != nil {
+		newParams.RawConditions = make([]RawCondition, len(qp.RawConditions))
+		for i, condition := range qp.RawConditions {
+			newParams.RawConditions[i] = RawCondition{SQL: condition.SQL}
+			if condition.Args != nil {
+				newParams.RawConditions[i].Args = make([]interface{}, len(condition.Args))
+				copy(newParams.RawConditions[i].Args, condition.Args)
+			}
+		}
+	}
 
 	if qp.Preloads != nil {
 		newParams.Preloads = make([]string, len(qp.Preloads))
 		copy(newParams.Preloads, qp.Preloads)
 	}
+	if qp.PreloadSpecs != nil {
+		newParams.PreloadSpecs = make([]PreloadSpec, len(qp.PreloadSpecs))
+		for i, spec := range qp.PreloadSpecs {
+			newParams.PreloadSpecs[i] = PreloadSpec{Relation: spec.Relation}
+			if spec.Args != nil {
+				newParams.PreloadSpecs[i].Args = make([]interface{}, len(spec.Args))
+				copy(newParams.PreloadSpecs[i].Args, spec.Args)
+			}
+		}
+	}
 
 	return newParams
 }
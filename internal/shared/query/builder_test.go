@@ -1,8 +1,11 @@
 package query
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
 )
@@ -94,6 +97,95 @@ func TestQueryParams_AddPreload(t *testing.T) {
 	}
 }
 
+// TestQueryParams_WithPreload validates conditional and nested preload specs
+func TestQueryParams_WithPreload(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	result := params.WithPreload("Orders", "status = ?", "paid").WithPreload("Orders.Items")
+
+	// Assert
+	if result != params {
+		t.Error("WithPreload should return pointer to same instance")
+	}
+
+	if len(params.PreloadSpecs) != 2 {
+		t.Fatalf("Expected 2 preload specs, got %d", len(params.PreloadSpecs))
+	}
+
+	if params.PreloadSpecs[0].Relation != "Orders" || len(params.PreloadSpecs[0].Args) != 2 {
+		t.Errorf("Expected Orders preload with 2 args, got %+v", params.PreloadSpecs[0])
+	}
+
+	if params.PreloadSpecs[1].Relation != "Orders.Items" || len(params.PreloadSpecs[1].Args) != 0 {
+		t.Errorf("Expected Orders.Items preload without args, got %+v", params.PreloadSpecs[1])
+	}
+
+	if !params.HasPreloads() {
+		t.Error("Expected HasPreloads to be true when preload specs are present")
+	}
+}
+
+// TestQueryParams_WhereHas validates relation existence filters
+func TestQueryParams_WhereHas(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	result := params.
+		WhereHas("Orders", identifier.NewIdentifier().Equal("status", "paid")).
+		WhereHas("Profile", nil)
+
+	// Assert
+	if result != params {
+		t.Error("WhereHas should return pointer to same instance")
+	}
+
+	if len(params.RelationFilters) != 2 {
+		t.Fatalf("Expected 2 relation filters, got %d", len(params.RelationFilters))
+	}
+
+	if params.RelationFilters[0].Relation != "Orders" || len(params.RelationFilters[0].Filters) != 1 {
+		t.Errorf("Expected Orders relation filter with 1 criteria, got %+v", params.RelationFilters[0])
+	}
+
+	if params.RelationFilters[1].Filters != nil {
+		t.Errorf("Expected nil criteria for nil identifier, got %+v", params.RelationFilters[1].Filters)
+	}
+
+	if !params.HasFilters() {
+		t.Error("Expected HasFilters to be true when relation filters are present")
+	}
+}
+
+// TestQueryParams_WhereDoesntHave validates negated relation filters
+func TestQueryParams_WhereDoesntHave(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]().WhereHas("Profile", nil)
+
+	// Act
+	result := params.WhereDoesntHave("Orders", identifier.NewIdentifier().Equal("status", "paid"))
+	cloned := params.Clone()
+
+	// Assert
+	if result != params {
+		t.Error("WhereDoesntHave should return pointer to same instance")
+	}
+	if len(params.RelationFilters) != 2 {
+		t.Fatalf("Expected 2 relation filters, got %d", len(params.RelationFilters))
+	}
+	if params.RelationFilters[0].Negate {
+		t.Error("Expected WhereHas filter not to be negated")
+	}
+	if !params.RelationFilters[1].Negate || params.RelationFilters[1].Relation != "Orders" || len(params.RelationFilters[1].Filters) != 1 {
+		t.Errorf("Expected negated Orders relation filter with 1 criteria, got %+v", params.RelationFilters[1])
+	}
+	if !cloned.RelationFilters[1].Negate {
+		t.Error("Expected clone to keep the negation")
+	}
+}
+
 // TestQueryParams_WithDeletedVisibility validates soft-delete visibility options
 func TestQueryParams_WithDeletedVisibility(t *testing.T) {
 	tests := []struct {
@@ -125,10 +217,10 @@ func TestQueryParams_WithDeletedVisibility(t *testing.T) {
 			expectedOnly:    true,
 		},
 		{
-			name:            "Both include and only deleted true",
+			name:            "Both include and only deleted true resolves to only deleted",
 			includeDeleted:  true,
 			onlyDeleted:     true,
-			expectedInclude: true,
+			expectedInclude: false,
 			expectedOnly:    true,
 		},
 	}
@@ -203,6 +295,70 @@ func TestQueryParams_OnlyDeletedRecords(t *testing.T) {
 	}
 }
 
+// TestQueryParams_WithoutDefaultScope validates the default scope override and that Clone keeps it
+func TestQueryParams_WithoutDefaultScope(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	result := params.WithoutDefaultScope()
+	cloned := params.Clone()
+
+	// Assert
+	if result != params {
+		t.Error("WithoutDefaultScope should return pointer to same instance")
+	}
+	if !params.IgnoreDefaultScope {
+		t.Error("Expected IgnoreDefaultScope to be true")
+	}
+	if !cloned.IgnoreDefaultScope {
+		t.Error("Expected clone to keep IgnoreDefaultScope")
+	}
+}
+
+// TestQueryParams_WhereRaw validates raw conditions and that Clone copies their arguments
+func TestQueryParams_WhereRaw(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	result := params.WhereRaw("age BETWEEN ? AND ?", 18, 65)
+	cloned := params.Clone()
+	cloned.RawConditions[0].Args[0] = 21
+
+	// Assert
+	if result != params {
+		t.Error("WhereRaw should return pointer to same instance")
+	}
+	if len(params.RawConditions) != 1 || params.RawConditions[0].SQL != "age BETWEEN ? AND ?" {
+		t.Fatalf("Expected one raw condition, got %+v", params.RawConditions)
+	}
+	if params.RawConditions[0].Args[0] != 18 {
+		t.Error("Clone should have independent raw condition arguments")
+	}
+}
+
+// TestQueryParams_WithForcePrimary validates the primary routing flag and that Clone keeps it
+func TestQueryParams_WithForcePrimary(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	result := params.WithForcePrimary()
+	cloned := params.Clone()
+
+	// Assert
+	if result != params {
+		t.Error("WithForcePrimary should return pointer to same instance")
+	}
+	if !params.ForcePrimary {
+		t.Error("Expected ForcePrimary to be true")
+	}
+	if !cloned.ForcePrimary {
+		t.Error("Expected clone to keep ForcePrimary")
+	}
+}
+
 // TestQueryParams_ExcludeDeletedRecords validates exclude deleted records setting
 func TestQueryParams_ExcludeDeletedRecords(t *testing.T) {
 	// Arrange
@@ -308,6 +464,23 @@ func TestQueryParams_HasSort(t *testing.T) {
 	}
 }
 
+// TestQueryParams_AddSort_NormalizesOrder validates that sort orders are normalized on add
+func TestQueryParams_AddSort_NormalizesOrder(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	params.AddSort("name", "DESC").AddSort("email", "")
+
+	// Assert
+	if params.Sort[0].Order != SortOrderDesc {
+		t.Errorf("Expected %q, got %q", SortOrderDesc, params.Sort[0].Order)
+	}
+	if params.Sort[1].Order != SortOrderAsc {
+		t.Errorf("Expected %q, got %q", SortOrderAsc, params.Sort[1].Order)
+	}
+}
+
 // TestQueryParams_HasPreloads validates preload detection
 func TestQueryParams_HasPreloads(t *testing.T) {
 	// Arrange & Act - No preloads
@@ -409,6 +582,29 @@ func TestQueryParams_Clone(t *testing.T) {
 	}
 }
 
+// TestQueryParams_Clone_PreloadSpecs validates deep copying of preload specs
+func TestQueryParams_Clone_PreloadSpecs(t *testing.T) {
+	// Arrange
+	original := NewQueryParams[*testutil.TestEntity]().WithPreload("Orders", "status = ?", "paid")
+
+	// Act
+	clone := original.Clone()
+
+	// Assert
+	if len(clone.PreloadSpecs) != 1 {
+		t.Fatalf("Expected 1 cloned preload spec, got %d", len(clone.PreloadSpecs))
+	}
+
+	original.PreloadSpecs[0].Relation = "Modified"
+	original.PreloadSpecs[0].Args[1] = "modified"
+	if clone.PreloadSpecs[0].Relation != "Orders" {
+		t.Error("Modifying original PreloadSpecs should not affect clone")
+	}
+	if clone.PreloadSpecs[0].Args[1] != "paid" {
+		t.Error("Modifying original PreloadSpecs args should not affect clone")
+	}
+}
+
 // TestQueryParams_Clone_NilSlices validates cloning with nil slices
 func TestQueryParams_Clone_NilSlices(t *testing.T) {
 	// Arrange
@@ -436,3 +632,103 @@ func TestQueryParams_Clone_NilSlices(t *testing.T) {
 		t.Error("Expected cloned Preloads to be nil when original is nil")
 	}
 }
+
+// TestTotalPages validates the page count for various totals and page sizes
+func TestTotalPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		totalItems int64
+		pageSize   int
+		expected   int
+	}{
+		{"No items", 0, 10, 0},
+		{"Single partial page", 3, 10, 1},
+		{"Exact multiple", 20, 10, 2},
+		{"Partial last page", 21, 10, 3},
+		{"Page size of one", 7, 1, 7},
+		{"Default page size", 101, 0, 3},
+		{"Negative page size uses default", 50, -5, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			pages := TotalPages(tt.totalItems, tt.pageSize)
+
+			// Assert
+			if pages != tt.expected {
+				t.Errorf("Expected %d pages, got %d", tt.expected, pages)
+			}
+		})
+	}
+}
+
+// TestQueryParams_WithFiltersMap validates that map entries become Equal filters in key order
+func TestQueryParams_WithFiltersMap(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().GreaterThan("age", 18))
+	expected := identifier.NewIdentifier().
+		GreaterThan("age", 18).
+		Equal("is_active", true).
+		Equal("status", "active").
+		ToFilterCriteria()
+
+	// Act
+	result := params.WithFiltersMap(map[string]interface{}{"status": "active", "is_active": true})
+
+	// Assert
+	if result.Err() != nil {
+		t.Fatalf("Expected no error, got: %v", result.Err())
+	}
+	if !reflect.DeepEqual(result.Filters, expected) {
+		t.Errorf("Expected filters %+v, got %+v", expected, result.Filters)
+	}
+}
+
+// TestQueryParams_WithFiltersMap_OrBase validates that an OR-combined base filter is grouped so
+// the map filters apply to every alternative
+func TestQueryParams_WithFiltersMap_OrBase(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("role", "admin").Or(identifier.NewIdentifier().Equal("role", "owner")))
+	expected := identifier.NewIdentifier().
+		AndGroup(identifier.NewIdentifier().Equal("role", "admin").Or(identifier.NewIdentifier().Equal("role", "owner"))).
+		Equal("status", "active").
+		ToFilterCriteria()
+
+	// Act
+	result := params.WithFiltersMap(map[string]interface{}{"status": "active"})
+
+	// Assert
+	if result.Err() != nil {
+		t.Fatalf("Expected no error, got: %v", result.Err())
+	}
+	if !reflect.DeepEqual(result.Filters, expected) {
+		t.Errorf("Expected filters %+v, got %+v", expected, result.Filters)
+	}
+}
+
+// TestQueryParams_WithFiltersMap_InvalidField validates that invalid keys are skipped and reported
+func TestQueryParams_WithFiltersMap_InvalidField(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	result := params.WithFiltersMap(map[string]interface{}{"status": "active", "name; DROP TABLE x": 1})
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(result.Err(), &validationErr) {
+		t.Fatalf("Expected validation error, got: %v", result.Err())
+	}
+	if validationErr.Field != "name; DROP TABLE x" {
+		t.Errorf("Expected error on the invalid key, got %q", validationErr.Field)
+	}
+	if len(result.Filters) != 1 || result.Filters[0].Field != "status" {
+		t.Errorf("Expected only the valid filter to be kept, got %+v", result.Filters)
+	}
+	if result.Clone().Err() == nil {
+		t.Error("Expected Clone to keep the recorded error")
+	}
+}
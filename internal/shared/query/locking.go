@@ -0,0 +1,25 @@
+package query
+
+// LockStrength is the row lock taken by a locking read
+type LockStrength string
+
+const (
+	LockStrengthUpdate LockStrength = "UPDATE" // SELECT ... FOR UPDATE, exclusive for writers
+	LockStrengthShare  LockStrength = "SHARE"  // SELECT ... FOR SHARE, blocks writers but not other readers
+)
+
+// LockOption changes how a locking read handles rows already locked by another transaction
+type LockOption string
+
+const (
+	LockOptionWait       LockOption = ""            // Wait for the other transaction (default)
+	LockOptionNoWait     LockOption = "NOWAIT"      // Fail immediately instead of waiting
+	LockOptionSkipLocked LockOption = "SKIP LOCKED" // Skip locked rows, as work queue consumers do
+)
+
+// Locking requests row locks on the entities returned by a read, which must run inside a
+// transaction since the locks are held until it ends
+type Locking struct {
+	Strength LockStrength `json:"-"`
+	Options  LockOption   `json:"-"`
+}
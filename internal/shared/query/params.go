@@ -15,19 +15,62 @@ type QueryParams[T types.IBaseModel] struct {
 	Offset   int `json:"-"`                         // Calculated offset (auto-computed from Page and PageSize)
 	Limit    int `json:"-"`                         // Calculated limit (auto-computed from PageSize)
 
+	// CountOnlyFirstPage skips the total COUNT after the first page (total is reported as -1),
+	// for infinite-scroll clients that only need the total once
+	CountOnlyFirstPage bool `json:"countOnlyFirstPage,omitempty" query:"countOnlyFirstPage"`
+
 	// Search functionality
-	Search string `json:"search,omitempty" query:"search"` // Free-text search term
+	Search       string   `json:"search,omitempty" query:"search"` // Free-text search term
+	SearchFields []string `json:"searchFields,omitempty"`          // Columns matched by Search (defaults to id)
 
 	// Sorting
-	Sort []SortField `json:"sort,omitempty"` // Multiple sort fields with direction
+	Sort       []SortField `json:"sort,omitempty"`                    // Multiple sort fields with direction
+	SortString string      `json:"sortString,omitempty" query:"sort"` // Compact sort list such as "-created_at,name", merged into Sort by PrepareDefaults
 
-	// Advanced filtering using IIdentifier system
+	// Advanced filtering using IIdentifier system. Each criterion is joined to the next by its
+	// LogicalOp (AND when empty), with AND binding tighter than OR; use a criterion with a Group
+	// to OR whole groups, e.g. NewIdentifier().AndGroup(a).OrGroup(b) for "(a) OR (b)". When any
+	// OR is present the list is applied as one parenthesized condition, so soft-delete visibility
+	// and search still apply to every alternative.
 	Filters []identifier.FilterCriteria `json:"filters,omitempty"`
 
-	// Soft-delete visibility control
+	// Relation existence filtering (e.g. "has at least one paid order")
+	RelationFilters []RelationFilter `json:"relationFilters,omitempty"`
+
+	// Raw SQL conditions for cases the builder cannot express.
+	// They are deliberately not bound from requests since the SQL is trusted as written.
+	RawConditions []RawCondition `json:"-"`
+
+	// Soft-delete visibility control. OnlyDeleted takes precedence when both are set, and
+	// PrepareDefaults clears IncludeDeleted in that case.
 	IncludeDeleted bool `json:"includeDeleted,omitempty" query:"includeDeleted"` // Include soft-deleted records
 	OnlyDeleted    bool `json:"onlyDeleted,omitempty" query:"onlyDeleted"`       // Show only soft-deleted records
 
+	// IgnoreDefaultScope skips the entity's default scope (see types.IDefaultScoped).
+	// It is deliberately not bound from requests so clients cannot bypass visibility rules.
+	IgnoreDefaultScope bool `json:"-"`
+
+	// ForcePrimary sends this read to the primary database even when read replicas are
+	// configured, for read-after-write consistency
+	ForcePrimary bool `json:"-"`
+
+	// Locking locks the returned rows until the surrounding transaction ends, e.g. FOR UPDATE
+	// SKIP LOCKED to claim jobs from a table-backed queue. It is not bound from requests.
+	Locking *Locking `json:"-"`
+
 	// Eager loading relationships
-	Preloads []string `json:"preloads,omitempty" query:"preloads"` // List of relations to preload
+	Preloads     []string      `json:"preloads,omitempty" query:"preloads"` // List of relations to preload
+	PreloadSpecs []PreloadSpec `json:"preloadSpecs,omitempty"`              // Relations to preload with conditions
+
+	// err records the first invalid input given to a builder method, see Err
+	err error
+}
+
+// Err returns the first error recorded while building the params, such as an invalid
+// field name passed to WithFiltersMap. Queries using params with an error fail with it.
+func (qp *QueryParams[T]) Err() error {
+	if qp == nil {
+		return nil
+	}
+	return qp.err
 }
@@ -1,8 +1,13 @@
 package query
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+
 	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
 )
 
@@ -235,6 +240,80 @@ func TestQueryParams_PrepareDefaults_NilSlices(t *testing.T) {
 	}
 }
 
+// TestQueryParams_PrepareDefaults_SortString validates that a bound compact sort string becomes sort fields
+func TestQueryParams_PrepareDefaults_SortString(t *testing.T) {
+	tests := []struct {
+		name     string
+		explicit []SortField
+		expected []SortField
+	}{
+		{
+			name:     "Parsed in order",
+			expected: []SortField{{Field: "created_at", Order: SortOrderDesc}, {Field: "name", Order: SortOrderAsc}},
+		},
+		{
+			name:     "Merged after explicit sort",
+			explicit: []SortField{{Field: "name", Order: SortOrderDesc}},
+			expected: []SortField{{Field: "name", Order: SortOrderDesc}, {Field: "created_at", Order: SortOrderDesc}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			field, _ := reflect.TypeOf(QueryParams[*testutil.TestEntity]{}).FieldByName("SortString")
+			if field.Tag.Get("query") != "sort" {
+				t.Fatalf("Expected SortString to bind from the sort query parameter, got %q", field.Tag.Get("query"))
+			}
+			params := &QueryParams[*testutil.TestEntity]{Sort: tt.explicit, SortString: "-created_at, name"}
+
+			// Act
+			params.PrepareDefaults()
+
+			// Assert
+			if params.Err() != nil {
+				t.Fatalf("Expected no error, got: %v", params.Err())
+			}
+			if !reflect.DeepEqual(params.Sort, tt.expected) {
+				t.Errorf("Expected sort %+v, got %+v", tt.expected, params.Sort)
+			}
+		})
+	}
+}
+
+// TestQueryParams_PrepareDefaults_InvalidSortString validates that invalid sort fields are reported
+func TestQueryParams_PrepareDefaults_InvalidSortString(t *testing.T) {
+	// Arrange
+	params := &QueryParams[*testutil.TestEntity]{SortString: "name,-id;DROP TABLE x"}
+
+	// Act
+	params.PrepareDefaults()
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(params.Err(), &validationErr) {
+		t.Fatalf("Expected validation error, got: %v", params.Err())
+	}
+	if len(params.Sort) != 0 {
+		t.Errorf("Expected no sort fields from an invalid sort string, got %+v", params.Sort)
+	}
+}
+
+// TestQueryParams_PrepareDefaults_ConflictingDeletedVisibility validates that OnlyDeleted wins
+// over IncludeDeleted when both are set
+func TestQueryParams_PrepareDefaults_ConflictingDeletedVisibility(t *testing.T) {
+	// Arrange
+	params := &QueryParams[*testutil.TestEntity]{IncludeDeleted: true, OnlyDeleted: true}
+
+	// Act
+	params.PrepareDefaults()
+
+	// Assert
+	if params.IncludeDeleted || !params.OnlyDeleted {
+		t.Errorf("Expected only deleted records, got IncludeDeleted=%v OnlyDeleted=%v", params.IncludeDeleted, params.OnlyDeleted)
+	}
+}
+
 // TestQueryParams_AddSort validates sort field addition
 func TestQueryParams_AddSort(t *testing.T) {
 	// Arrange
@@ -360,6 +439,51 @@ func TestQueryParams_ClearSort(t *testing.T) {
 	}
 }
 
+// TestQueryParams_ClearFilters validates that every kind of filter is cleared
+func TestQueryParams_ClearFilters(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("status", "active")).
+		WhereHas("Orders", nil).
+		WhereRaw("age > ?", 18)
+
+	// Act
+	result := params.ClearFilters()
+
+	// Assert
+	if result != params {
+		t.Error("ClearFilters should return pointer to same instance")
+	}
+	if params.HasFilters() || len(params.RawConditions) != 0 {
+		t.Errorf("Expected no filters after clear, got %+v, %+v, %+v", params.Filters, params.RelationFilters, params.RawConditions)
+	}
+	if params.Filters == nil || params.RelationFilters == nil || params.RawConditions == nil {
+		t.Error("Expected filter slices to remain initialized after clear")
+	}
+}
+
+// TestQueryParams_ClearPreloads validates that plain and conditional preloads are cleared
+func TestQueryParams_ClearPreloads(t *testing.T) {
+	// Arrange
+	params := NewQueryParams[*testutil.TestEntity]().
+		AddPreload("Orders").
+		WithPreload("Orders", "status = ?", "paid")
+
+	// Act
+	result := params.ClearPreloads()
+
+	// Assert
+	if result != params {
+		t.Error("ClearPreloads should return pointer to same instance")
+	}
+	if params.HasPreloads() {
+		t.Errorf("Expected no preloads after clear, got %+v, %+v", params.Preloads, params.PreloadSpecs)
+	}
+	if params.Preloads == nil || params.PreloadSpecs == nil {
+		t.Error("Expected preload slices to remain initialized after clear")
+	}
+}
+
 // TestQueryParams_WithSearch validates search term setting
 func TestQueryParams_WithSearch(t *testing.T) {
 	tests := []struct {
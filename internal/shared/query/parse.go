@@ -0,0 +1,185 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+)
+
+// filterKeyPattern matches "filter[field]" and "filter[field][op]" query-string keys
+var filterKeyPattern = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([a-z]+)\])?$`)
+
+// queryFieldPattern matches plain or dotted field names accepted from query strings
+var queryFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// ParseQueryParams builds QueryParams from a URL query string using this grammar:
+//
+//	page=2&pageSize=20          pagination, normalized by PrepareDefaults
+//	search=term                 free-text search
+//	sort=-createdAt,email       comma-separated fields, a leading "-" sorts descending
+//	filter[status]=active       equality filter
+//	filter[age][gte]=18         operator filter: eq, ne, gt, gte, lt, lte, like,
+//	                            in and nin (comma-separated values), null and notnull
+//	                            (value "true" or "false")
+//	preloads=Orders,Profile     comma-separated relations to preload
+//	includeDeleted=true         soft-delete visibility, also onlyDeleted=true
+//	countOnlyFirstPage=true     skip the total count after the first page
+//
+// Filters are combined with AND in key order so the result is deterministic. Filter values
+// are passed through as strings. Unknown keys are ignored; malformed values and field
+// names return a ValidationError.
+func ParseQueryParams[T types.IBaseModel](values url.Values) (*QueryParams[T], error) {
+	params := NewQueryParams[T]()
+
+	var err error
+	if params.Page, err = parseIntValue(values, "page", params.Page); err != nil {
+		return nil, err
+	}
+	if params.PageSize, err = parseIntValue(values, "pageSize", params.PageSize); err != nil {
+		return nil, err
+	}
+	if params.IncludeDeleted, err = parseBoolValue(values, "includeDeleted"); err != nil {
+		return nil, err
+	}
+	if params.OnlyDeleted, err = parseBoolValue(values, "onlyDeleted"); err != nil {
+		return nil, err
+	}
+	if params.CountOnlyFirstPage, err = parseBoolValue(values, "countOnlyFirstPage"); err != nil {
+		return nil, err
+	}
+	params.Search = strings.TrimSpace(values.Get("search"))
+
+	sorts, err := parseSortString(values.Get("sort"))
+	if err != nil {
+		return nil, err
+	}
+	params.Sort = append(params.Sort, sorts...)
+
+	params.Preloads = append(params.Preloads, splitList(values.Get("preloads"))...)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	filters := identifier.NewIdentifier()
+	for _, key := range keys {
+		match := filterKeyPattern.FindStringSubmatch(key)
+		if match == nil || !queryFieldPattern.MatchString(match[1]) {
+			return nil, domainerrors.NewValidationError(key, "invalid filter key")
+		}
+		for _, value := range values[key] {
+			if filters, err = addQueryFilter(filters, match[1], match[2], value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	params.WithFilters(filters)
+
+	return params.PrepareDefaults(), nil
+}
+
+// addQueryFilter appends the filter described by a query-string operator to filters
+func addQueryFilter(filters identifier.IIdentifier, field, op, value string) (identifier.IIdentifier, error) {
+	switch op {
+	case "", "eq":
+		return filters.Equal(field, value), nil
+	case "ne":
+		return filters.NotEqual(field, value), nil
+	case "gt":
+		return filters.GreaterThan(field, value), nil
+	case "gte":
+		return filters.GreaterOrEqual(field, value), nil
+	case "lt":
+		return filters.LessThan(field, value), nil
+	case "lte":
+		return filters.LessOrEqual(field, value), nil
+	case "like":
+		return filters.Like(field, value), nil
+	case "in", "nin":
+		items := splitList(value)
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			list[i] = item
+		}
+		if op == "in" {
+			return filters.In(field, list), nil
+		}
+		return filters.NotIn(field, list), nil
+	case "null", "notnull":
+		isSet, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, domainerrors.NewValidationError(field, fmt.Sprintf("invalid %s value %q", op, value))
+		}
+		if isSet == (op == "null") {
+			return filters.IsNull(field), nil
+		}
+		return filters.IsNotNull(field), nil
+	default:
+		return nil, domainerrors.NewValidationError(field, fmt.Sprintf("unsupported filter operator %q", op))
+	}
+}
+
+// parseIntValue parses an integer query value, returning fallback when it is absent
+func parseIntValue(values url.Values, key string, fallback int) (int, error) {
+	raw := strings.TrimSpace(values.Get(key))
+	if raw == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, domainerrors.NewValidationError(key, fmt.Sprintf("invalid integer %q", raw))
+	}
+	return parsed, nil
+}
+
+// parseBoolValue parses a boolean query value, returning false when it is absent
+func parseBoolValue(values url.Values, key string) (bool, error) {
+	raw := strings.TrimSpace(values.Get(key))
+	if raw == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, domainerrors.NewValidationError(key, fmt.Sprintf("invalid boolean %q", raw))
+	}
+	return parsed, nil
+}
+
+// parseSortString parses a compact sort list such as "-createdAt,email", where a leading "-"
+// sorts descending
+func parseSortString(raw string) ([]SortField, error) {
+	var sorts []SortField
+	for _, field := range splitList(raw) {
+		order := SortOrderAsc
+		if strings.HasPrefix(field, "-") {
+			field, order = field[1:], SortOrderDesc
+		}
+		if !queryFieldPattern.MatchString(field) {
+			return nil, domainerrors.NewValidationError("sort", fmt.Sprintf("invalid sort field %q", field))
+		}
+		sorts = append(sorts, SortField{Field: field, Order: order})
+	}
+	return sorts, nil
+}
+
+// splitList splits a comma-separated value, trimming blanks and dropping empty items
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
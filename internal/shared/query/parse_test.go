@@ -0,0 +1,131 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestParseQueryParams validates parsing of a representative list endpoint query string
+func TestParseQueryParams(t *testing.T) {
+	// Arrange
+	values, err := url.ParseQuery("page=2&pageSize=20&search=john&sort=-createdAt,email" +
+		"&filter[status]=active&filter[age][gte]=18&filter[role][in]=admin,editor" +
+		"&filter[deletedBy][null]=true&preloads=Orders&includeDeleted=true")
+	if err != nil {
+		t.Fatalf("Failed to parse query string: %v", err)
+	}
+
+	// Act
+	params, err := ParseQueryParams[*testutil.TestEntity](values)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if params.Page != 2 || params.PageSize != 20 || params.Offset != 20 || params.Limit != 20 {
+		t.Errorf("Unexpected pagination: page %d, size %d, offset %d, limit %d", params.Page, params.PageSize, params.Offset, params.Limit)
+	}
+	if params.Search != "john" {
+		t.Errorf("Expected search 'john', got %q", params.Search)
+	}
+	expectedSort := []SortField{{Field: "createdAt", Order: SortOrderDesc}, {Field: "email", Order: SortOrderAsc}}
+	if len(params.Sort) != len(expectedSort) {
+		t.Fatalf("Expected %d sort fields, got %+v", len(expectedSort), params.Sort)
+	}
+	for i, expected := range expectedSort {
+		if params.Sort[i] != expected {
+			t.Errorf("Expected sort %d to be %+v, got %+v", i, expected, params.Sort[i])
+		}
+	}
+	if len(params.Preloads) != 1 || params.Preloads[0] != "Orders" {
+		t.Errorf("Expected preload 'Orders', got %v", params.Preloads)
+	}
+	if !params.IncludeDeleted || params.OnlyDeleted {
+		t.Errorf("Expected only IncludeDeleted to be set, got %v/%v", params.IncludeDeleted, params.OnlyDeleted)
+	}
+
+	// Filters are ordered by key
+	expectedFilters := []struct {
+		field    string
+		operator identifier.FilterOperator
+	}{
+		{"age", identifier.FilterOperatorGreaterEqual},
+		{"deletedBy", identifier.FilterOperatorIsNull},
+		{"role", identifier.FilterOperatorIn},
+		{"status", identifier.FilterOperatorEqual},
+	}
+	if len(params.Filters) != len(expectedFilters) {
+		t.Fatalf("Expected %d filters, got %+v", len(expectedFilters), params.Filters)
+	}
+	for i, expected := range expectedFilters {
+		filter := params.Filters[i]
+		if filter.Field != expected.field || filter.Operator != expected.operator {
+			t.Errorf("Expected filter %d to be %s %s, got %s %s", i, expected.field, expected.operator, filter.Field, filter.Operator)
+		}
+	}
+	if params.Filters[0].Value != "18" {
+		t.Errorf("Expected age filter value '18', got %v", params.Filters[0].Value)
+	}
+	if len(params.Filters[2].Values) != 2 || params.Filters[2].Values[1] != "editor" {
+		t.Errorf("Expected role filter values [admin editor], got %v", params.Filters[2].Values)
+	}
+	if params.Filters[3].Value != "active" {
+		t.Errorf("Expected status filter value 'active', got %v", params.Filters[3].Value)
+	}
+}
+
+// TestParseQueryParams_Defaults validates that an empty query string yields default params
+func TestParseQueryParams_Defaults(t *testing.T) {
+	// Act
+	params, err := ParseQueryParams[*testutil.TestEntity](url.Values{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if params.Page != 1 || params.PageSize != 50 {
+		t.Errorf("Expected default pagination, got page %d, size %d", params.Page, params.PageSize)
+	}
+	if params.HasSort() || params.HasFilters() {
+		t.Errorf("Expected no sort or filters, got %+v / %+v", params.Sort, params.Filters)
+	}
+}
+
+// TestParseQueryParams_Invalid validates that malformed input is rejected
+func TestParseQueryParams_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"Invalid page", "page=abc"},
+		{"Invalid boolean", "onlyDeleted=maybe"},
+		{"Invalid sort field", "sort=name%20DESC"},
+		{"Invalid filter field", "filter[na me]=x"},
+		{"Unsupported operator", "filter[name][regex]=x"},
+		{"Invalid null value", "filter[name][null]=maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Failed to parse query string: %v", err)
+			}
+
+			// Act
+			_, err = ParseQueryParams[*testutil.TestEntity](values)
+
+			// Assert
+			var validationErr *domainerrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError, got: %v", err)
+			}
+		})
+	}
+}
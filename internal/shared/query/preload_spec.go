@@ -0,0 +1,11 @@
+package query
+
+// PreloadSpec represents a relation to eager load with optional conditions.
+// Relation supports nested paths using dot notation (e.g. "Orders.Items").
+type PreloadSpec struct {
+	// Relation is the name of the association to preload
+	Relation string `json:"relation"`
+
+	// Args are passed to the preload as conditions (e.g. "status = ?", "paid")
+	Args []interface{} `json:"args,omitempty"`
+}
@@ -0,0 +1,12 @@
+package query
+
+// RawCondition is a caller-written SQL fragment for conditions the builder cannot express,
+// such as vendor-specific operators. The caller owns the safety of SQL; values must be
+// passed through Args so they are bound as parameters rather than interpolated.
+type RawCondition struct {
+	// SQL is the condition with ? placeholders for Args (e.g. "tags @> ?")
+	SQL string `json:"-"`
+
+	// Args are bound to the placeholders in SQL
+	Args []interface{} `json:"-"`
+}
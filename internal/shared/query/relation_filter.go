@@ -0,0 +1,17 @@
+package query
+
+import "github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+
+// RelationFilter restricts results to entities that have at least one related row
+// matching the given criteria, or none when Negate is set. It is translated into a
+// correlated EXISTS (or NOT EXISTS) subquery.
+type RelationFilter struct {
+	// Relation is the name of the association as declared on the entity (e.g. "Orders")
+	Relation string `json:"relation"`
+
+	// Filters are applied to the related rows; empty means any related row matches
+	Filters []identifier.FilterCriteria `json:"filters,omitempty"`
+
+	// Negate inverts the filter to match entities without any such related row
+	Negate bool `json:"negate,omitempty"`
+}
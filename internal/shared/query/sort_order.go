@@ -1,5 +1,7 @@
 package query
 
+import "strings"
+
 // SortOrder defines the direction for sorting operations
 type SortOrder string
 
@@ -9,3 +11,18 @@ const (
 	// SortOrderDesc represents descending sort order
 	SortOrderDesc SortOrder = "desc"
 )
+
+// Normalize returns the order lowercased and trimmed, so "DESC" and " desc " become SortOrderDesc.
+// An empty order defaults to SortOrderAsc. The result may still be invalid; check it with IsValid.
+func (o SortOrder) Normalize() SortOrder {
+	normalized := SortOrder(strings.ToLower(strings.TrimSpace(string(o))))
+	if normalized == "" {
+		return SortOrderAsc
+	}
+	return normalized
+}
+
+// IsValid reports whether the order is exactly SortOrderAsc or SortOrderDesc
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
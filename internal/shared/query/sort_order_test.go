@@ -98,3 +98,35 @@ func TestSortOrder_Usage(t *testing.T) {
 		})
 	}
 }
+
+// TestSortOrder_Normalize validates case, whitespace and empty order normalization
+func TestSortOrder_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    SortOrder
+		expected SortOrder
+		valid    bool
+	}{
+		{"Lowercase asc", "asc", SortOrderAsc, true},
+		{"Uppercase desc", "DESC", SortOrderDesc, true},
+		{"Padded mixed case", " Desc ", SortOrderDesc, true},
+		{"Empty defaults to asc", "", SortOrderAsc, true},
+		{"Injected string", "asc; DROP TABLE users", "asc; drop table users", false},
+		{"Unknown word", "sideways", "sideways", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			normalized := tt.order.Normalize()
+
+			// Assert
+			if normalized != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, normalized)
+			}
+			if normalized.IsValid() != tt.valid {
+				t.Errorf("Expected IsValid %v for %q", tt.valid, normalized)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
@@ -40,11 +41,31 @@ func (r *BaseRepository[T]) FindOne(ctx context.Context, filter T) (T, error) {
 	return r.uow.FindOne(ctx, filter)
 }
 
+// FindOneIncludingTrashed retrieves a single entity matching the provided filter, including soft-deleted ones
+func (r *BaseRepository[T]) FindOneIncludingTrashed(ctx context.Context, filter T) (T, error) {
+	return r.uow.FindOneIncludingTrashed(ctx, filter)
+}
+
 // FindOneById retrieves a single entity by its ID
 func (r *BaseRepository[T]) FindOneById(ctx context.Context, id int) (T, error) {
 	return r.uow.FindOneById(ctx, id)
 }
 
+// FindByIDs retrieves the non-deleted entities with the given IDs in one query, ordered by ID
+func (r *BaseRepository[T]) FindByIDs(ctx context.Context, ids []int) ([]T, error) {
+	return r.uow.FindByIDs(ctx, ids)
+}
+
+// FindChangedSince retrieves the entities updated at or after since, oldest change first
+func (r *BaseRepository[T]) FindChangedSince(ctx context.Context, since time.Time, query *query.QueryParams[T]) ([]T, error) {
+	return r.uow.FindChangedSince(ctx, since, query)
+}
+
+// FindTop retrieves at most n live entities matching the identifier in the given order
+func (r *BaseRepository[T]) FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]T, error) {
+	return r.uow.FindTop(ctx, n, sort, identifier)
+}
+
 // FindOneByIdentifier retrieves a single entity using the IIdentifier filter system
 func (r *BaseRepository[T]) FindOneByIdentifier(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
 	return r.uow.FindOneByIdentifier(ctx, identifier)
@@ -62,11 +83,36 @@ func (r *BaseRepository[T]) Update(ctx context.Context, identifier identifier.II
 	return r.uow.Update(ctx, identifier, entity)
 }
 
+// UpdateE modifies entities matching the identifier and returns the number of affected rows
+func (r *BaseRepository[T]) UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error) {
+	return r.uow.UpdateE(ctx, identifier, entity)
+}
+
+// UpdateIncludingTrashed modifies an entity matching the identifier even when it is soft-deleted
+func (r *BaseRepository[T]) UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	return r.uow.UpdateIncludingTrashed(ctx, identifier, entity)
+}
+
+// Save inserts the entity when it has no ID yet and updates it by ID otherwise
+func (r *BaseRepository[T]) Save(ctx context.Context, entity T) (T, error) {
+	return r.uow.Save(ctx, entity)
+}
+
+// MergeJSON merges the keys of patch into a JSON column of the entities matching the identifier
+func (r *BaseRepository[T]) MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error) {
+	return r.uow.MergeJSON(ctx, identifier, column, patch)
+}
+
 // Delete performs a logical operation (soft-delete by default)
 func (r *BaseRepository[T]) Delete(ctx context.Context, identifier identifier.IIdentifier) error {
 	return r.uow.Delete(ctx, identifier)
 }
 
+// DeleteE performs the same logical delete and returns the number of rows deleted
+func (r *BaseRepository[T]) DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return r.uow.DeleteE(ctx, identifier)
+}
+
 // Soft-delete lifecycle
 
 // SoftDelete performs soft deletion by setting DeletedAt timestamp
@@ -74,6 +120,16 @@ func (r *BaseRepository[T]) SoftDelete(ctx context.Context, identifier identifie
 	return r.uow.SoftDelete(ctx, identifier)
 }
 
+// SoftDeleteWithNote soft-deletes and records the reason in the audit note in one statement
+func (r *BaseRepository[T]) SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (T, error) {
+	return r.uow.SoftDeleteWithNote(ctx, identifier, note)
+}
+
+// Replace soft-deletes the live entities matching the identifier and inserts entity atomically
+func (r *BaseRepository[T]) Replace(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	return r.uow.Replace(ctx, identifier, entity)
+}
+
 // HardDelete permanently removes entities from the database
 func (r *BaseRepository[T]) HardDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
 	return r.uow.HardDelete(ctx, identifier)
@@ -96,11 +152,26 @@ func (r *BaseRepository[T]) BulkSoftDelete(ctx context.Context, identifiers []id
 	return r.uow.BulkSoftDelete(ctx, identifiers)
 }
 
+// BulkSoftDeleteE soft-deletes multiple entities and returns the number of rows moved to the trash
+func (r *BaseRepository[T]) BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error) {
+	return r.uow.BulkSoftDeleteE(ctx, identifiers)
+}
+
+// SoftDeleteWhere soft-deletes all live entities matching the query filters and returns the number of rows moved to the trash
+func (r *BaseRepository[T]) SoftDeleteWhere(ctx context.Context, params *query.QueryParams[T]) (int64, error) {
+	return r.uow.SoftDeleteWhere(ctx, params)
+}
+
 // BulkHardDelete permanently removes multiple entities identified by the provided identifiers
 func (r *BaseRepository[T]) BulkHardDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
 	return r.uow.BulkHardDelete(ctx, identifiers)
 }
 
+// PruneWhere permanently removes all entities matching the query filters, including soft-deleted ones
+func (r *BaseRepository[T]) PruneWhere(ctx context.Context, params *query.QueryParams[T]) (int64, error) {
+	return r.uow.PruneWhere(ctx, params)
+}
+
 // Trash management
 
 // GetTrashed retrieves all soft-deleted entities
@@ -108,16 +179,26 @@ func (r *BaseRepository[T]) GetTrashed(ctx context.Context) ([]T, error) {
 	return r.uow.GetTrashed(ctx)
 }
 
+// GetTrashedByIdentifier retrieves soft-deleted entities matching the identifier, most recently deleted first
+func (r *BaseRepository[T]) GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]T, error) {
+	return r.uow.GetTrashedByIdentifier(ctx, identifier)
+}
+
 // GetTrashedWithPagination retrieves soft-deleted entities with pagination
 func (r *BaseRepository[T]) GetTrashedWithPagination(ctx context.Context, params *query.QueryParams[T]) ([]T, int64, error) {
 	return r.uow.GetTrashedWithPagination(ctx, params)
 }
 
-// Restore recovers soft-deleted entities by clearing their DeletedAt timestamp
+// Restore recovers a single soft-deleted entity, the most recently deleted when several match
 func (r *BaseRepository[T]) Restore(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
 	return r.uow.Restore(ctx, identifier)
 }
 
+// RestoreAllMatching recovers every soft-deleted entity matching the identifier
+func (r *BaseRepository[T]) RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return r.uow.RestoreAllMatching(ctx, identifier)
+}
+
 // RestoreAll recovers all soft-deleted entities of type T
 func (r *BaseRepository[T]) RestoreAll(ctx context.Context) error {
 	return r.uow.RestoreAll(ctx)
@@ -130,10 +211,35 @@ func (r *BaseRepository[T]) Count(ctx context.Context, params *query.QueryParams
 	return r.uow.Count(ctx, params)
 }
 
+// CountPages returns the total number of matching entities and the number of pages
+func (r *BaseRepository[T]) CountPages(ctx context.Context, query *query.QueryParams[T]) (int64, int, error) {
+	return r.uow.CountPages(ctx, query)
+}
+
+// CountIncludingTrashed returns the number of entities matching the identifier, including soft-deleted ones
+func (r *BaseRepository[T]) CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return r.uow.CountIncludingTrashed(ctx, identifier)
+}
+
+// CountBy returns the number of entities matching the identifier, configured by opts
+func (r *BaseRepository[T]) CountBy(ctx context.Context, identifier identifier.IIdentifier, opts unit_of_work.CountOptions) (int64, error) {
+	return r.uow.CountBy(ctx, identifier, opts)
+}
+
+// MustExist returns unit_of_work.ErrEntityNotFound when no live entity matches the identifier
+func (r *BaseRepository[T]) MustExist(ctx context.Context, identifier identifier.IIdentifier) error {
+	return r.uow.MustExist(ctx, identifier)
+}
+
 // Exists checks if any entity matches the provided identifier
 func (r *BaseRepository[T]) Exists(ctx context.Context, identifier identifier.IIdentifier) (bool, error) {
 	return r.uow.Exists(ctx, identifier)
 }
 
+// ExistsWhere checks if any entity matches both the identifier and the extra predicate
+func (r *BaseRepository[T]) ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error) {
+	return r.uow.ExistsWhere(ctx, identifier, extra)
+}
+
 // Compile-time check to ensure BaseRepository implements IBaseRepository
 var _ IBaseRepository[types.IBaseModel] = (*BaseRepository[types.IBaseModel])(nil)
@@ -122,3 +122,104 @@ func TestBaseRepository_Update(t *testing.T) {
 		t.Error("Expected same entity to be returned")
 	}
 }
+
+// TestBaseRepository_UpdateE validates update delegation with affected rows
+func TestBaseRepository_UpdateE(t *testing.T) {
+	// Arrange
+	entity := testutil.CreateTestEntities()[0]
+	id := identifier.NewIdentifier().Equal("id", 1)
+	mockUow := &mockUnitOfWork{
+		UpdateEResult:       entity,
+		UpdateERowsAffected: 1,
+	}
+	repo := NewBaseRepository[*testutil.TestEntity](mockUow)
+
+	// Act
+	result, rowsAffected, err := repo.UpdateE(context.Background(), id, entity)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if !mockUow.UpdateECalled {
+		t.Error("Expected UpdateE to be called on UnitOfWork")
+	}
+	if rowsAffected != 1 {
+		t.Errorf("Expected 1 affected row, got %d", rowsAffected)
+	}
+	if result != entity {
+		t.Error("Expected same entity to be returned")
+	}
+}
+
+// TestBaseRepository_UpdateIncludingTrashed validates trash-inclusive update delegation
+func TestBaseRepository_UpdateIncludingTrashed(t *testing.T) {
+	// Arrange
+	entity := testutil.CreateTestEntities()[0]
+	id := identifier.NewIdentifier().Equal("id", 1)
+	mockUow := &mockUnitOfWork{
+		UpdateIncludingTrashedResult: entity,
+	}
+	repo := NewBaseRepository[*testutil.TestEntity](mockUow)
+
+	// Act
+	result, err := repo.UpdateIncludingTrashed(context.Background(), id, entity)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if !mockUow.UpdateIncludingTrashedCalled {
+		t.Error("Expected UpdateIncludingTrashed to be called on UnitOfWork")
+	}
+	if result != entity {
+		t.Error("Expected same entity to be returned")
+	}
+}
+
+// TestBaseRepository_GetTrashedByIdentifier validates filtered trash delegation
+func TestBaseRepository_GetTrashedByIdentifier(t *testing.T) {
+	// Arrange
+	mockUow := &mockUnitOfWork{
+		GetTrashedByIdentifierResult: testutil.CreateTestEntities()[:2],
+	}
+	repo := NewBaseRepository[*testutil.TestEntity](mockUow)
+
+	// Act
+	result, err := repo.GetTrashedByIdentifier(context.Background(), identifier.NewIdentifier().Equal("status", "active"))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if !mockUow.GetTrashedByIdentifierCalled {
+		t.Error("Expected GetTrashedByIdentifier to be called on UnitOfWork")
+	}
+	if len(result) != 2 {
+		t.Errorf("Expected 2 entities, got: %d", len(result))
+	}
+}
+
+// TestBaseRepository_PruneWhere validates prune delegation
+func TestBaseRepository_PruneWhere(t *testing.T) {
+	// Arrange
+	mockUow := &mockUnitOfWork{
+		PruneWhereResult: 3,
+	}
+	repo := NewBaseRepository[*testutil.TestEntity](mockUow)
+	params := query.NewQueryParams[*testutil.TestEntity]().WithFilters(identifier.NewIdentifier().Equal("status", "archived"))
+
+	// Act
+	pruned, err := repo.PruneWhere(context.Background(), params)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if !mockUow.PruneWhereCalled {
+		t.Error("Expected PruneWhere to be called on UnitOfWork")
+	}
+	if pruned != 3 {
+		t.Errorf("Expected 3 pruned rows, got %d", pruned)
+	}
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
 )
 
 // IBaseRepository defines the contract for repository layer that delegates to IUnitOfWork.
@@ -16,31 +18,52 @@ type IBaseRepository[T types.IBaseModel] interface {
 	FindAll(ctx context.Context) ([]T, error)
 	FindAllWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error)
 	FindOne(ctx context.Context, filter T) (T, error)
+	FindOneIncludingTrashed(ctx context.Context, filter T) (T, error)
 	FindOneById(ctx context.Context, id int) (T, error)
+	FindByIDs(ctx context.Context, ids []int) ([]T, error)
+	FindChangedSince(ctx context.Context, since time.Time, query *query.QueryParams[T]) ([]T, error)
+	FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]T, error)
 	FindOneByIdentifier(ctx context.Context, identifier identifier.IIdentifier) (T, error)
 
 	// Mutation operations
 	Insert(ctx context.Context, entity T) (T, error)
 	Update(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error)
+	UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error)
+	UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error)
+	Save(ctx context.Context, entity T) (T, error)
+	MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error)
 	Delete(ctx context.Context, identifier identifier.IIdentifier) error
+	DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error)
 
 	// Soft-delete lifecycle
 	SoftDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error)
+	SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (T, error)
+	Replace(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error)
 	HardDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error)
 
 	// Bulk operations
 	BulkInsert(ctx context.Context, entities []T) ([]T, error)
 	BulkUpdate(ctx context.Context, entities []T) ([]T, error)
 	BulkSoftDelete(ctx context.Context, identifiers []identifier.IIdentifier) error
+	BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error)
+	SoftDeleteWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error)
 	BulkHardDelete(ctx context.Context, identifiers []identifier.IIdentifier) error
+	PruneWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error)
 
 	// Trash management
 	GetTrashed(ctx context.Context) ([]T, error)
+	GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]T, error)
 	GetTrashedWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error)
 	Restore(ctx context.Context, identifier identifier.IIdentifier) (T, error)
+	RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error)
 	RestoreAll(ctx context.Context) error
 
 	// Utility operations
 	Count(ctx context.Context, query *query.QueryParams[T]) (int64, error)
+	CountPages(ctx context.Context, query *query.QueryParams[T]) (int64, int, error)
+	CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error)
+	CountBy(ctx context.Context, identifier identifier.IIdentifier, opts unit_of_work.CountOptions) (int64, error)
 	Exists(ctx context.Context, identifier identifier.IIdentifier) (bool, error)
+	MustExist(ctx context.Context, identifier identifier.IIdentifier) error
+	ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error)
 }
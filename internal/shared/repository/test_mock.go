@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
 	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
 )
 
@@ -36,7 +38,34 @@ type mockUnitOfWork struct {
 	BeginTransactionCalled         bool
 	CommitTransactionCalled        bool
 	RollbackTransactionCalled      bool
+	RunInTransactionCalled         bool
 	ResolveIDByUniqueFieldCalled   bool
+	GetTrashedByIdentifierCalled   bool
+	RollbackTransactionECalled     bool
+	UpdateECalled                  bool
+	PruneWhereCalled               bool
+	UpdateIncludingTrashedCalled   bool
+	FindByIDsCalled                bool
+	SoftDeleteWithNoteCalled       bool
+	ResolveIDByFieldsCalled        bool
+	BulkSoftDeleteECalled          bool
+	DeleteECalled                  bool
+	CountIncludingTrashedCalled    bool
+	FindOneIncludingTrashedCalled  bool
+	InTransactionCalled            bool
+	PingCalled                     bool
+	ExistsWhereCalled              bool
+	SaveCalled                     bool
+	CountPagesCalled               bool
+	CountByCalled                  bool
+	FindChangedSinceCalled         bool
+	MergeJSONCalled                bool
+	DialectCalled                  bool
+	ReplaceCalled                  bool
+	RestoreAllMatchingCalled       bool
+	MustExistCalled                bool
+	SoftDeleteWhereCalled          bool
+	FindTopCalled                  bool
 
 	// Mock return values
 	FindAllResult                  []*testutil.TestEntity
@@ -58,6 +87,31 @@ type mockUnitOfWork struct {
 	CountResult                    int64
 	ExistsResult                   bool
 	ResolveIDByUniqueFieldResult   int
+	GetTrashedByIdentifierResult   []*testutil.TestEntity
+	UpdateEResult                  *testutil.TestEntity
+	UpdateERowsAffected            int64
+	PruneWhereResult               int64
+	UpdateIncludingTrashedResult   *testutil.TestEntity
+	FindByIDsResult                []*testutil.TestEntity
+	SoftDeleteWithNoteResult       *testutil.TestEntity
+	ResolveIDByFieldsResult        int
+	BulkSoftDeleteEResult          int64
+	DeleteEResult                  int64
+	CountIncludingTrashedResult    int64
+	FindOneIncludingTrashedResult  *testutil.TestEntity
+	InTransactionResult            bool
+	ExistsWhereResult              bool
+	SaveResult                     *testutil.TestEntity
+	CountPagesTotal                int64
+	CountPagesPages                int
+	CountByResult                  int64
+	FindChangedSinceResult         []*testutil.TestEntity
+	MergeJSONRowsAffected          int64
+	DialectResult                  string
+	ReplaceResult                  *testutil.TestEntity
+	RestoreAllMatchingResult       int64
+	SoftDeleteWhereResult          int64
+	FindTopResult                  []*testutil.TestEntity
 
 	// Mock error values
 	FindAllError                  error
@@ -82,7 +136,32 @@ type mockUnitOfWork struct {
 	ExistsError                   error
 	BeginTransactionError         error
 	CommitTransactionError        error
+	RunInTransactionError         error
 	ResolveIDByUniqueFieldError   error
+	GetTrashedByIdentifierError   error
+	RollbackTransactionEError     error
+	UpdateEError                  error
+	PruneWhereError               error
+	UpdateIncludingTrashedError   error
+	FindByIDsError                error
+	SoftDeleteWithNoteError       error
+	ResolveIDByFieldsError        error
+	BulkSoftDeleteEError          error
+	DeleteEError                  error
+	CountIncludingTrashedError    error
+	FindOneIncludingTrashedError  error
+	PingError                     error
+	ExistsWhereError              error
+	SaveError                     error
+	CountPagesError               error
+	CountByError                  error
+	FindChangedSinceError         error
+	MergeJSONError                error
+	ReplaceError                  error
+	RestoreAllMatchingError       error
+	MustExistError                error
+	SoftDeleteWhereError          error
+	FindTopError                  error
 }
 
 // Mock method implementations
@@ -200,7 +279,145 @@ func (m *mockUnitOfWork) RollbackTransaction(ctx context.Context) {
 	m.RollbackTransactionCalled = true
 }
 
+func (m *mockUnitOfWork) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.RunInTransactionCalled = true
+	if m.RunInTransactionError != nil {
+		return m.RunInTransactionError
+	}
+	return fn(ctx)
+}
+
 func (m *mockUnitOfWork) ResolveIDByUniqueField(ctx context.Context, model types.IBaseModel, field string, value interface{}) (int, error) {
 	m.ResolveIDByUniqueFieldCalled = true
 	return m.ResolveIDByUniqueFieldResult, m.ResolveIDByUniqueFieldError
 }
+
+func (m *mockUnitOfWork) GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]*testutil.TestEntity, error) {
+	m.GetTrashedByIdentifierCalled = true
+	return m.GetTrashedByIdentifierResult, m.GetTrashedByIdentifierError
+}
+
+func (m *mockUnitOfWork) RollbackTransactionE(ctx context.Context) error {
+	m.RollbackTransactionECalled = true
+	return m.RollbackTransactionEError
+}
+
+func (m *mockUnitOfWork) UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity *testutil.TestEntity) (*testutil.TestEntity, int64, error) {
+	m.UpdateECalled = true
+	return m.UpdateEResult, m.UpdateERowsAffected, m.UpdateEError
+}
+
+func (m *mockUnitOfWork) PruneWhere(ctx context.Context, params *query.QueryParams[*testutil.TestEntity]) (int64, error) {
+	m.PruneWhereCalled = true
+	return m.PruneWhereResult, m.PruneWhereError
+}
+
+func (m *mockUnitOfWork) UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity *testutil.TestEntity) (*testutil.TestEntity, error) {
+	m.UpdateIncludingTrashedCalled = true
+	return m.UpdateIncludingTrashedResult, m.UpdateIncludingTrashedError
+}
+
+func (m *mockUnitOfWork) FindByIDs(ctx context.Context, ids []int) ([]*testutil.TestEntity, error) {
+	m.FindByIDsCalled = true
+	return m.FindByIDsResult, m.FindByIDsError
+}
+
+func (m *mockUnitOfWork) SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (*testutil.TestEntity, error) {
+	m.SoftDeleteWithNoteCalled = true
+	return m.SoftDeleteWithNoteResult, m.SoftDeleteWithNoteError
+}
+
+func (m *mockUnitOfWork) ResolveIDByFields(ctx context.Context, model types.IBaseModel, fields map[string]interface{}) (int, error) {
+	m.ResolveIDByFieldsCalled = true
+	return m.ResolveIDByFieldsResult, m.ResolveIDByFieldsError
+}
+
+func (m *mockUnitOfWork) BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error) {
+	m.BulkSoftDeleteECalled = true
+	return m.BulkSoftDeleteEResult, m.BulkSoftDeleteEError
+}
+
+func (m *mockUnitOfWork) DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	m.DeleteECalled = true
+	return m.DeleteEResult, m.DeleteEError
+}
+
+func (m *mockUnitOfWork) CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	m.CountIncludingTrashedCalled = true
+	return m.CountIncludingTrashedResult, m.CountIncludingTrashedError
+}
+
+func (m *mockUnitOfWork) FindOneIncludingTrashed(ctx context.Context, filter *testutil.TestEntity) (*testutil.TestEntity, error) {
+	m.FindOneIncludingTrashedCalled = true
+	return m.FindOneIncludingTrashedResult, m.FindOneIncludingTrashedError
+}
+
+func (m *mockUnitOfWork) InTransaction() bool {
+	m.InTransactionCalled = true
+	return m.InTransactionResult
+}
+
+func (m *mockUnitOfWork) Ping(ctx context.Context) error {
+	m.PingCalled = true
+	return m.PingError
+}
+
+func (m *mockUnitOfWork) ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error) {
+	m.ExistsWhereCalled = true
+	return m.ExistsWhereResult, m.ExistsWhereError
+}
+
+func (m *mockUnitOfWork) Save(ctx context.Context, entity *testutil.TestEntity) (*testutil.TestEntity, error) {
+	m.SaveCalled = true
+	return m.SaveResult, m.SaveError
+}
+
+func (m *mockUnitOfWork) CountPages(ctx context.Context, query *query.QueryParams[*testutil.TestEntity]) (int64, int, error) {
+	m.CountPagesCalled = true
+	return m.CountPagesTotal, m.CountPagesPages, m.CountPagesError
+}
+
+func (m *mockUnitOfWork) CountBy(ctx context.Context, identifier identifier.IIdentifier, opts unit_of_work.CountOptions) (int64, error) {
+	m.CountByCalled = true
+	return m.CountByResult, m.CountByError
+}
+
+func (m *mockUnitOfWork) FindChangedSince(ctx context.Context, since time.Time, query *query.QueryParams[*testutil.TestEntity]) ([]*testutil.TestEntity, error) {
+	m.FindChangedSinceCalled = true
+	return m.FindChangedSinceResult, m.FindChangedSinceError
+}
+
+func (m *mockUnitOfWork) MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error) {
+	m.MergeJSONCalled = true
+	return m.MergeJSONRowsAffected, m.MergeJSONError
+}
+
+func (m *mockUnitOfWork) Dialect() string {
+	m.DialectCalled = true
+	return m.DialectResult
+}
+
+func (m *mockUnitOfWork) Replace(ctx context.Context, identifier identifier.IIdentifier, entity *testutil.TestEntity) (*testutil.TestEntity, error) {
+	m.ReplaceCalled = true
+	return m.ReplaceResult, m.ReplaceError
+}
+
+func (m *mockUnitOfWork) RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	m.RestoreAllMatchingCalled = true
+	return m.RestoreAllMatchingResult, m.RestoreAllMatchingError
+}
+
+func (m *mockUnitOfWork) MustExist(ctx context.Context, identifier identifier.IIdentifier) error {
+	m.MustExistCalled = true
+	return m.MustExistError
+}
+
+func (m *mockUnitOfWork) SoftDeleteWhere(ctx context.Context, params *query.QueryParams[*testutil.TestEntity]) (int64, error) {
+	m.SoftDeleteWhereCalled = true
+	return m.SoftDeleteWhereResult, m.SoftDeleteWhereError
+}
+
+func (m *mockUnitOfWork) FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]*testutil.TestEntity, error) {
+	m.FindTopCalled = true
+	return m.FindTopResult, m.FindTopError
+}
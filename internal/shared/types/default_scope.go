@@ -0,0 +1,12 @@
+package types
+
+import "github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+
+// IDefaultScoped is implemented by entities whose queries must always be restricted by a
+// set of filter criteria, such as a visibility rule, much like soft-deleted rows are hidden.
+// Unit of work implementations inject the scope into every statement unless the caller
+// explicitly opts out through QueryParams.IgnoreDefaultScope.
+type IDefaultScoped interface {
+	// DefaultScope returns the criteria applied to every query on the entity
+	DefaultScope() []identifier.FilterCriteria
+}
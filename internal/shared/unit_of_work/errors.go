@@ -0,0 +1,26 @@
+package unit_of_work
+
+import "errors"
+
+var (
+	// ErrNoActiveTransaction is returned when committing without a transaction in progress
+	ErrNoActiveTransaction = errors.New("no active transaction")
+
+	// ErrTransactionInProgress is returned when beginning a transaction while another is in progress
+	ErrTransactionInProgress = errors.New("transaction already in progress")
+
+	// ErrEntityNotFound is returned by MustExist when no live entity matches the identifier.
+	// The returned error also matches gorm.ErrRecordNotFound, the error of the other lookups.
+	ErrEntityNotFound = errors.New("entity not found")
+
+	// ErrUnboundedDelete is returned when a hard delete has no conditions and would empty the table
+	ErrUnboundedDelete = errors.New("hard delete without conditions would remove every row")
+
+	// ErrUnsupportedDialect is returned by operations that have no implementation for the
+	// database behind the unit of work
+	ErrUnsupportedDialect = errors.New("operation is not supported by this database dialect")
+
+	// ErrLockingOutsideTransaction is returned when a locking read runs outside a transaction,
+	// where its row locks would be released as soon as the statement ends
+	ErrLockingOutsideTransaction = errors.New("locking reads require an active transaction")
+)
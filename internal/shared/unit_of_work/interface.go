@@ -2,6 +2,7 @@ package unit_of_work
 
 import (
 	"context"
+	"time"
 
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
@@ -21,15 +22,43 @@ import (
 // - Bulk operations with explicit semantics
 type IUnitOfWork[T types.IBaseModel] interface {
 	// Transaction management
-	// BeginTransaction starts a new database transaction
+	// BeginTransaction starts a new database transaction; it fails with ErrTransactionInProgress
+	// when one is already active
 	BeginTransaction(ctx context.Context) error
 
-	// CommitTransaction commits the current transaction
+	// CommitTransaction commits the current transaction; it fails with ErrNoActiveTransaction
+	// when none is active
 	CommitTransaction(ctx context.Context) error
 
 	// RollbackTransaction rolls back the current transaction
 	RollbackTransaction(ctx context.Context)
 
+	// RollbackTransactionE rolls back the current transaction and returns any rollback error
+	RollbackTransactionE(ctx context.Context) error
+
+	// RunInTransaction runs fn in a transaction, committing on success and rolling back on error.
+	// Implementations retry the whole body when the failure is transient.
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// InTransaction reports whether a transaction started by BeginTransaction is in progress,
+	// so callers can join it instead of nesting another one
+	InTransaction() bool
+
+	// Health check
+	// Ping runs a lightweight round trip to the database, for readiness probes
+	Ping(ctx context.Context) error
+
+	// Dialect returns the name of the backing database, such as "postgres", "mysql" or "sqlite",
+	// so generic code can branch on backend capabilities
+	Dialect() string
+
+	IDataOperations[T]
+}
+
+// IDataOperations groups the data access methods shared by a unit of work and a
+// transaction handle obtained from it. Every method runs in the transaction of its
+// receiver, if any.
+type IDataOperations[T types.IBaseModel] interface {
 	// Basic queries
 	// FindAll retrieves all entities of type T (excluding soft-deleted by default)
 	FindAll(ctx context.Context) ([]T, error)
@@ -40,9 +69,23 @@ type IUnitOfWork[T types.IBaseModel] interface {
 	// FindOne retrieves a single entity matching the provided filter
 	FindOne(ctx context.Context, filter T) (T, error)
 
+	// FindOneIncludingTrashed retrieves a single entity matching the provided filter, including soft-deleted ones
+	FindOneIncludingTrashed(ctx context.Context, filter T) (T, error)
+
 	// FindOneById retrieves a single entity by its ID
 	FindOneById(ctx context.Context, id int) (T, error)
 
+	// FindByIDs retrieves the non-deleted entities with the given IDs in one query, ordered by ID
+	FindByIDs(ctx context.Context, ids []int) ([]T, error)
+
+	// FindChangedSince retrieves the entities updated at or after since, oldest change first,
+	// for incremental sync consumers
+	FindChangedSince(ctx context.Context, since time.Time, query *query.QueryParams[T]) ([]T, error)
+
+	// FindTop retrieves at most n live entities matching the identifier in the given order,
+	// without counting the matches, for "top N" reads such as the most recent entries
+	FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]T, error)
+
 	// FindOneByIdentifier retrieves a single entity using the IIdentifier filter system
 	FindOneByIdentifier(ctx context.Context, identifier identifier.IIdentifier) (T, error)
 
@@ -53,25 +96,56 @@ type IUnitOfWork[T types.IBaseModel] interface {
 	// Update modifies entities matching the identifier with the provided entity data
 	Update(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error)
 
+	// UpdateE modifies entities matching the identifier and returns the number of affected rows
+	UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error)
+
+	// UpdateIncludingTrashed modifies an entity matching the identifier even when it is soft-deleted
+	UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error)
+
+	// Save inserts the entity when it has no ID yet and updates it by ID otherwise
+	Save(ctx context.Context, entity T) (T, error)
+
+	// MergeJSON merges the keys of patch into a JSON column of the entities matching the
+	// identifier without replacing the whole document, and returns the number of rows updated
+	MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error)
+
 	// Delete performs a logical operation (soft-delete by default, hard-delete if configured)
 	Delete(ctx context.Context, identifier identifier.IIdentifier) error
 
+	// DeleteE performs the same logical delete and returns the number of rows deleted
+	DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error)
+
 	// Soft-delete lifecycle management
 	// SoftDelete performs soft deletion by setting DeletedAt timestamp
 	SoftDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error)
 
+	// SoftDeleteWithNote soft-deletes and records the reason in the audit note in one statement
+	SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (T, error)
+
+	// Replace soft-deletes the live entities matching the identifier and inserts entity in their
+	// place atomically, in the active transaction or in its own
+	Replace(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error)
+
 	// HardDelete permanently removes entities from the database
 	HardDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error)
 
 	// GetTrashed retrieves all soft-deleted entities
 	GetTrashed(ctx context.Context) ([]T, error)
 
+	// GetTrashedByIdentifier retrieves soft-deleted entities matching the identifier, most recently deleted first
+	GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]T, error)
+
 	// GetTrashedWithPagination retrieves soft-deleted entities with pagination
 	GetTrashedWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error)
 
-	// Restore recovers soft-deleted entities by clearing their DeletedAt timestamp
+	// Restore recovers a single soft-deleted entity, the most recently deleted when several
+	// match, by clearing its DeletedAt timestamp
 	Restore(ctx context.Context, identifier identifier.IIdentifier) (T, error)
 
+	// RestoreAllMatching recovers every soft-deleted entity matching the identifier and
+	// returns how many were restored
+	RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error)
+
 	// RestoreAll recovers all soft-deleted entities of type T
 	RestoreAll(ctx context.Context) error
 
@@ -85,18 +159,67 @@ type IUnitOfWork[T types.IBaseModel] interface {
 	// BulkSoftDelete soft-deletes multiple entities identified by the provided identifiers
 	BulkSoftDelete(ctx context.Context, identifiers []identifier.IIdentifier) error
 
+	// BulkSoftDeleteE soft-deletes multiple entities and returns the number of rows moved to the trash
+	BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error)
+
+	// SoftDeleteWhere soft-deletes all live entities matching the query filters in one statement
+	// and returns the number of rows moved to the trash
+	SoftDeleteWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error)
+
 	// BulkHardDelete permanently removes multiple entities identified by the provided identifiers
 	BulkHardDelete(ctx context.Context, identifiers []identifier.IIdentifier) error
 
+	// PruneWhere permanently removes all entities matching the query filters, including soft-deleted ones,
+	// and returns the number of deleted rows
+	PruneWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error)
+
 	// Utility operations
 	// ResolveIDByUniqueField finds the ID of an entity by searching a unique field
 	ResolveIDByUniqueField(ctx context.Context, model types.IBaseModel, field string, value interface{}) (int, error)
 
+	// ResolveIDByFields finds the ID of an entity by a composite unique key, matching every field
+	ResolveIDByFields(ctx context.Context, model types.IBaseModel, fields map[string]interface{}) (int, error)
+
 	// Count returns the total number of entities matching the query parameters
 	Count(ctx context.Context, query *query.QueryParams[T]) (int64, error)
 
+	// CountPages returns the total number of matching entities and the number of pages of
+	// PageSize items, without fetching any entity
+	CountPages(ctx context.Context, query *query.QueryParams[T]) (int64, int, error)
+
+	// CountIncludingTrashed returns the number of entities matching the identifier, live and soft-deleted
+	CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error)
+
+	// CountBy returns the number of entities matching the identifier, with soft-delete
+	// visibility and distinct counting controlled by opts
+	CountBy(ctx context.Context, identifier identifier.IIdentifier, opts CountOptions) (int64, error)
+
 	// Exists checks if any entity matches the provided identifier
 	Exists(ctx context.Context, identifier identifier.IIdentifier) (bool, error)
+
+	// MustExist returns ErrEntityNotFound, which also matches gorm.ErrRecordNotFound, when no
+	// live entity matches the identifier, as a precondition before Update or Delete
+	MustExist(ctx context.Context, identifier identifier.IIdentifier) error
+
+	// ExistsWhere checks if any entity matches both the identifier and the extra predicate
+	ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error)
+}
+
+// ITransaction is a handle on a single database transaction. It exposes the same data
+// methods as IUnitOfWork, all bound to the transaction, and owns its lifecycle, so the
+// unit of work that started it keeps no transaction state and can be shared across
+// goroutines. A handle must not be used after Commit or Rollback.
+type ITransaction[T types.IBaseModel] interface {
+	IDataOperations[T]
+
+	// Commit commits the transaction
+	Commit(ctx context.Context) error
+
+	// Rollback rolls back the transaction; it returns an error if the transaction is already done
+	Rollback(ctx context.Context) error
+
+	// InTransaction reports whether the transaction has not been committed or rolled back yet
+	InTransaction() bool
 }
 
 // IUnitOfWorkFactory defines the contract for creating unit of work instances.
@@ -127,6 +250,18 @@ type TransactionOptions struct {
 	Timeout int64
 }
 
+// CountOptions configures CountBy
+type CountOptions struct {
+	// IncludeDeleted counts soft-deleted entities together with live ones
+	IncludeDeleted bool
+
+	// OnlyDeleted counts only soft-deleted entities; it takes precedence over IncludeDeleted
+	OnlyDeleted bool
+
+	// Distinct, when set, counts the distinct non-NULL values of this field instead of entities
+	Distinct string
+}
+
 // BulkOperationResult provides information about the outcome of bulk operations
 type BulkOperationResult struct {
 	// SuccessCount is the number of entities successfully processed
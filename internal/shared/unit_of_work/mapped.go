@@ -0,0 +1,19 @@
+package unit_of_work
+
+import (
+	"context"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/mapping"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+)
+
+// FindAllMapped retrieves all non-deleted entities through uow and transforms each with fn,
+// for example into DTOs. It is a function rather than a method because Go methods cannot
+// introduce the extra type parameter R.
+func FindAllMapped[T types.IBaseModel, R any](ctx context.Context, uow IUnitOfWork[T], fn func(T) R) ([]R, error) {
+	entities, err := uow.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapping.Map(entities, fn), nil
+}
@@ -0,0 +1,108 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// advisoryLock is a held advisory lock and the connection pinned to it
+type advisoryLock struct {
+	conn *sql.Conn
+	stop func() bool // Cancels the release scheduled for the end of the acquiring context
+}
+
+// TryAdvisoryLock attempts to acquire the session-level Postgres advisory lock identified by key
+// without blocking, using pg_try_advisory_lock. Advisory locks belong to the connection that
+// took them, so a dedicated connection is checked out of the pool and held until AdvisoryUnlock
+// releases the key or ctx ends, whichever comes first; with a context that never ends, such as
+// context.Background(), AdvisoryUnlock is the only way to return the connection. Acquiring a
+// key this unit of work already holds returns true immediately.
+func (uow *PostgresUnitOfWork[T]) TryAdvisoryLock(ctx context.Context, key int64) (bool, error) {
+	uow.lockMu.Lock()
+	defer uow.lockMu.Unlock()
+
+	if _, held := uow.advisoryLocks[key]; held {
+		return true, nil
+	}
+
+	sqlDB, err := uow.db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return false, err
+	}
+	if !acquired {
+		return false, conn.Close()
+	}
+
+	if uow.advisoryLocks == nil {
+		uow.advisoryLocks = make(map[int64]*advisoryLock)
+	}
+	lock := &advisoryLock{conn: conn}
+	lock.stop = context.AfterFunc(ctx, func() {
+		uow.lockMu.Lock()
+		owned := uow.advisoryLocks[key] == lock
+		if owned {
+			delete(uow.advisoryLocks, key)
+		}
+		uow.lockMu.Unlock()
+
+		if owned {
+			_ = releaseAdvisoryLock(context.Background(), conn, key)
+		}
+	})
+	uow.advisoryLocks[key] = lock
+	return true, nil
+}
+
+// AdvisoryUnlock releases an advisory lock acquired with TryAdvisoryLock and returns its
+// connection to the pool. The unlock runs even when ctx is already cancelled, bounded by
+// advisoryUnlockTimeout. Releasing a key that is not held by this unit of work is an error.
+func (uow *PostgresUnitOfWork[T]) AdvisoryUnlock(ctx context.Context, key int64) error {
+	uow.lockMu.Lock()
+	lock, held := uow.advisoryLocks[key]
+	if held {
+		delete(uow.advisoryLocks, key)
+	}
+	uow.lockMu.Unlock()
+
+	if !held {
+		return fmt.Errorf("advisory lock %d is not held", key)
+	}
+	lock.stop()
+	return releaseAdvisoryLock(ctx, lock.conn, key)
+}
+
+// advisoryUnlockTimeout bounds the pg_advisory_unlock round trip, which runs independently of
+// the cancellation of the caller's context
+const advisoryUnlockTimeout = 5 * time.Second
+
+// releaseAdvisoryLock unlocks key on conn and closes conn, returning it to the pool. When the
+// unlock fails the connection may still hold the lock, so it is discarded instead of pooled.
+func releaseAdvisoryLock(ctx context.Context, conn *sql.Conn, key int64) error {
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), advisoryUnlockTimeout)
+	defer cancel()
+
+	var released bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", key).Scan(&released); err != nil {
+		_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		return err
+	}
+	if !released {
+		return fmt.Errorf("advisory lock %d was not held by the session", key)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	registerAdvisoryDriver sync.Once
+	advisoryConnIDs        atomic.Int64
+	advisoryLockOwners     sync.Map // lock key -> owning connection id
+)
+
+// setupAdvisoryLockDB opens a SQLite database whose connections emulate the Postgres
+// pg_try_advisory_lock and pg_advisory_unlock functions with per-connection ownership
+func setupAdvisoryLockDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	registerAdvisoryDriver.Do(func() {
+		sql.Register("sqlite3_advisory", &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				connID := advisoryConnIDs.Add(1)
+				if err := conn.RegisterFunc("pg_try_advisory_lock", func(key int64) bool {
+					owner, loaded := advisoryLockOwners.LoadOrStore(key, connID)
+					return !loaded || owner == connID
+				}, false); err != nil {
+					return err
+				}
+				return conn.RegisterFunc("pg_advisory_unlock", func(key int64) bool {
+					return advisoryLockOwners.CompareAndDelete(key, connID)
+				}, false)
+			},
+		})
+	})
+
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.New(sqlite.Config{DriverName: "sqlite3_advisory", DSN: dsn}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	return db
+}
+
+// TestPostgresUnitOfWork_AdvisoryLock validates acquiring, contending for and releasing an advisory lock
+func TestPostgresUnitOfWork_AdvisoryLock(t *testing.T) {
+	// Arrange
+	db := setupAdvisoryLockDB(t)
+	ctx := context.Background()
+	first := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	second := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	const key int64 = 42
+
+	// Act & Assert
+	acquired, err := first.TryAdvisoryLock(ctx, key)
+	if err != nil || !acquired {
+		t.Fatalf("Expected first session to acquire the lock, got acquired=%v err=%v", acquired, err)
+	}
+
+	acquired, err = second.TryAdvisoryLock(ctx, key)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if acquired {
+		t.Fatal("Expected second session to be refused the held lock")
+	}
+
+	if err := first.AdvisoryUnlock(ctx, key); err != nil {
+		t.Fatalf("Failed to release lock: %v", err)
+	}
+
+	acquired, err = second.TryAdvisoryLock(ctx, key)
+	if err != nil || !acquired {
+		t.Fatalf("Expected second session to acquire the released lock, got acquired=%v err=%v", acquired, err)
+	}
+	if err := second.AdvisoryUnlock(ctx, key); err != nil {
+		t.Fatalf("Failed to release lock: %v", err)
+	}
+}
+
+// TestPostgresUnitOfWork_AdvisoryUnlock_CancelledContext validates that a lock is released even
+// when the unlock is called with an already cancelled context
+func TestPostgresUnitOfWork_AdvisoryUnlock_CancelledContext(t *testing.T) {
+	// Arrange
+	db := setupAdvisoryLockDB(t)
+	first := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	second := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	const key int64 = 44
+	acquired, err := first.TryAdvisoryLock(context.Background(), key)
+	if err != nil || !acquired {
+		t.Fatalf("Expected first session to acquire the lock, got acquired=%v err=%v", acquired, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err = first.AdvisoryUnlock(ctx, key)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the unlock to ignore the cancelled context, got: %v", err)
+	}
+	acquired, err = second.TryAdvisoryLock(context.Background(), key)
+	if err != nil || !acquired {
+		t.Fatalf("Expected second session to acquire the released lock, got acquired=%v err=%v", acquired, err)
+	}
+	if err := second.AdvisoryUnlock(context.Background(), key); err != nil {
+		t.Fatalf("Failed to release lock: %v", err)
+	}
+}
+
+// TestPostgresUnitOfWork_AdvisoryUnlock_NotHeld validates that releasing an unheld key fails
+func TestPostgresUnitOfWork_AdvisoryUnlock_NotHeld(t *testing.T) {
+	// Arrange
+	db := setupAdvisoryLockDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+	// Act
+	err := uow.AdvisoryUnlock(context.Background(), 7)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when releasing a lock that is not held")
+	}
+}
+
+// TestPostgresUnitOfWork_AdvisoryLock_ContextDone validates that a lock is released with its
+// pinned connection once the acquiring context ends
+func TestPostgresUnitOfWork_AdvisoryLock_ContextDone(t *testing.T) {
+	// Arrange
+	db := setupAdvisoryLockDB(t)
+	first := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	second := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	const key int64 = 43
+
+	ctx, cancel := context.WithCancel(context.Background())
+	acquired, err := first.TryAdvisoryLock(ctx, key)
+	if err != nil || !acquired {
+		t.Fatalf("Expected first session to acquire the lock, got acquired=%v err=%v", acquired, err)
+	}
+
+	// Act
+	cancel()
+
+	// Assert
+	deadline := time.Now().Add(time.Second)
+	for {
+		acquired, err = second.TryAdvisoryLock(context.Background(), key)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if acquired {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the lock to be released after the acquiring context ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := first.AdvisoryUnlock(context.Background(), key); err == nil {
+		t.Error("Expected the released key to no longer be held by the first session")
+	}
+	if err := second.AdvisoryUnlock(context.Background(), key); err != nil {
+		t.Fatalf("Failed to release lock: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+)
+
+// AggregateFunc names an SQL aggregate function supported by ScalarAggregate
+type AggregateFunc string
+
+const (
+	// AggregateSum adds up the field over matching rows
+	AggregateSum AggregateFunc = "sum"
+
+	// AggregateAvg averages the field over matching rows
+	AggregateAvg AggregateFunc = "avg"
+
+	// AggregateMin returns the smallest value of the field
+	AggregateMin AggregateFunc = "min"
+
+	// AggregateMax returns the largest value of the field
+	AggregateMax AggregateFunc = "max"
+
+	// AggregateCount counts the non-NULL values of the field, or all rows for "*"
+	AggregateCount AggregateFunc = "count"
+)
+
+// aggregateFuncs lists the supported aggregate functions
+var aggregateFuncs = map[AggregateFunc]bool{
+	AggregateSum:   true,
+	AggregateAvg:   true,
+	AggregateMin:   true,
+	AggregateMax:   true,
+	AggregateCount: true,
+}
+
+// AggregateSpec describes an aggregate computed per group by GroupAggregate, such as
+// COUNT(*) per status
+type AggregateSpec struct {
+	GroupBy []string         // Columns the rows are grouped by, at least one
+	Func    AggregateFunc    // Aggregate computed for each group
+	Field   string           // Aggregated field, or "*" with AggregateCount
+	Having  *HavingCondition // Keeps only groups whose aggregate satisfies it; nil keeps every group
+}
+
+// HavingCondition compares the aggregate of a group against Value, as in HAVING COUNT(*) > 5
+type HavingCondition struct {
+	Operator identifier.FilterOperator // One of eq, neq, gt, gte, lt, lte
+	Value    interface{}
+}
+
+// AggregateGroup is one group returned by GroupAggregate
+type AggregateGroup struct {
+	Keys  map[string]interface{} // Group column values, keyed by the GroupBy names
+	Value float64                // Aggregate of the group, 0 when NULL
+}
+
+// havingOperators maps the comparison operators usable in a HAVING condition to SQL
+var havingOperators = map[identifier.FilterOperator]string{
+	identifier.FilterOperatorEqual:        "=",
+	identifier.FilterOperatorNotEqual:     "!=",
+	identifier.FilterOperatorGreaterThan:  ">",
+	identifier.FilterOperatorGreaterEqual: ">=",
+	identifier.FilterOperatorLessThan:     "<",
+	identifier.FilterOperatorLessEqual:    "<=",
+}
+
+// ScalarAggregate computes a single numeric aggregate such as sum(amount) over the rows
+// matching params. Filters, search and soft-delete visibility are honored; sorting,
+// preloads and pagination are ignored. An aggregate over no rows (NULL) yields 0.
+func (uow *PostgresUnitOfWork[T]) ScalarAggregate(ctx context.Context, params *query.QueryParams[T], fn AggregateFunc, field string) (float64, error) {
+	var result sql.NullFloat64
+	if err := uow.ScalarAggregateInto(ctx, params, fn, field, &result); err != nil {
+		return 0, err
+	}
+	return result.Float64, nil
+}
+
+// ScalarAggregateInto behaves like ScalarAggregate but scans the result into dest, for
+// non-numeric aggregates such as max(created_at). Use a sql.Null* or pointer destination
+// when no rows may match.
+func (uow *PostgresUnitOfWork[T]) ScalarAggregateInto(ctx context.Context, params *query.QueryParams[T], fn AggregateFunc, field string, dest interface{}) error {
+	expression, err := uow.aggregateExpression(fn, field)
+	if err != nil {
+		return err
+	}
+
+	db := uow.getDB()
+	return uow.withReadRetry(ctx, func() error {
+		query := uow.filterApplier.ApplyQueryConditions(db.WithContext(ctx).Model(new(T)), params)
+		return query.Select(expression).Scan(dest).Error
+	})
+}
+
+// aggregateExpression validates fn and field and renders the aggregate SQL expression
+func (uow *PostgresUnitOfWork[T]) aggregateExpression(fn AggregateFunc, field string) (string, error) {
+	fn = AggregateFunc(strings.ToLower(strings.TrimSpace(string(fn))))
+	if !aggregateFuncs[fn] {
+		return "", domainerrors.NewValidationError(field, fmt.Sprintf("unsupported aggregate function %q", fn))
+	}
+
+	if field == "*" && fn == AggregateCount {
+		return "COUNT(*)", nil
+	}
+	if err := ValidateFieldName(field); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(string(fn)), uow.filterApplier.columnName(field)), nil
+}
+
+// GroupAggregate computes spec's aggregate for each group of the rows matching params, ordered
+// by the group columns, keeping only the groups that satisfy spec.Having when set, e.g. the
+// statuses with more than five rows. Filters, search and soft-delete visibility are honored;
+// sorting, preloads and pagination are ignored.
+func (uow *PostgresUnitOfWork[T]) GroupAggregate(ctx context.Context, params *query.QueryParams[T], spec AggregateSpec) ([]AggregateGroup, error) {
+	expression, err := uow.aggregateExpression(spec.Func, spec.Field)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.GroupBy) == 0 {
+		return nil, domainerrors.NewValidationError("groupBy", "at least one group column is required")
+	}
+	columns := make([]string, len(spec.GroupBy))
+	for i, field := range spec.GroupBy {
+		if err := ValidateFieldName(field); err != nil {
+			return nil, err
+		}
+		columns[i] = uow.filterApplier.columnName(field)
+	}
+	grouping := strings.Join(columns, ", ")
+
+	var groups []AggregateGroup
+	db := uow.getDB()
+	err = uow.withReadRetry(ctx, func() error {
+		groups = nil
+		query := uow.filterApplier.ApplyQueryConditions(db.WithContext(ctx).Model(new(T)), params).
+			Select(grouping + ", " + expression).
+			Group(grouping).
+			Order(grouping)
+		query = uow.filterApplier.ApplyHaving(query, expression, spec.Having)
+
+		rows, err := query.Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			keys := make([]interface{}, len(columns))
+			var value sql.NullFloat64
+			dest := make([]interface{}, 0, len(columns)+1)
+			for i := range keys {
+				dest = append(dest, &keys[i])
+			}
+			if err := rows.Scan(append(dest, &value)...); err != nil {
+				return err
+			}
+
+			group := AggregateGroup{Keys: make(map[string]interface{}, len(columns)), Value: value.Float64}
+			for i, field := range spec.GroupBy {
+				group.Keys[field] = keys[i]
+			}
+			groups = append(groups, group)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// DistinctValues returns the unique values of field among the rows matching params, in
+// ascending order, for building filter dropdowns. Filters, search and soft-delete
+// visibility are honored; sorting, preloads and pagination are ignored. NULL is
+// included as nil when present.
+func (uow *PostgresUnitOfWork[T]) DistinctValues(ctx context.Context, field string, params *query.QueryParams[T]) ([]interface{}, error) {
+	if err := ValidateFieldName(field); err != nil {
+		return nil, err
+	}
+	column := uow.filterApplier.columnName(field)
+
+	var values []interface{}
+	db := uow.getDB()
+	err := uow.withReadRetry(ctx, func() error {
+		values = nil
+		query := uow.filterApplier.ApplyQueryConditions(db.WithContext(ctx).Model(new(T)), params)
+		return query.Distinct(column).Order(column).Pluck(column, &values).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,271 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// seedAggregateEntities inserts entities with known ages, soft-deleting the oldest one
+func seedAggregateEntities(t *testing.T, uow *PostgresUnitOfWork[*testutil.TestEntity]) {
+	t.Helper()
+
+	ctx := context.Background()
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "A", Age: 20, Status: "active"},
+		{Name: "B", Age: 30, Status: "active"},
+		{Name: "C", Age: 45, Status: "inactive"},
+		{Name: "D", Age: 90, Status: "active"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[3].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+}
+
+// TestPostgresUnitOfWork_ScalarAggregate validates aggregates over live, filtered rows
+func TestPostgresUnitOfWork_ScalarAggregate(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       AggregateFunc
+		field    string
+		filters  identifier.IIdentifier
+		expected float64
+	}{
+		{"Sum", AggregateSum, "age", nil, 95},
+		{"Max", AggregateMax, "age", nil, 45},
+		{"Min", AggregateMin, "age", nil, 20},
+		{"Avg with filter", AggregateAvg, "age", identifier.NewIdentifier().Equal("status", "active"), 25},
+		{"Count all", AggregateCount, "*", nil, 3},
+		{"Case-insensitive function", "SUM", "age", nil, 95},
+		{"No matching rows", AggregateSum, "age", identifier.NewIdentifier().Equal("status", "unknown"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+			seedAggregateEntities(t, uow)
+			params := query.NewQueryParams[*testutil.TestEntity]().WithFilters(tt.filters)
+
+			// Act
+			result, err := uow.ScalarAggregate(context.Background(), params, tt.fn, tt.field)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_ScalarAggregateInto validates scanning a non-numeric aggregate
+func TestPostgresUnitOfWork_ScalarAggregateInto(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	seedAggregateEntities(t, uow)
+
+	// Act
+	var latest sql.NullString
+	err := uow.ScalarAggregateInto(context.Background(), query.NewQueryParams[*testutil.TestEntity](), AggregateMax, "name", &latest)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if latest.String != "C" {
+		t.Errorf("Expected 'C', got %q", latest.String)
+	}
+}
+
+// TestPostgresUnitOfWork_ScalarAggregate_Invalid validates that unsafe input is rejected
+func TestPostgresUnitOfWork_ScalarAggregate_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    AggregateFunc
+		field string
+	}{
+		{"Unsupported function", "stddev", "age"},
+		{"Invalid field", AggregateSum, "age); DROP TABLE test_entities; --"},
+		{"Star outside count", AggregateSum, "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+			// Act
+			_, err := uow.ScalarAggregate(context.Background(), query.NewQueryParams[*testutil.TestEntity](), tt.fn, tt.field)
+
+			// Assert
+			var validationErr *domainerrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_DistinctValues validates that each value among matching live rows is returned once
+func TestPostgresUnitOfWork_DistinctValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		params   *query.QueryParams[*testutil.TestEntity]
+		expected []interface{}
+	}{
+		{"All live rows", "status", nil, []interface{}{"active", "inactive"}},
+		{"Filtered", "status", query.NewQueryParams[*testutil.TestEntity]().WithFilters(identifier.NewIdentifier().GreaterThan("age", 40)), []interface{}{"inactive"}},
+		{"Numeric column", "age", query.NewQueryParams[*testutil.TestEntity]().WithFilters(identifier.NewIdentifier().Equal("status", "active")), []interface{}{int64(20), int64(30)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+			seedAggregateEntities(t, uow)
+
+			// Act
+			values, err := uow.DistinctValues(context.Background(), tt.field, tt.params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(values) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, values)
+			}
+			for i, want := range tt.expected {
+				if values[i] != want {
+					t.Errorf("Expected value %d to be %#v, got %#v", i, want, values[i])
+				}
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_DistinctValues_InvalidField validates that unsafe field names are rejected
+func TestPostgresUnitOfWork_DistinctValues_InvalidField(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+	// Act
+	_, err := uow.DistinctValues(context.Background(), "status; DROP TABLE test_entities", nil)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
+
+// TestPostgresUnitOfWork_GroupAggregate validates per-group aggregates and that only the
+// groups passing the HAVING condition are returned
+func TestPostgresUnitOfWork_GroupAggregate(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     AggregateSpec
+		expected []AggregateGroup
+	}{
+		{
+			name: "Count per status",
+			spec: AggregateSpec{GroupBy: []string{"status"}, Func: AggregateCount, Field: "*"},
+			expected: []AggregateGroup{
+				{Keys: map[string]interface{}{"status": "active"}, Value: 2},
+				{Keys: map[string]interface{}{"status": "inactive"}, Value: 1},
+			},
+		},
+		{
+			name: "Having count above threshold",
+			spec: AggregateSpec{GroupBy: []string{"status"}, Func: AggregateCount, Field: "*",
+				Having: &HavingCondition{Operator: identifier.FilterOperatorGreaterThan, Value: 1}},
+			expected: []AggregateGroup{
+				{Keys: map[string]interface{}{"status": "active"}, Value: 2},
+			},
+		},
+		{
+			name: "Having sum",
+			spec: AggregateSpec{GroupBy: []string{"status"}, Func: AggregateSum, Field: "age",
+				Having: &HavingCondition{Operator: identifier.FilterOperatorGreaterEqual, Value: 45}},
+			expected: []AggregateGroup{
+				{Keys: map[string]interface{}{"status": "active"}, Value: 50},
+				{Keys: map[string]interface{}{"status": "inactive"}, Value: 45},
+			},
+		},
+		{
+			name: "No group passes",
+			spec: AggregateSpec{GroupBy: []string{"status"}, Func: AggregateCount, Field: "*",
+				Having: &HavingCondition{Operator: identifier.FilterOperatorGreaterThan, Value: 5}},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+			seedAggregateEntities(t, uow)
+
+			// Act
+			groups, err := uow.GroupAggregate(context.Background(), query.NewQueryParams[*testutil.TestEntity](), tt.spec)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !reflect.DeepEqual(groups, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, groups)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_GroupAggregate_Invalid validates that unsafe group columns and
+// unsupported HAVING operators are rejected
+func TestPostgresUnitOfWork_GroupAggregate_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec AggregateSpec
+	}{
+		{"No group columns", AggregateSpec{Func: AggregateCount, Field: "*"}},
+		{"Invalid group column", AggregateSpec{GroupBy: []string{"status; DROP TABLE test_entities"}, Func: AggregateCount, Field: "*"}},
+		{"Unsupported HAVING operator", AggregateSpec{GroupBy: []string{"status"}, Func: AggregateCount, Field: "*",
+			Having: &HavingCondition{Operator: identifier.FilterOperatorLike, Value: "1%"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+			// Act
+			_, err := uow.GroupAggregate(context.Background(), nil, tt.spec)
+
+			// Assert
+			var validationErr *domainerrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError, got: %v", err)
+			}
+		})
+	}
+}
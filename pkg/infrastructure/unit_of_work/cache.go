@@ -0,0 +1,454 @@
+package unit_of_work
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+)
+
+// Cache stores encoded lookup results for CachedUnitOfWork. Implement it over Redis or another
+// shared store to share cached results between processes. Implementations should report backend
+// failures as misses: the cache only ever saves database round trips and never fails a call.
+type Cache interface {
+	// Get returns the value stored under key, and false when it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool)
+
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(ctx context.Context, prefix string)
+}
+
+// memoryCacheEntry is a value held by MemoryCache with its expiry
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache for a single instance. Expired entries are dropped when read.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+	now     func() time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value stored under key, and false when it is missing or expired
+func (mc *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry, ok := mc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !mc.now().Before(entry.expiresAt) {
+		delete(mc.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores value under key for ttl
+func (mc *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.entries[key] = memoryCacheEntry{value: value, expiresAt: mc.now().Add(ttl)}
+}
+
+// DeletePrefix removes every key starting with prefix
+func (mc *MemoryCache) DeletePrefix(ctx context.Context, prefix string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for key := range mc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(mc.entries, key)
+		}
+	}
+}
+
+// CachedUnitOfWork decorates an IUnitOfWork with a read-through cache for FindOneById and
+// Exists. Results are cached for the configured TTL under keys built from the entity type,
+// method and arguments, and every cached result of the entity type is invalidated by any
+// mutation made through the decorator, including commits. Writes that bypass it, such as
+// another process without a shared cache, are only picked up once the TTL expires.
+//
+// Entities are cached with encoding/gob, so every exported field is restored on a hit whatever
+// its JSON tags; unexported fields, which GORM does not map to columns, come back zeroed.
+// Results that gob cannot encode, such as entities with unregistered interface fields, are
+// not cached. Reads inside a transaction always go to the database.
+//
+// Keys do not include ctx, so scopes that depend on it, such as a tenant scope registered with
+// WithScopes that reads the tenant from ctx, are not told apart: one tenant's cached result is
+// returned to another. Do not put a CachedUnitOfWork in front of such a unit of work.
+type CachedUnitOfWork[T types.IBaseModel] struct {
+	inner  unit_of_work.IUnitOfWork[T]
+	cache  Cache
+	ttl    time.Duration
+	prefix string
+}
+
+// NewCachedUnitOfWork wraps inner with a cache holding FindOneById and Exists results for ttl
+func NewCachedUnitOfWork[T types.IBaseModel](inner unit_of_work.IUnitOfWork[T], cache Cache, ttl time.Duration) unit_of_work.IUnitOfWork[T] {
+	var zero T
+	return &CachedUnitOfWork[T]{
+		inner:  inner,
+		cache:  cache,
+		ttl:    ttl,
+		prefix: fmt.Sprintf("uow:%T:", zero),
+	}
+}
+
+// invalidate drops every cached result of the entity type
+func (c *CachedUnitOfWork[T]) invalidate(ctx context.Context) {
+	c.cache.DeletePrefix(ctx, c.prefix)
+}
+
+// cached returns the decoded value stored under key, or loads it and caches a successful
+// result. Inside a transaction it always loads, since the transaction may see uncommitted rows.
+func cached[T types.IBaseModel, V any](ctx context.Context, c *CachedUnitOfWork[T], key string, load func() (V, error)) (V, error) {
+	if c.inner.InTransaction() {
+		return load()
+	}
+
+	key = c.prefix + key
+	if data, ok := c.cache.Get(ctx, key); ok {
+		var value V
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(value); err == nil {
+		c.cache.Set(ctx, key, data.Bytes(), c.ttl)
+	}
+	return value, nil
+}
+
+// FindOneById retrieves a single entity by its ID, from the cache when present
+func (c *CachedUnitOfWork[T]) FindOneById(ctx context.Context, id int) (T, error) {
+	return cached(ctx, c, "FindOneById:"+strconv.Itoa(id), func() (T, error) { return c.inner.FindOneById(ctx, id) })
+}
+
+// Exists checks if any entity matches the provided identifier, from the cache when present.
+// Identifiers with subquery filters, which the JSON key cannot represent, or whose criteria
+// cannot be encoded as JSON are not cached.
+func (c *CachedUnitOfWork[T]) Exists(ctx context.Context, ident identifier.IIdentifier) (bool, error) {
+	var criteria []identifier.FilterCriteria
+	if ident != nil {
+		criteria = ident.ToFilterCriteria()
+	}
+	if hasSubquery(criteria) {
+		return c.inner.Exists(ctx, ident)
+	}
+	key, err := json.Marshal(criteria)
+	if err != nil {
+		return c.inner.Exists(ctx, ident)
+	}
+	return cached(ctx, c, "Exists:"+string(key), func() (bool, error) { return c.inner.Exists(ctx, ident) })
+}
+
+// hasSubquery reports whether any criterion, including nested groups, filters by a subquery
+func hasSubquery(criteria []identifier.FilterCriteria) bool {
+	for _, criterion := range criteria {
+		if criterion.Subquery != nil || hasSubquery(criterion.Group) {
+			return true
+		}
+	}
+	return false
+}
+
+// BeginTransaction starts a new database transaction
+func (c *CachedUnitOfWork[T]) BeginTransaction(ctx context.Context) error {
+	return c.inner.BeginTransaction(ctx)
+}
+
+// CommitTransaction commits the current transaction
+func (c *CachedUnitOfWork[T]) CommitTransaction(ctx context.Context) error {
+	defer c.invalidate(ctx)
+	return c.inner.CommitTransaction(ctx)
+}
+
+// RollbackTransaction rolls back the current transaction
+func (c *CachedUnitOfWork[T]) RollbackTransaction(ctx context.Context) {
+	c.inner.RollbackTransaction(ctx)
+}
+
+// RollbackTransactionE rolls back the current transaction and returns the rollback error
+func (c *CachedUnitOfWork[T]) RollbackTransactionE(ctx context.Context) error {
+	return c.inner.RollbackTransactionE(ctx)
+}
+
+// RunInTransaction executes fn inside a transaction
+func (c *CachedUnitOfWork[T]) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	defer c.invalidate(ctx)
+	return c.inner.RunInTransaction(ctx, fn)
+}
+
+// InTransaction reports whether the wrapped unit of work is in a transaction
+func (c *CachedUnitOfWork[T]) InTransaction() bool {
+	return c.inner.InTransaction()
+}
+
+// Ping checks that the database answers
+func (c *CachedUnitOfWork[T]) Ping(ctx context.Context) error {
+	return c.inner.Ping(ctx)
+}
+
+// Dialect returns the name of the database behind the wrapped unit of work
+func (c *CachedUnitOfWork[T]) Dialect() string {
+	return c.inner.Dialect()
+}
+
+// FindAll retrieves all non-deleted entities
+func (c *CachedUnitOfWork[T]) FindAll(ctx context.Context) ([]T, error) {
+	return c.inner.FindAll(ctx)
+}
+
+// FindAllWithPagination retrieves entities with pagination support and returns total count
+func (c *CachedUnitOfWork[T]) FindAllWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error) {
+	return c.inner.FindAllWithPagination(ctx, query)
+}
+
+// FindOne retrieves a single entity matching the provided filter
+func (c *CachedUnitOfWork[T]) FindOne(ctx context.Context, filter T) (T, error) {
+	return c.inner.FindOne(ctx, filter)
+}
+
+// FindOneIncludingTrashed retrieves a single entity matching the provided filter, including soft-deleted ones
+func (c *CachedUnitOfWork[T]) FindOneIncludingTrashed(ctx context.Context, filter T) (T, error) {
+	return c.inner.FindOneIncludingTrashed(ctx, filter)
+}
+
+// FindByIDs retrieves the live entities whose IDs are in ids
+func (c *CachedUnitOfWork[T]) FindByIDs(ctx context.Context, ids []int) ([]T, error) {
+	return c.inner.FindByIDs(ctx, ids)
+}
+
+// FindChangedSince retrieves the entities updated at or after since, oldest change first
+func (c *CachedUnitOfWork[T]) FindChangedSince(ctx context.Context, since time.Time, query *query.QueryParams[T]) ([]T, error) {
+	return c.inner.FindChangedSince(ctx, since, query)
+}
+
+// FindTop retrieves at most n live entities matching the identifier in the given order
+func (c *CachedUnitOfWork[T]) FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]T, error) {
+	return c.inner.FindTop(ctx, n, sort, identifier)
+}
+
+// FindOneByIdentifier retrieves a single entity using the IIdentifier filter system
+func (c *CachedUnitOfWork[T]) FindOneByIdentifier(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	return c.inner.FindOneByIdentifier(ctx, identifier)
+}
+
+// Insert creates a new entity
+func (c *CachedUnitOfWork[T]) Insert(ctx context.Context, entity T) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.Insert(ctx, entity)
+}
+
+// Update modifies an existing entity
+func (c *CachedUnitOfWork[T]) Update(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.Update(ctx, identifier, entity)
+}
+
+// UpdateE modifies an existing entity and returns the number of affected rows
+func (c *CachedUnitOfWork[T]) UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.UpdateE(ctx, identifier, entity)
+}
+
+// UpdateIncludingTrashed modifies an entity even if it is soft-deleted
+func (c *CachedUnitOfWork[T]) UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.UpdateIncludingTrashed(ctx, identifier, entity)
+}
+
+// Save inserts or updates an entity depending on whether it has an ID
+func (c *CachedUnitOfWork[T]) Save(ctx context.Context, entity T) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.Save(ctx, entity)
+}
+
+// MergeJSON merges the keys of patch into a JSON column of the entities matching the identifier
+func (c *CachedUnitOfWork[T]) MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.MergeJSON(ctx, identifier, column, patch)
+}
+
+// Delete performs a logical delete operation
+func (c *CachedUnitOfWork[T]) Delete(ctx context.Context, identifier identifier.IIdentifier) error {
+	defer c.invalidate(ctx)
+	return c.inner.Delete(ctx, identifier)
+}
+
+// DeleteE performs a logical delete operation and returns the number of rows deleted
+func (c *CachedUnitOfWork[T]) DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.DeleteE(ctx, identifier)
+}
+
+// SoftDelete performs soft deletion
+func (c *CachedUnitOfWork[T]) SoftDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.SoftDelete(ctx, identifier)
+}
+
+// SoftDeleteWithNote soft-deletes and records the reason in the audit note
+func (c *CachedUnitOfWork[T]) SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.SoftDeleteWithNote(ctx, identifier, note)
+}
+
+// Replace soft-deletes the live entities matching the identifier and inserts entity atomically
+func (c *CachedUnitOfWork[T]) Replace(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.Replace(ctx, identifier, entity)
+}
+
+// HardDelete permanently removes entities from the database
+func (c *CachedUnitOfWork[T]) HardDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.HardDelete(ctx, identifier)
+}
+
+// GetTrashed retrieves all soft-deleted entities
+func (c *CachedUnitOfWork[T]) GetTrashed(ctx context.Context) ([]T, error) {
+	return c.inner.GetTrashed(ctx)
+}
+
+// GetTrashedByIdentifier retrieves soft-deleted entities matching the identifier
+func (c *CachedUnitOfWork[T]) GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]T, error) {
+	return c.inner.GetTrashedByIdentifier(ctx, identifier)
+}
+
+// GetTrashedWithPagination retrieves soft-deleted entities with pagination
+func (c *CachedUnitOfWork[T]) GetTrashedWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error) {
+	return c.inner.GetTrashedWithPagination(ctx, query)
+}
+
+// Restore recovers a soft-deleted entity
+func (c *CachedUnitOfWork[T]) Restore(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.Restore(ctx, identifier)
+}
+
+// RestoreAllMatching recovers every soft-deleted entity matching the identifier
+func (c *CachedUnitOfWork[T]) RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.RestoreAllMatching(ctx, identifier)
+}
+
+// RestoreAll recovers all soft-deleted entities
+func (c *CachedUnitOfWork[T]) RestoreAll(ctx context.Context) error {
+	defer c.invalidate(ctx)
+	return c.inner.RestoreAll(ctx)
+}
+
+// BulkInsert creates multiple entities
+func (c *CachedUnitOfWork[T]) BulkInsert(ctx context.Context, entities []T) ([]T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.BulkInsert(ctx, entities)
+}
+
+// BulkUpdate modifies multiple entities
+func (c *CachedUnitOfWork[T]) BulkUpdate(ctx context.Context, entities []T) ([]T, error) {
+	defer c.invalidate(ctx)
+	return c.inner.BulkUpdate(ctx, entities)
+}
+
+// BulkSoftDelete soft-deletes multiple entities
+func (c *CachedUnitOfWork[T]) BulkSoftDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
+	defer c.invalidate(ctx)
+	return c.inner.BulkSoftDelete(ctx, identifiers)
+}
+
+// BulkSoftDeleteE soft-deletes multiple entities and returns the number of rows moved to the trash
+func (c *CachedUnitOfWork[T]) BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.BulkSoftDeleteE(ctx, identifiers)
+}
+
+// SoftDeleteWhere soft-deletes all live entities matching the query filters
+func (c *CachedUnitOfWork[T]) SoftDeleteWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.SoftDeleteWhere(ctx, query)
+}
+
+// BulkHardDelete permanently removes multiple entities
+func (c *CachedUnitOfWork[T]) BulkHardDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
+	defer c.invalidate(ctx)
+	return c.inner.BulkHardDelete(ctx, identifiers)
+}
+
+// PruneWhere permanently removes every entity matching the query filters
+func (c *CachedUnitOfWork[T]) PruneWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.inner.PruneWhere(ctx, query)
+}
+
+// ResolveIDByUniqueField finds the ID of an entity by searching a unique field
+func (c *CachedUnitOfWork[T]) ResolveIDByUniqueField(ctx context.Context, model types.IBaseModel, field string, value interface{}) (int, error) {
+	return c.inner.ResolveIDByUniqueField(ctx, model, field, value)
+}
+
+// ResolveIDByFields finds the ID of an entity by a composite unique key
+func (c *CachedUnitOfWork[T]) ResolveIDByFields(ctx context.Context, model types.IBaseModel, fields map[string]interface{}) (int, error) {
+	return c.inner.ResolveIDByFields(ctx, model, fields)
+}
+
+// Count returns the total number of entities matching the query parameters
+func (c *CachedUnitOfWork[T]) Count(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
+	return c.inner.Count(ctx, query)
+}
+
+// CountPages returns the total number of matching entities and the number of pages
+func (c *CachedUnitOfWork[T]) CountPages(ctx context.Context, query *query.QueryParams[T]) (int64, int, error) {
+	return c.inner.CountPages(ctx, query)
+}
+
+// CountIncludingTrashed returns the number of entities matching the identifier, including soft-deleted ones
+func (c *CachedUnitOfWork[T]) CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return c.inner.CountIncludingTrashed(ctx, identifier)
+}
+
+// CountBy returns the number of entities matching the identifier, configured by opts
+func (c *CachedUnitOfWork[T]) CountBy(ctx context.Context, identifier identifier.IIdentifier, opts unit_of_work.CountOptions) (int64, error) {
+	return c.inner.CountBy(ctx, identifier, opts)
+}
+
+// MustExist returns ErrEntityNotFound when no live entity matches the identifier
+func (c *CachedUnitOfWork[T]) MustExist(ctx context.Context, identifier identifier.IIdentifier) error {
+	return c.inner.MustExist(ctx, identifier)
+}
+
+// ExistsWhere checks if any entity matches both the identifier and the extra predicate
+func (c *CachedUnitOfWork[T]) ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error) {
+	return c.inner.ExistsWhere(ctx, identifier, extra)
+}
@@ -0,0 +1,239 @@
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestCachedUnitOfWork_FindOneById validates that a repeated lookup is served from the cache
+// and that an update invalidates it
+func TestCachedUnitOfWork_FindOneById(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewCachedUnitOfWork(NewPostgresUnitOfWork[*testutil.TestEntity](db), NewMemoryCache(), time.Minute)
+	entity, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Original", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	statements := countStatements(t, db)
+	if _, err := uow.FindOneById(ctx, entity.GetID()); err != nil {
+		t.Fatalf("Failed to load entity: %v", err)
+	}
+
+	// Act
+	cachedEntity, err := uow.FindOneById(ctx, entity.GetID())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if *statements != 1 {
+		t.Errorf("Expected the second lookup to hit the cache, got %d statements", *statements)
+	}
+	if cachedEntity.Name != "Original" || cachedEntity.GetID() != entity.GetID() {
+		t.Errorf("Expected the cached entity, got %+v", cachedEntity)
+	}
+
+	entity.Name = "Renamed"
+	if _, err := uow.Update(ctx, identifier.NewIdentifier().Equal("id", entity.GetID()), entity); err != nil {
+		t.Fatalf("Failed to update entity: %v", err)
+	}
+	*statements = 0
+	reloaded, err := uow.FindOneById(ctx, entity.GetID())
+	if err != nil {
+		t.Fatalf("Failed to reload entity: %v", err)
+	}
+	if *statements != 1 {
+		t.Errorf("Expected the update to invalidate the cache, got %d statements", *statements)
+	}
+	if reloaded.Name != "Renamed" {
+		t.Errorf("Expected the updated name, got %s", reloaded.Name)
+	}
+}
+
+// cachedSecretEntity has a column hidden from JSON
+type cachedSecretEntity struct {
+	types.BaseEntity
+	Name   string `gorm:"column:name"`
+	Secret string `gorm:"column:secret" json:"-"`
+}
+
+// TestCachedUnitOfWork_FindOneById_JSONHiddenFields validates that a cache hit restores columns
+// hidden from JSON, so updating the cached entity does not wipe them
+func TestCachedUnitOfWork_FindOneById_JSONHiddenFields(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	if err := db.AutoMigrate(&cachedSecretEntity{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	ctx := context.Background()
+	uow := NewCachedUnitOfWork(NewPostgresUnitOfWork[*cachedSecretEntity](db), NewMemoryCache(), time.Minute)
+	entity, err := uow.Insert(ctx, &cachedSecretEntity{Name: "Original", Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	if _, err := uow.FindOneById(ctx, entity.GetID()); err != nil {
+		t.Fatalf("Failed to load entity: %v", err)
+	}
+	statements := countStatements(t, db)
+
+	// Act
+	cachedEntity, err := uow.FindOneById(ctx, entity.GetID())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if *statements != 0 {
+		t.Fatalf("Expected the lookup to hit the cache, got %d statements", *statements)
+	}
+	if cachedEntity.Secret != "s3cret" {
+		t.Fatalf("Expected the hidden column to be restored, got %q", cachedEntity.Secret)
+	}
+	cachedEntity.Name = "Renamed"
+	if _, err := uow.Update(ctx, identifier.NewIdentifier().Equal("id", entity.GetID()), cachedEntity); err != nil {
+		t.Fatalf("Failed to update entity: %v", err)
+	}
+	var stored cachedSecretEntity
+	if err := db.First(&stored, entity.GetID()).Error; err != nil {
+		t.Fatalf("Failed to reload entity: %v", err)
+	}
+	if stored.Secret != "s3cret" {
+		t.Errorf("Expected the update to keep the hidden column, got %q", stored.Secret)
+	}
+}
+
+// TestCachedUnitOfWork_Exists validates that existence checks are cached per identifier and
+// refreshed after an insert
+func TestCachedUnitOfWork_Exists(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewCachedUnitOfWork(NewPostgresUnitOfWork[*testutil.TestEntity](db), NewMemoryCache(), time.Minute)
+	byEmail := identifier.NewIdentifier().Equal("email", "john@example.com")
+	statements := countStatements(t, db)
+
+	// Act
+	first, _ := uow.Exists(ctx, byEmail)
+	second, _ := uow.Exists(ctx, identifier.NewIdentifier().Equal("email", "john@example.com"))
+	cachedStatements := *statements
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "John", Email: "john@example.com"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	afterInsert, err := uow.Exists(ctx, byEmail)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if first || second {
+		t.Errorf("Expected no entity before the insert")
+	}
+	if cachedStatements != 1 {
+		t.Errorf("Expected an equal identifier to hit the cache, got %d statements", cachedStatements)
+	}
+	if !afterInsert {
+		t.Errorf("Expected the insert to invalidate the cached result")
+	}
+}
+
+// TestCachedUnitOfWork_Exists_Subquery validates that identifiers differing only in their
+// subquery are not answered from each other's cache entry
+func TestCachedUnitOfWork_Exists_Subquery(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewCachedUnitOfWork(NewPostgresUnitOfWork[*testutil.TestEntity](db), NewMemoryCache(), time.Minute)
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "John"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	matching := identifier.NewSubquery("test_entities", "id", identifier.NewIdentifier().Equal("name", "John"))
+	matchingNothing := identifier.NewSubquery("test_entities", "id", identifier.NewIdentifier().Equal("name", "Jane"))
+
+	// Act
+	first, err := uow.Exists(ctx, identifier.NewIdentifier().InSubquery("id", matching))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	second, err := uow.Exists(ctx, identifier.NewIdentifier().InSubquery("id", matchingNothing))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !first {
+		t.Error("Expected the matching subquery to find the entity")
+	}
+	if second {
+		t.Error("Expected the subquery matching nothing to find no entity")
+	}
+}
+
+// TestCachedUnitOfWork_BypassesCacheInTransaction validates that reads inside a transaction
+// neither use nor fill the cache
+func TestCachedUnitOfWork_BypassesCacheInTransaction(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	uow := NewCachedUnitOfWork(NewPostgresUnitOfWork[*testutil.TestEntity](db), cache, time.Minute)
+	entity, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Entity"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	if err := uow.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer uow.RollbackTransaction(ctx)
+
+	// Act
+	_, err = uow.FindOneById(ctx, entity.GetID())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cache.entries) != 0 {
+		t.Errorf("Expected nothing cached inside a transaction, got %d entries", len(cache.entries))
+	}
+}
+
+// TestMemoryCache validates expiry and prefix deletion
+func TestMemoryCache(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return clock }
+	cache.Set(ctx, "users:1", []byte("a"), time.Minute)
+	cache.Set(ctx, "users:2", []byte("b"), time.Hour)
+	cache.Set(ctx, "orders:1", []byte("c"), time.Hour)
+
+	// Act
+	clock = clock.Add(2 * time.Minute)
+	_, expired := cache.Get(ctx, "users:1")
+	live, liveFound := cache.Get(ctx, "users:2")
+	cache.DeletePrefix(ctx, "users:")
+	_, deleted := cache.Get(ctx, "users:2")
+	_, other := cache.Get(ctx, "orders:1")
+
+	// Assert
+	if expired {
+		t.Errorf("Expected the entry to expire after its TTL")
+	}
+	if !liveFound || string(live) != "b" {
+		t.Errorf("Expected the live entry, got %q", live)
+	}
+	if deleted {
+		t.Errorf("Expected DeletePrefix to remove matching entries")
+	}
+	if !other {
+		t.Errorf("Expected DeletePrefix to keep other entries")
+	}
+}
@@ -0,0 +1,520 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+)
+
+// ErrCircuitOpen is returned without reaching the database while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	// defaultCircuitFailureThreshold is how many consecutive failures open the circuit
+	defaultCircuitFailureThreshold = 5
+
+	// defaultCircuitCooldown is how long the circuit stays open before a probe is allowed
+	defaultCircuitCooldown = 30 * time.Second
+)
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	// circuitClosed lets every call through
+	circuitClosed circuitState = iota
+
+	// circuitOpen fails every call fast until the cooldown has elapsed
+	circuitOpen
+
+	// circuitHalfOpen lets a single probe call through to decide whether to close again
+	circuitHalfOpen
+)
+
+// circuitBreakerConfig holds the optional behavior of a CircuitBreakerUnitOfWork
+type circuitBreakerConfig struct {
+	// failureThreshold is how many consecutive failures open the circuit
+	failureThreshold int
+
+	// cooldown is how long the circuit stays open before a probe is allowed
+	cooldown time.Duration
+
+	// isFailure decides which errors count as failures of the database
+	isFailure func(error) bool
+}
+
+// CircuitBreakerOption configures optional behavior of a CircuitBreakerUnitOfWork
+type CircuitBreakerOption func(*circuitBreakerConfig)
+
+// WithFailureThreshold sets how many consecutive failures open the circuit.
+// A non-positive threshold uses the default.
+func WithFailureThreshold(threshold int) CircuitBreakerOption {
+	return func(cfg *circuitBreakerConfig) {
+		if threshold > 0 {
+			cfg.failureThreshold = threshold
+		}
+	}
+}
+
+// WithCooldown sets how long the circuit stays open before a probe call is let through.
+// A non-positive cooldown uses the default.
+func WithCooldown(cooldown time.Duration) CircuitBreakerOption {
+	return func(cfg *circuitBreakerConfig) {
+		if cooldown > 0 {
+			cfg.cooldown = cooldown
+		}
+	}
+}
+
+// WithFailurePredicate sets which errors count as failures. By default only connection errors
+// and deadline expirations do, so not-found or validation errors never open the circuit.
+func WithFailurePredicate(isFailure func(error) bool) CircuitBreakerOption {
+	return func(cfg *circuitBreakerConfig) {
+		if isFailure != nil {
+			cfg.isFailure = isFailure
+		}
+	}
+}
+
+// isCircuitFailure is the default failure predicate
+func isCircuitFailure(err error) bool {
+	return IsConnectionError(err) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// CircuitBreakerUnitOfWork decorates an IUnitOfWork with a circuit breaker. After a number of
+// consecutive failures the circuit opens and calls fail fast with ErrCircuitOpen instead of
+// waiting on an unavailable database. Once the cooldown has elapsed a single probe call is let
+// through: its success closes the circuit, its failure opens it for another cooldown.
+type CircuitBreakerUnitOfWork[T types.IBaseModel] struct {
+	inner  unit_of_work.IUnitOfWork[T]
+	config circuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex // Guards the fields below
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreakerUnitOfWork wraps inner with a circuit breaker
+func NewCircuitBreakerUnitOfWork[T types.IBaseModel](inner unit_of_work.IUnitOfWork[T], opts ...CircuitBreakerOption) unit_of_work.IUnitOfWork[T] {
+	cfg := circuitBreakerConfig{
+		failureThreshold: defaultCircuitFailureThreshold,
+		cooldown:         defaultCircuitCooldown,
+		isFailure:        isCircuitFailure,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &CircuitBreakerUnitOfWork[T]{
+		inner:  inner,
+		config: cfg,
+		now:    time.Now,
+	}
+}
+
+// allow reports whether a call may proceed, moving an open circuit to half-open once the
+// cooldown has elapsed. Only one probe is in flight while half-open.
+func (cb *CircuitBreakerUnitOfWork[T]) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.config.cooldown {
+			return ErrCircuitOpen
+		}
+		cb.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit with the outcome of a call that was allowed through. A cancelled
+// call says nothing about the database, so it only gives up the probe slot: a half-open circuit
+// returns to open with its cooldown already elapsed, and the next call probes again.
+func (cb *CircuitBreakerUnitOfWork[T]) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) {
+		cb.releaseProbe()
+		return
+	}
+	if err == nil || !cb.config.isFailure(err) {
+		cb.state = circuitClosed
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == circuitHalfOpen || cb.failures >= cb.config.failureThreshold {
+		cb.state = circuitOpen
+		cb.openedAt = cb.now()
+		cb.failures = 0
+	}
+}
+
+// releaseProbe gives up the probe slot of a half-open circuit without deciding its state.
+// cb.mu must be held.
+func (cb *CircuitBreakerUnitOfWork[T]) releaseProbe() {
+	if cb.state == circuitHalfOpen {
+		cb.state = circuitOpen
+	}
+}
+
+// circuitTransactionKey marks the context of a RunInTransaction body run by a circuit breaker
+type circuitTransactionKey struct{}
+
+// guard runs call through the circuit breaker. Calls made with the context of a RunInTransaction
+// body of this breaker pass straight through: the transaction was let in when it began, and
+// blocking its statements, such as those of a probe, would leave it half done. The outcome is
+// recorded in a defer, so a panicking probe releases the half-open circuit.
+func (cb *CircuitBreakerUnitOfWork[T]) guard(ctx context.Context, call func() error) (err error) {
+	if ctx.Value(circuitTransactionKey{}) == cb {
+		return call()
+	}
+	if err := cb.allow(); err != nil {
+		return err
+	}
+
+	completed := false
+	defer func() {
+		if completed {
+			cb.record(err)
+			return
+		}
+		cb.mu.Lock()
+		cb.releaseProbe()
+		cb.mu.Unlock()
+	}()
+	err = call()
+	completed = true
+	return err
+}
+
+// guardValue runs call through the circuit breaker, returning its result
+func guardValue[T types.IBaseModel, R any](cb *CircuitBreakerUnitOfWork[T], ctx context.Context, call func() (R, error)) (R, error) {
+	var result R
+	err := cb.guard(ctx, func() error {
+		var err error
+		result, err = call()
+		return err
+	})
+	return result, err
+}
+
+// Transaction management
+
+// BeginTransaction starts a new database transaction
+func (cb *CircuitBreakerUnitOfWork[T]) BeginTransaction(ctx context.Context) error {
+	return cb.guard(ctx, func() error { return cb.inner.BeginTransaction(ctx) })
+}
+
+// CommitTransaction commits the current transaction. Like a rollback it is never blocked by
+// the breaker, so a transaction that was begun can always be finished.
+func (cb *CircuitBreakerUnitOfWork[T]) CommitTransaction(ctx context.Context) error {
+	return cb.inner.CommitTransaction(ctx)
+}
+
+// RollbackTransaction rolls back the current transaction. It is never blocked by the breaker
+// so that open transactions can always be released.
+func (cb *CircuitBreakerUnitOfWork[T]) RollbackTransaction(ctx context.Context) {
+	cb.inner.RollbackTransaction(ctx)
+}
+
+// RollbackTransactionE rolls back the current transaction and returns the rollback error.
+// It is never blocked by the breaker so that open transactions can always be released.
+func (cb *CircuitBreakerUnitOfWork[T]) RollbackTransactionE(ctx context.Context) error {
+	return cb.inner.RollbackTransactionE(ctx)
+}
+
+// RunInTransaction executes fn inside a transaction. The transaction passes the breaker as a
+// whole; calls fn makes through this breaker with the context it receives are not checked again.
+func (cb *CircuitBreakerUnitOfWork[T]) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return cb.guard(ctx, func() error {
+		return cb.inner.RunInTransaction(ctx, func(ctx context.Context) error {
+			return fn(context.WithValue(ctx, circuitTransactionKey{}, cb))
+		})
+	})
+}
+
+// InTransaction reports whether the wrapped unit of work is in a transaction
+func (cb *CircuitBreakerUnitOfWork[T]) InTransaction() bool {
+	return cb.inner.InTransaction()
+}
+
+// Ping checks that the database answers
+func (cb *CircuitBreakerUnitOfWork[T]) Ping(ctx context.Context) error {
+	return cb.guard(ctx, func() error { return cb.inner.Ping(ctx) })
+}
+
+// Dialect returns the name of the database behind the wrapped unit of work
+func (cb *CircuitBreakerUnitOfWork[T]) Dialect() string {
+	return cb.inner.Dialect()
+}
+
+// Query operations
+
+// FindAll retrieves all non-deleted entities
+func (cb *CircuitBreakerUnitOfWork[T]) FindAll(ctx context.Context) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.FindAll(ctx) })
+}
+
+// FindAllWithPagination retrieves entities with pagination support and returns total count
+func (cb *CircuitBreakerUnitOfWork[T]) FindAllWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error) {
+	var entities []T
+	var total int64
+	err := cb.guard(ctx, func() error {
+		var err error
+		entities, total, err = cb.inner.FindAllWithPagination(ctx, query)
+		return err
+	})
+	return entities, total, err
+}
+
+// FindOne retrieves a single entity matching the provided filter
+func (cb *CircuitBreakerUnitOfWork[T]) FindOne(ctx context.Context, filter T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.FindOne(ctx, filter) })
+}
+
+// FindOneIncludingTrashed retrieves a single entity matching the provided filter, including soft-deleted ones
+func (cb *CircuitBreakerUnitOfWork[T]) FindOneIncludingTrashed(ctx context.Context, filter T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.FindOneIncludingTrashed(ctx, filter) })
+}
+
+// FindOneById retrieves a single entity by its ID
+func (cb *CircuitBreakerUnitOfWork[T]) FindOneById(ctx context.Context, id int) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.FindOneById(ctx, id) })
+}
+
+// FindByIDs retrieves the live entities whose IDs are in ids
+func (cb *CircuitBreakerUnitOfWork[T]) FindByIDs(ctx context.Context, ids []int) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.FindByIDs(ctx, ids) })
+}
+
+// FindChangedSince retrieves the entities updated at or after since, oldest change first
+func (cb *CircuitBreakerUnitOfWork[T]) FindChangedSince(ctx context.Context, since time.Time, query *query.QueryParams[T]) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.FindChangedSince(ctx, since, query) })
+}
+
+// FindTop retrieves at most n live entities matching the identifier in the given order
+func (cb *CircuitBreakerUnitOfWork[T]) FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.FindTop(ctx, n, sort, identifier) })
+}
+
+// FindOneByIdentifier retrieves a single entity using the IIdentifier filter system
+func (cb *CircuitBreakerUnitOfWork[T]) FindOneByIdentifier(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.FindOneByIdentifier(ctx, identifier) })
+}
+
+// Mutation operations
+
+// Insert creates a new entity
+func (cb *CircuitBreakerUnitOfWork[T]) Insert(ctx context.Context, entity T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.Insert(ctx, entity) })
+}
+
+// Update modifies an existing entity
+func (cb *CircuitBreakerUnitOfWork[T]) Update(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.Update(ctx, identifier, entity) })
+}
+
+// UpdateE modifies an existing entity and returns the number of affected rows
+func (cb *CircuitBreakerUnitOfWork[T]) UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error) {
+	var updated T
+	var affected int64
+	err := cb.guard(ctx, func() error {
+		var err error
+		updated, affected, err = cb.inner.UpdateE(ctx, identifier, entity)
+		return err
+	})
+	return updated, affected, err
+}
+
+// UpdateIncludingTrashed modifies an entity even if it is soft-deleted
+func (cb *CircuitBreakerUnitOfWork[T]) UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.UpdateIncludingTrashed(ctx, identifier, entity) })
+}
+
+// Save inserts or updates an entity depending on whether it has an ID
+func (cb *CircuitBreakerUnitOfWork[T]) Save(ctx context.Context, entity T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.Save(ctx, entity) })
+}
+
+// MergeJSON merges the keys of patch into a JSON column of the entities matching the identifier
+func (cb *CircuitBreakerUnitOfWork[T]) MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.MergeJSON(ctx, identifier, column, patch) })
+}
+
+// Delete performs a logical delete operation
+func (cb *CircuitBreakerUnitOfWork[T]) Delete(ctx context.Context, identifier identifier.IIdentifier) error {
+	return cb.guard(ctx, func() error { return cb.inner.Delete(ctx, identifier) })
+}
+
+// DeleteE performs a logical delete operation and returns the number of rows deleted
+func (cb *CircuitBreakerUnitOfWork[T]) DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.DeleteE(ctx, identifier) })
+}
+
+// Soft-delete lifecycle management
+
+// SoftDelete performs soft deletion
+func (cb *CircuitBreakerUnitOfWork[T]) SoftDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.SoftDelete(ctx, identifier) })
+}
+
+// SoftDeleteWithNote soft-deletes and records the reason in the audit note
+func (cb *CircuitBreakerUnitOfWork[T]) SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.SoftDeleteWithNote(ctx, identifier, note) })
+}
+
+// Replace soft-deletes the live entities matching the identifier and inserts entity atomically
+func (cb *CircuitBreakerUnitOfWork[T]) Replace(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.Replace(ctx, identifier, entity) })
+}
+
+// HardDelete permanently removes entities from the database
+func (cb *CircuitBreakerUnitOfWork[T]) HardDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.HardDelete(ctx, identifier) })
+}
+
+// GetTrashed retrieves all soft-deleted entities
+func (cb *CircuitBreakerUnitOfWork[T]) GetTrashed(ctx context.Context) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.GetTrashed(ctx) })
+}
+
+// GetTrashedByIdentifier retrieves soft-deleted entities matching the identifier
+func (cb *CircuitBreakerUnitOfWork[T]) GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.GetTrashedByIdentifier(ctx, identifier) })
+}
+
+// GetTrashedWithPagination retrieves soft-deleted entities with pagination
+func (cb *CircuitBreakerUnitOfWork[T]) GetTrashedWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error) {
+	var entities []T
+	var total int64
+	err := cb.guard(ctx, func() error {
+		var err error
+		entities, total, err = cb.inner.GetTrashedWithPagination(ctx, query)
+		return err
+	})
+	return entities, total, err
+}
+
+// Restore recovers a soft-deleted entity
+func (cb *CircuitBreakerUnitOfWork[T]) Restore(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	return guardValue(cb, ctx, func() (T, error) { return cb.inner.Restore(ctx, identifier) })
+}
+
+// RestoreAllMatching recovers every soft-deleted entity matching the identifier
+func (cb *CircuitBreakerUnitOfWork[T]) RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.RestoreAllMatching(ctx, identifier) })
+}
+
+// RestoreAll recovers all soft-deleted entities
+func (cb *CircuitBreakerUnitOfWork[T]) RestoreAll(ctx context.Context) error {
+	return cb.guard(ctx, func() error { return cb.inner.RestoreAll(ctx) })
+}
+
+// Bulk operations
+
+// BulkInsert creates multiple entities
+func (cb *CircuitBreakerUnitOfWork[T]) BulkInsert(ctx context.Context, entities []T) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.BulkInsert(ctx, entities) })
+}
+
+// BulkUpdate modifies multiple entities
+func (cb *CircuitBreakerUnitOfWork[T]) BulkUpdate(ctx context.Context, entities []T) ([]T, error) {
+	return guardValue(cb, ctx, func() ([]T, error) { return cb.inner.BulkUpdate(ctx, entities) })
+}
+
+// BulkSoftDelete soft-deletes multiple entities
+func (cb *CircuitBreakerUnitOfWork[T]) BulkSoftDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
+	return cb.guard(ctx, func() error { return cb.inner.BulkSoftDelete(ctx, identifiers) })
+}
+
+// BulkSoftDeleteE soft-deletes multiple entities and returns the number of rows moved to the trash
+func (cb *CircuitBreakerUnitOfWork[T]) BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.BulkSoftDeleteE(ctx, identifiers) })
+}
+
+// SoftDeleteWhere soft-deletes all live entities matching the query filters
+func (cb *CircuitBreakerUnitOfWork[T]) SoftDeleteWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.SoftDeleteWhere(ctx, query) })
+}
+
+// BulkHardDelete permanently removes multiple entities
+func (cb *CircuitBreakerUnitOfWork[T]) BulkHardDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
+	return cb.guard(ctx, func() error { return cb.inner.BulkHardDelete(ctx, identifiers) })
+}
+
+// PruneWhere permanently removes every entity matching the query filters
+func (cb *CircuitBreakerUnitOfWork[T]) PruneWhere(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.PruneWhere(ctx, query) })
+}
+
+// Utility operations
+
+// ResolveIDByUniqueField finds the ID of an entity by searching a unique field
+func (cb *CircuitBreakerUnitOfWork[T]) ResolveIDByUniqueField(ctx context.Context, model types.IBaseModel, field string, value interface{}) (int, error) {
+	return guardValue(cb, ctx, func() (int, error) { return cb.inner.ResolveIDByUniqueField(ctx, model, field, value) })
+}
+
+// ResolveIDByFields finds the ID of an entity by a composite unique key
+func (cb *CircuitBreakerUnitOfWork[T]) ResolveIDByFields(ctx context.Context, model types.IBaseModel, fields map[string]interface{}) (int, error) {
+	return guardValue(cb, ctx, func() (int, error) { return cb.inner.ResolveIDByFields(ctx, model, fields) })
+}
+
+// Count returns the total number of entities matching the query parameters
+func (cb *CircuitBreakerUnitOfWork[T]) Count(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.Count(ctx, query) })
+}
+
+// CountPages returns the total number of matching entities and the number of pages
+func (cb *CircuitBreakerUnitOfWork[T]) CountPages(ctx context.Context, query *query.QueryParams[T]) (int64, int, error) {
+	var pages int
+	total, err := guardValue(cb, ctx, func() (int64, error) {
+		total, p, err := cb.inner.CountPages(ctx, query)
+		pages = p
+		return total, err
+	})
+	return total, pages, err
+}
+
+// CountIncludingTrashed returns the number of entities matching the identifier, including soft-deleted ones
+func (cb *CircuitBreakerUnitOfWork[T]) CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.CountIncludingTrashed(ctx, identifier) })
+}
+
+// CountBy returns the number of entities matching the identifier, configured by opts
+func (cb *CircuitBreakerUnitOfWork[T]) CountBy(ctx context.Context, identifier identifier.IIdentifier, opts unit_of_work.CountOptions) (int64, error) {
+	return guardValue(cb, ctx, func() (int64, error) { return cb.inner.CountBy(ctx, identifier, opts) })
+}
+
+// Exists checks if any entity matches the provided identifier
+func (cb *CircuitBreakerUnitOfWork[T]) Exists(ctx context.Context, identifier identifier.IIdentifier) (bool, error) {
+	return guardValue(cb, ctx, func() (bool, error) { return cb.inner.Exists(ctx, identifier) })
+}
+
+// MustExist returns ErrEntityNotFound when no live entity matches the identifier
+func (cb *CircuitBreakerUnitOfWork[T]) MustExist(ctx context.Context, identifier identifier.IIdentifier) error {
+	return cb.guard(ctx, func() error { return cb.inner.MustExist(ctx, identifier) })
+}
+
+// ExistsWhere checks if any entity matches both the identifier and the extra predicate
+func (cb *CircuitBreakerUnitOfWork[T]) ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error) {
+	return guardValue(cb, ctx, func() (bool, error) { return cb.inner.ExistsWhere(ctx, identifier, extra) })
+}
+
+// Compile-time check to ensure CircuitBreakerUnitOfWork implements IUnitOfWork
+var _ unit_of_work.IUnitOfWork[types.IBaseModel] = (*CircuitBreakerUnitOfWork[types.IBaseModel])(nil)
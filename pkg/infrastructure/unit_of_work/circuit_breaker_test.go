@@ -0,0 +1,290 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// stubUnitOfWork counts FindAll calls and fails them with err, or panics when panics is set
+type stubUnitOfWork struct {
+	unit_of_work.IUnitOfWork[*testutil.TestEntity]
+	calls         int
+	commits       int
+	err           error
+	panics        bool
+	inTransaction bool
+}
+
+// InTransaction reports the simulated transaction state
+func (s *stubUnitOfWork) InTransaction() bool {
+	return s.inTransaction
+}
+
+// RunInTransaction runs fn in a simulated transaction
+func (s *stubUnitOfWork) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.inTransaction = true
+	defer func() { s.inTransaction = false }()
+	return fn(ctx)
+}
+
+// CommitTransaction records the commit and ends the simulated transaction
+func (s *stubUnitOfWork) CommitTransaction(ctx context.Context) error {
+	s.commits++
+	s.inTransaction = false
+	return nil
+}
+
+// FindAll records the call and returns the configured error
+func (s *stubUnitOfWork) FindAll(ctx context.Context) ([]*testutil.TestEntity, error) {
+	s.calls++
+	if s.panics {
+		panic("stub failure")
+	}
+	return nil, s.err
+}
+
+// newTestCircuitBreaker wraps a stub with a breaker opening after 3 failures and a controllable clock
+func newTestCircuitBreaker(stub *stubUnitOfWork) (*CircuitBreakerUnitOfWork[*testutil.TestEntity], *time.Time) {
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreakerUnitOfWork[*testutil.TestEntity](stub, WithFailureThreshold(3), WithCooldown(time.Minute)).(*CircuitBreakerUnitOfWork[*testutil.TestEntity])
+	cb.now = func() time.Time { return clock }
+	return cb, &clock
+}
+
+// TestCircuitBreaker_OpensAfterConsecutiveFailures validates that an open circuit fails fast
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{err: driver.ErrBadConn}
+	cb, _ := newTestCircuitBreaker(stub)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := cb.FindAll(ctx); !errors.Is(err, driver.ErrBadConn) {
+			t.Fatalf("Expected connection error on call %d, got: %v", i, err)
+		}
+	}
+
+	// Act
+	_, err := cb.FindAll(ctx)
+
+	// Assert
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got: %v", err)
+	}
+	if stub.calls != 3 {
+		t.Errorf("Expected the wrapped unit of work to be called 3 times, got %d", stub.calls)
+	}
+}
+
+// TestCircuitBreaker_IgnoresQueryErrors validates that errors from a healthy database do not open the circuit
+func TestCircuitBreaker_IgnoresQueryErrors(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{err: gorm.ErrRecordNotFound}
+	cb, _ := newTestCircuitBreaker(stub)
+	ctx := context.Background()
+
+	// Act
+	for i := 0; i < 5; i++ {
+		_, _ = cb.FindAll(ctx)
+	}
+
+	// Assert
+	if stub.calls != 5 {
+		t.Errorf("Expected every call to reach the wrapped unit of work, got %d", stub.calls)
+	}
+}
+
+// TestCircuitBreaker_HalfOpen validates that a probe after the cooldown closes or reopens the circuit
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name         string
+		probeErr     error
+		expectedNext error
+		expectedCall int
+	}{
+		{"Successful probe closes", nil, nil, 5},
+		{"Failed probe reopens", driver.ErrBadConn, ErrCircuitOpen, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			stub := &stubUnitOfWork{err: driver.ErrBadConn}
+			cb, clock := newTestCircuitBreaker(stub)
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				_, _ = cb.FindAll(ctx)
+			}
+			*clock = clock.Add(time.Minute)
+			stub.err = tt.probeErr
+
+			// Act
+			_, probeErr := cb.FindAll(ctx)
+			_, nextErr := cb.FindAll(ctx)
+
+			// Assert
+			if !errors.Is(probeErr, tt.probeErr) {
+				t.Errorf("Expected probe error %v, got: %v", tt.probeErr, probeErr)
+			}
+			if !errors.Is(nextErr, tt.expectedNext) {
+				t.Errorf("Expected next error %v, got: %v", tt.expectedNext, nextErr)
+			}
+			if stub.calls != tt.expectedCall {
+				t.Errorf("Expected %d calls to the wrapped unit of work, got %d", tt.expectedCall, stub.calls)
+			}
+		})
+	}
+}
+
+// TestCircuitBreaker_HalfOpenTransactionProbe validates that calls inside a RunInTransaction
+// probe are not rejected as a second probe
+func TestCircuitBreaker_HalfOpenTransactionProbe(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{err: driver.ErrBadConn}
+	cb, clock := newTestCircuitBreaker(stub)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, _ = cb.FindAll(ctx)
+	}
+	*clock = clock.Add(time.Minute)
+	stub.err = nil
+
+	// Act
+	err := cb.RunInTransaction(ctx, func(ctx context.Context) error {
+		_, err := cb.FindAll(ctx)
+		return err
+	})
+	_, nextErr := cb.FindAll(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the probe transaction to succeed, got: %v", err)
+	}
+	if nextErr != nil {
+		t.Errorf("Expected the successful probe to close the circuit, got: %v", nextErr)
+	}
+	if stub.calls != 5 {
+		t.Errorf("Expected 5 calls to the wrapped unit of work, got %d", stub.calls)
+	}
+}
+
+// TestCircuitBreaker_CommitWhileOpen validates that a begun transaction can be committed after
+// the circuit has opened
+func TestCircuitBreaker_CommitWhileOpen(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{err: driver.ErrBadConn}
+	cb, _ := newTestCircuitBreaker(stub)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, _ = cb.FindAll(ctx)
+	}
+	stub.inTransaction = true
+
+	// Act
+	err := cb.CommitTransaction(ctx)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected the commit to reach the wrapped unit of work, got: %v", err)
+	}
+	if stub.commits != 1 {
+		t.Errorf("Expected 1 commit, got %d", stub.commits)
+	}
+}
+
+// openTestCircuit fails FindAll until the circuit of cb opens, then lets the cooldown elapse
+func openTestCircuit(t *testing.T, cb *CircuitBreakerUnitOfWork[*testutil.TestEntity], stub *stubUnitOfWork, clock *time.Time) {
+	t.Helper()
+	stub.err = driver.ErrBadConn
+	for i := 0; i < 3; i++ {
+		_, _ = cb.FindAll(context.Background())
+	}
+	*clock = clock.Add(time.Minute)
+}
+
+// TestCircuitBreaker_OtherTransactionDoesNotBypass validates that a transaction open on the
+// shared unit of work does not let unrelated calls past an open circuit
+func TestCircuitBreaker_OtherTransactionDoesNotBypass(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{err: driver.ErrBadConn}
+	cb, _ := newTestCircuitBreaker(stub)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, _ = cb.FindAll(ctx)
+	}
+	stub.inTransaction = true
+
+	// Act
+	_, err := cb.FindAll(ctx)
+
+	// Assert
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got: %v", err)
+	}
+	if stub.calls != 3 {
+		t.Errorf("Expected 3 calls to the wrapped unit of work, got %d", stub.calls)
+	}
+}
+
+// TestCircuitBreaker_CancelledProbe validates that a cancelled probe neither closes nor reopens
+// the circuit, so the next call probes again
+func TestCircuitBreaker_CancelledProbe(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{}
+	cb, clock := newTestCircuitBreaker(stub)
+	openTestCircuit(t, cb, stub, clock)
+	stub.err = context.Canceled
+	ctx := context.Background()
+
+	// Act
+	_, probeErr := cb.FindAll(ctx)
+	stub.err = driver.ErrBadConn
+	_, secondProbeErr := cb.FindAll(ctx)
+	_, nextErr := cb.FindAll(ctx)
+
+	// Assert
+	if !errors.Is(probeErr, context.Canceled) {
+		t.Errorf("Expected the cancelled probe to reach the wrapped unit of work, got: %v", probeErr)
+	}
+	if !errors.Is(secondProbeErr, driver.ErrBadConn) {
+		t.Errorf("Expected a second probe after the cancelled one, got: %v", secondProbeErr)
+	}
+	if !errors.Is(nextErr, ErrCircuitOpen) {
+		t.Errorf("Expected the failed second probe to reopen the circuit, got: %v", nextErr)
+	}
+}
+
+// TestCircuitBreaker_PanickingProbe validates that a panicking probe does not leave the circuit
+// stuck half-open
+func TestCircuitBreaker_PanickingProbe(t *testing.T) {
+	// Arrange
+	stub := &stubUnitOfWork{}
+	cb, clock := newTestCircuitBreaker(stub)
+	openTestCircuit(t, cb, stub, clock)
+	stub.err = nil
+	stub.panics = true
+	ctx := context.Background()
+
+	// Act
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = cb.FindAll(ctx)
+	}()
+	stub.panics = false
+	_, err := cb.FindAll(ctx)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected a new probe after the panic, got: %v", err)
+	}
+	if stub.calls != 5 {
+		t.Errorf("Expected 5 calls to the wrapped unit of work, got %d", stub.calls)
+	}
+}
@@ -0,0 +1,63 @@
+package unit_of_work
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// dateStringLayouts are the formats recognized when coercing date strings, most specific first
+var dateStringLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// WithDateStringParsing makes filters on timestamp columns parse date-like string values
+// (e.g. "2023-01-01" or RFC 3339) into time.Time before they are bound
+func (fa *FilterApplier) WithDateStringParsing(enabled bool) *FilterApplier {
+	fa.parseDateStrings = enabled
+	return fa
+}
+
+// bindValue prepares a filter value for binding against column: date strings are parsed
+// for timestamp columns when enabled, then time values are normalized
+func (fa *FilterApplier) bindValue(query *gorm.DB, column string, value interface{}) interface{} {
+	if fa.parseDateStrings {
+		if s, ok := value.(string); ok && isTimeColumn(query, column) {
+			if parsed, ok := parseDateString(s); ok {
+				value = parsed
+			}
+		}
+	}
+	return fa.normalizeValue(value)
+}
+
+// isTimeColumn reports whether column is a timestamp field of the query's model
+func isTimeColumn(query *gorm.DB, column string) bool {
+	stmt := query.Statement
+	if stmt.Schema == nil {
+		if stmt.Model == nil || stmt.Parse(stmt.Model) != nil {
+			return false
+		}
+	}
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		column = column[i+1:]
+	}
+	field := stmt.Schema.LookUpField(column)
+	return field != nil && field.DataType == schema.Time
+}
+
+// parseDateString parses s with the first matching layout in dateStringLayouts.
+// Values without a zone are read as UTC.
+func parseDateString(s string) (time.Time, bool) {
+	for _, layout := range dateStringLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
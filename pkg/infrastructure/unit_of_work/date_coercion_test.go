@@ -0,0 +1,90 @@
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// TestWithDateStringParsing validates that date strings match timestamp columns only once coerced
+func TestWithDateStringParsing(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []PostgresOption
+		expected []string
+	}{
+		{"Coerced", []PostgresOption{WithDateStringParsing()}, []string{"Mid", "Late"}},
+		{"Bound as strings", nil, []string{"Late"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, tt.opts...)
+			for name, day := range map[string]int{"Early": 1, "Mid": 10, "Late": 20} {
+				entity := &testutil.TestEntity{Name: name}
+				entity.CreatedAt = time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+				if _, err := uow.Insert(ctx, entity); err != nil {
+					t.Fatalf("Failed to insert test entity: %v", err)
+				}
+			}
+			filter := identifier.NewIdentifier().Between("created_at", "2024-01-10T00:00:00Z", "2024-01-20T00:00:00Z")
+			params := query.NewQueryParams[*testutil.TestEntity]().WithFilters(filter).AddSortAsc("created_at").PrepareDefaults()
+
+			// Act
+			results, _, err := uow.FindAllWithPagination(ctx, params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(results) != len(tt.expected) {
+				t.Fatalf("Expected %d results, got %d", len(tt.expected), len(results))
+			}
+			for i, name := range tt.expected {
+				if results[i].Name != name {
+					t.Errorf("Expected %q, got %q", name, results[i].Name)
+				}
+			}
+		})
+	}
+}
+
+// TestFilterApplier_WithDateStringParsing_Columns validates that only timestamp columns are coerced, including inside groups
+func TestFilterApplier_WithDateStringParsing_Columns(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier().WithDateStringParsing(true)
+	filter := identifier.NewIdentifier().
+		GreaterThan("created_at", "2024-01-01").
+		Equal("name", "2024-01-01").
+		AndGroup(identifier.NewIdentifier().LessThan("updatedAt", "2024-02-01 12:00:00").Or(identifier.NewIdentifier().Equal("status", "not a date")))
+
+	// Act
+	stmt := fa.ApplyIdentifier(db.Model(&testutil.TestEntity{}), filter).
+		Session(&gorm.Session{DryRun: true}).Find(&[]testutil.TestEntity{}).Statement
+
+	// Assert
+	expected := []interface{}{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2024-01-01",
+		time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+		"not a date",
+	}
+	if len(stmt.Vars) != len(expected) {
+		t.Fatalf("Expected %d bound values, got %v", len(expected), stmt.Vars)
+	}
+	for i, want := range expected {
+		if stmt.Vars[i] != want {
+			t.Errorf("Expected bound value %d to be %#v, got %#v", i, want, stmt.Vars[i])
+		}
+	}
+}
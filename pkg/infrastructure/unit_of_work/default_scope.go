@@ -0,0 +1,60 @@
+package unit_of_work
+
+import (
+	"reflect"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+
+	"gorm.io/gorm"
+)
+
+// ignoreDefaultScopeKey is the statement setting that opts a query out of the default scope
+const ignoreDefaultScopeKey = "go_database:ignore_default_scope"
+
+// defaultScopeOf returns the default scope declared by model, or nil when its entity type
+// does not implement types.IDefaultScoped. Pointers such as new(T) are dereferenced.
+func defaultScopeOf(model interface{}) []identifier.FilterCriteria {
+	if model == nil {
+		return nil
+	}
+
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if scoped, ok := reflect.New(t).Interface().(types.IDefaultScoped); ok {
+		return scoped.DefaultScope()
+	}
+	return nil
+}
+
+// ApplyDefaultScope restricts the query by the default scope of model, if it declares one.
+// The criteria are applied as a single parenthesized group.
+func (fa *FilterApplier) ApplyDefaultScope(query *gorm.DB, model interface{}) *gorm.DB {
+	scope := defaultScopeOf(model)
+	if len(scope) == 0 {
+		return query
+	}
+	return fa.ApplyFilters(query, []identifier.FilterCriteria{{Group: scope}})
+}
+
+// IgnoreDefaultScope marks the query so the unit of work does not inject the entity's default scope
+func IgnoreDefaultScope(query *gorm.DB) *gorm.DB {
+	return query.Set(ignoreDefaultScopeKey, true)
+}
+
+// defaultScope returns a GORM scope injecting T's default scope at execution time, or nil
+// when T does not declare one. Statements marked with IgnoreDefaultScope are left untouched.
+func (uow *PostgresUnitOfWork[T]) defaultScope() func(*gorm.DB) *gorm.DB {
+	if defaultScopeOf(new(T)) == nil {
+		return nil
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if ignore, ok := db.Get(ignoreDefaultScopeKey); ok && ignore == true {
+			return db
+		}
+		return uow.filterApplier.ApplyDefaultScope(db, new(T))
+	}
+}
@@ -0,0 +1,156 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// scopedEntity only exposes public rows unless the default scope is ignored
+type scopedEntity struct {
+	types.BaseEntity
+	Name       string `gorm:"column:name"`
+	Visibility string `gorm:"column:visibility"`
+}
+
+// TableName returns the table name for GORM
+func (se *scopedEntity) TableName() string {
+	return "scoped_entities"
+}
+
+// DefaultScope restricts queries to public rows
+func (se *scopedEntity) DefaultScope() []identifier.FilterCriteria {
+	return identifier.NewIdentifier().Equal("visibility", "public").ToFilterCriteria()
+}
+
+// setupDefaultScope creates a unit of work for scopedEntity with one public and one private row
+func setupDefaultScope(t *testing.T) (*PostgresUnitOfWork[*scopedEntity], []*scopedEntity) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	if err := db.AutoMigrate(&scopedEntity{}); err != nil {
+		t.Fatalf("Failed to migrate scoped entity: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*scopedEntity](db).(*PostgresUnitOfWork[*scopedEntity])
+
+	entities, err := uow.BulkInsert(context.Background(), []*scopedEntity{
+		{Name: "Public", Visibility: "public"},
+		{Name: "Private", Visibility: "private"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert scoped entities: %v", err)
+	}
+	return uow, entities
+}
+
+// TestDefaultScope_AppliedToQueries validates that the default scope hides rows from every read path
+func TestDefaultScope_AppliedToQueries(t *testing.T) {
+	// Arrange
+	uow, entities := setupDefaultScope(t)
+	ctx := context.Background()
+
+	// Act
+	all, allErr := uow.FindAll(ctx)
+	paged, total, pagedErr := uow.FindAllWithPagination(ctx, query.NewQueryParams[*scopedEntity]())
+	count, countErr := uow.Count(ctx, query.NewQueryParams[*scopedEntity]())
+	_, findErr := uow.FindOneById(ctx, entities[1].GetID())
+	exists, existsErr := uow.Exists(ctx, identifier.NewIdentifier().Equal("name", "Private"))
+
+	// Assert
+	for _, err := range []error{allErr, pagedErr, countErr, existsErr} {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	if len(all) != 1 || all[0].Name != "Public" {
+		t.Errorf("Expected only the public row from FindAll, got %+v", all)
+	}
+	if total != 1 || len(paged) != 1 {
+		t.Errorf("Expected 1 row from pagination, got %d (total %d)", len(paged), total)
+	}
+	if count != 1 {
+		t.Errorf("Expected count 1, got %d", count)
+	}
+	if !errors.Is(findErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected private row to be hidden, got: %v", findErr)
+	}
+	if exists {
+		t.Error("Expected private row not to exist")
+	}
+}
+
+// TestDefaultScope_Override validates that the override flag lifts the default scope
+func TestDefaultScope_Override(t *testing.T) {
+	// Arrange
+	uow, _ := setupDefaultScope(t)
+	ctx := context.Background()
+	params := query.NewQueryParams[*scopedEntity]().WithoutDefaultScope()
+
+	// Act
+	paged, total, pagedErr := uow.FindAllWithPagination(ctx, params)
+	count, countErr := uow.Count(ctx, params)
+
+	// Assert
+	for _, err := range []error{pagedErr, countErr} {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	if total != 2 || len(paged) != 2 {
+		t.Errorf("Expected 2 rows from pagination, got %d (total %d)", len(paged), total)
+	}
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+}
+
+// TestDefaultScope_Mutations validates that writes cannot reach rows outside the default scope
+func TestDefaultScope_Mutations(t *testing.T) {
+	// Arrange
+	uow, entities := setupDefaultScope(t)
+	ctx := context.Background()
+	privateID := identifier.NewIdentifier().Equal("id", entities[1].GetID())
+
+	// Act
+	err := uow.Delete(ctx, privateID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	count, err := uow.Count(ctx, query.NewQueryParams[*scopedEntity]().WithoutDefaultScope())
+	if err != nil {
+		t.Fatalf("Failed to count entities: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected the private row to survive, got %d rows", count)
+	}
+}
+
+// TestFilterApplier_ApplyDefaultScope validates the SQL produced for a default scope
+func TestFilterApplier_ApplyDefaultScope(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+
+	// Act
+	scoped := dryRunSQL(fa.ApplyDefaultScope(db.Model(&scopedEntity{}), &scopedEntity{}))
+	unscoped := dryRunSQL(fa.ApplyDefaultScope(db.Model(&testutil.TestEntity{}), &testutil.TestEntity{}))
+	plain := dryRunSQL(db.Model(&testutil.TestEntity{}))
+
+	// Assert
+	if !strings.Contains(scoped, "visibility = ?") {
+		t.Errorf("Expected SQL to contain the default scope, got: %s", scoped)
+	}
+	if unscoped != plain {
+		t.Errorf("Expected no extra condition for an unscoped entity, got: %s", unscoped)
+	}
+}
@@ -0,0 +1,48 @@
+package unit_of_work
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldIndexCache maps a struct reflect.Type to its exported field indices keyed by field name.
+// Field metadata is computed once per type so hot paths avoid repeated FieldByName scans.
+var fieldIndexCache sync.Map // map[reflect.Type]map[string][]int
+
+// cachedFieldIndices returns the exported field indices of a struct type, including promoted fields
+func cachedFieldIndices(t reflect.Type) map[string][]int {
+	if cached, ok := fieldIndexCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	indices := make(map[string][]int)
+	if t.Kind() == reflect.Struct {
+		for _, field := range reflect.VisibleFields(t) {
+			if field.IsExported() {
+				indices[field.Name] = field.Index
+			}
+		}
+	}
+
+	actual, _ := fieldIndexCache.LoadOrStore(t, indices)
+	return actual.(map[string][]int)
+}
+
+// lookupField returns the named field of a struct value using the cached indices.
+// It returns the zero reflect.Value when the field does not exist or is unreachable.
+func lookupField(val reflect.Value, name string) reflect.Value {
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	index, ok := cachedFieldIndices(val.Type())[name]
+	if !ok {
+		return reflect.Value{}
+	}
+
+	field, err := val.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return field
+}
@@ -0,0 +1,114 @@
+package unit_of_work
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestLookupField validates cached field lookups on structs and promoted fields
+func TestLookupField(t *testing.T) {
+	// Arrange
+	params := query.NewQueryParams[*testutil.TestEntity]().WithSearch("john")
+	entity := testutil.TestEntity{Name: "John"}
+	entity.ID = 7
+
+	tests := []struct {
+		name     string
+		val      reflect.Value
+		field    string
+		expected interface{}
+	}{
+		{"Direct field", reflect.ValueOf(*params), "Search", "john"},
+		{"Promoted field", reflect.ValueOf(entity), "ID", 7},
+		{"Missing field", reflect.ValueOf(entity), "Missing", nil},
+		{"Non-struct value", reflect.ValueOf(42), "Search", nil},
+		{"Invalid value", reflect.Value{}, "Search", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			field := lookupField(tt.val, tt.field)
+
+			// Assert
+			if tt.expected == nil {
+				if field.IsValid() {
+					t.Errorf("Expected invalid field, got %v", field)
+				}
+				return
+			}
+			if !field.IsValid() {
+				t.Fatal("Expected valid field")
+			}
+			if field.Interface() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, field.Interface())
+			}
+		})
+	}
+}
+
+// TestCachedFieldIndices_ReusesEntry validates that metadata is computed once per type
+func TestCachedFieldIndices_ReusesEntry(t *testing.T) {
+	// Arrange
+	typ := reflect.TypeOf(testutil.TestEntity{})
+
+	// Act
+	first := cachedFieldIndices(typ)
+	second := cachedFieldIndices(typ)
+
+	// Assert
+	if reflect.ValueOf(first).Pointer() != reflect.ValueOf(second).Pointer() {
+		t.Error("Expected the cached metadata to be reused")
+	}
+}
+
+// BenchmarkLookupField_Cached measures field access through the type-keyed cache
+func BenchmarkLookupField_Cached(b *testing.B) {
+	val := reflect.ValueOf(*query.NewQueryParams[*testutil.TestEntity]())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, name := range []string{"Filters", "Search", "OnlyDeleted", "IncludeDeleted", "Sort", "Preloads"} {
+			_ = lookupField(val, name)
+		}
+	}
+}
+
+// BenchmarkLookupField_FieldByName measures uncached reflection for comparison
+func BenchmarkLookupField_FieldByName(b *testing.B) {
+	val := reflect.ValueOf(*query.NewQueryParams[*testutil.TestEntity]())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, name := range []string{"Filters", "Search", "OnlyDeleted", "IncludeDeleted", "Sort", "Preloads"} {
+			_ = val.FieldByName(name)
+		}
+	}
+}
+
+// BenchmarkFilterApplier_ApplyQueryParams measures building a query from QueryParams
+func BenchmarkFilterApplier_ApplyQueryParams(b *testing.B) {
+	db := testutil.SetupTestDB(b)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]().WithSearch("john").AddSortDesc("created_at")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+	}
+}
+
+// BenchmarkFilterApplier_ApplyQueryParams_ColdCache measures building a query from QueryParams
+// with the field cache emptied before every call, as a baseline for the cached benchmark
+func BenchmarkFilterApplier_ApplyQueryParams_ColdCache(b *testing.B) {
+	db := testutil.SetupTestDB(b)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]().WithSearch("john").AddSortDesc("created_at")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fieldIndexCache.Clear()
+		_ = fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+	}
+}
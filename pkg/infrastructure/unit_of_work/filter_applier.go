@@ -3,7 +3,12 @@ package unit_of_work
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	queryparams "github.com/ai-shiraz-teams/go-database/internal/shared/query"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
@@ -11,21 +16,112 @@ import (
 	"gorm.io/gorm"
 )
 
+// fieldNamePattern matches plain or table-qualified column names such as "name" or "users.name"
+var fieldNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// ValidateFieldName checks that a field name is a plain column reference safe to embed in SQL
+func ValidateFieldName(field string) error {
+	if !fieldNamePattern.MatchString(field) {
+		return domainerrors.NewValidationError(field, "invalid field name")
+	}
+	return nil
+}
+
 // FilterApplier provides utilities to convert IIdentifier filters to GORM queries.
 // This maintains separation between domain logic and ORM implementation.
-type FilterApplier struct{}
+type FilterApplier struct {
+	namingStrategy NamingStrategy // Maps filter and sort fields to column names
+	errorOnEmptyIn bool           // Reject empty In/NotIn value lists instead of matching none/all
+	utcTimes       bool           // Convert time.Time filter values to UTC before binding
+
+	parseDateStrings bool // Parse date strings bound against timestamp columns into time.Time
+
+	softDeleteStrategy SoftDeleteStrategy  // How soft-deleted rows are recognized
+	filterTransformers []FilterTransformer // Rewrite the filters of each call before they are applied
+	sortExpressions    map[string]string   // SQL expressions ordered by in place of logical sort fields
+
+	defaultSort []queryparams.SortField // Ordering used when params specify none (id ASC when empty)
+
+	numericSearchFields []string // Columns matched exactly by integer search terms (id when nil)
+}
 
-// NewFilterApplier creates a new FilterApplier instance
+// NewFilterApplier creates a new FilterApplier instance using snake_case column naming
 func NewFilterApplier() *FilterApplier {
-	return &FilterApplier{}
+	return &FilterApplier{
+		namingStrategy: SnakeCaseNamingStrategy,
+	}
+}
+
+// WithNamingStrategy sets the strategy used to map field names to column names
+func (fa *FilterApplier) WithNamingStrategy(strategy NamingStrategy) *FilterApplier {
+	fa.namingStrategy = strategy
+	return fa
+}
+
+// WithErrorOnEmptyIn makes In and NotIn filters with an empty values list fail with a
+// validation error instead of rendering "1 = 0" (no rows) and "1 = 1" (all rows)
+func (fa *FilterApplier) WithErrorOnEmptyIn(enabled bool) *FilterApplier {
+	fa.errorOnEmptyIn = enabled
+	return fa
+}
+
+// WithUTCTimes converts time.Time filter values to UTC before they are bound
+func (fa *FilterApplier) WithUTCTimes(enabled bool) *FilterApplier {
+	fa.utcTimes = enabled
+	return fa
+}
+
+// columnName resolves a field to its column name. Only plain or table-qualified names
+// are mapped; expressions are passed through untouched.
+func (fa *FilterApplier) columnName(field string) string {
+	if fa.namingStrategy == nil || !fieldNamePattern.MatchString(field) {
+		return field
+	}
+	return fa.namingStrategy(field)
 }
 
-// ApplyFilters converts FilterCriteria from IIdentifier to GORM query conditions
+// ApplyFilters converts FilterCriteria from IIdentifier to GORM query conditions.
+// Criteria joined by OR are rendered as one parenthesized condition, so conditions added
+// to the query before or after them, such as soft-delete visibility or search, apply to
+// every alternative instead of binding only to the last one.
 func (fa *FilterApplier) ApplyFilters(query *gorm.DB, filters []identifier.FilterCriteria) *gorm.DB {
 	if len(filters) == 0 {
 		return query
 	}
 
+	if hasOrCriteria(filters) {
+		group := fa.applyFilterChain(fa.groupSession(query), filters)
+		if group.Error != nil {
+			_ = query.AddError(group.Error)
+		}
+		return query.Where(group)
+	}
+	return fa.applyFilterChain(query, filters)
+}
+
+// hasOrCriteria reports whether any criterion is joined to the next one with OR
+func hasOrCriteria(filters []identifier.FilterCriteria) bool {
+	for _, filter := range filters[:len(filters)-1] {
+		if filter.LogicalOp == identifier.LogicalOperatorOr {
+			return true
+		}
+	}
+	return false
+}
+
+// groupSession returns an empty query for building a parenthesized group of conditions
+func (fa *FilterApplier) groupSession(query *gorm.DB) *gorm.DB {
+	groupQuery := query.Session(&gorm.Session{NewDB: true})
+	if fa.parseDateStrings {
+		// Keep the model so nested filters can resolve column types
+		groupQuery = groupQuery.Model(query.Statement.Model)
+	}
+	return groupQuery
+}
+
+// applyFilterChain applies the criteria in order, joining each to the previous one with
+// the logical operator stored on the previous criterion
+func (fa *FilterApplier) applyFilterChain(query *gorm.DB, filters []identifier.FilterCriteria) *gorm.DB {
 	for i, filter := range filters {
 		// For the first filter, always use WHERE
 		// For subsequent filters, check the logical operator of the PREVIOUS filter
@@ -44,6 +140,13 @@ func (fa *FilterApplier) ApplyFilters(query *gorm.DB, filters []identifier.Filte
 	return query
 }
 
+// ApplyFiltersE behaves like ApplyFilters but also returns any error recorded while
+// translating the filters, such as an invalid field name or a rejected empty IN list
+func (fa *FilterApplier) ApplyFiltersE(query *gorm.DB, filters []identifier.FilterCriteria) (*gorm.DB, error) {
+	query = fa.ApplyFilters(query, filters)
+	return query, query.Error
+}
+
 // applyFilter applies a single FilterCriteria to the GORM query
 func (fa *FilterApplier) applyFilter(query *gorm.DB, filter identifier.FilterCriteria, isFirst bool, useOr bool) *gorm.DB {
 	// Handle grouped filters (nested conditions)
@@ -57,7 +160,10 @@ func (fa *FilterApplier) applyFilter(query *gorm.DB, filter identifier.FilterCri
 
 // applyGroupFilter handles nested filter groups with AND/OR logic
 func (fa *FilterApplier) applyGroupFilter(query *gorm.DB, filter identifier.FilterCriteria, isFirst bool, useOr bool) *gorm.DB {
-	groupQuery := fa.ApplyFilters(query.Session(&gorm.Session{NewDB: true}), filter.Group)
+	groupQuery := fa.applyFilterChain(fa.groupSession(query), filter.Group)
+	if groupQuery.Error != nil {
+		_ = query.AddError(groupQuery.Error)
+	}
 
 	if isFirst {
 		return query.Where(groupQuery)
@@ -70,10 +176,16 @@ func (fa *FilterApplier) applyGroupFilter(query *gorm.DB, filter identifier.Filt
 
 // applySingleFilter applies individual filter conditions based on operator
 func (fa *FilterApplier) applySingleFilter(query *gorm.DB, filter identifier.FilterCriteria, isFirst bool, useOr bool) *gorm.DB {
-	field := filter.Field
+	field := fa.columnName(filter.Field)
 	operator := filter.Operator
-	value := filter.Value
+	value := fa.bindValue(query, field, filter.Value)
 	values := filter.Values
+	if (fa.utcTimes || fa.parseDateStrings) && len(values) > 0 {
+		values = make([]interface{}, len(filter.Values))
+		for i, v := range filter.Values {
+			values[i] = fa.bindValue(query, field, v)
+		}
+	}
 
 	var condition string
 	var args []interface{}
@@ -107,7 +219,24 @@ func (fa *FilterApplier) applySingleFilter(query *gorm.DB, filter identifier.Fil
 		condition = fmt.Sprintf("%s LIKE ?", field)
 		args = []interface{}{value}
 
+	case identifier.FilterOperatorRegex, identifier.FilterOperatorRegexInsensitive:
+		// Regex patterns are passed through unescaped, so the field must be a plain column
+		if err := ValidateFieldName(field); err != nil {
+			_ = query.AddError(err)
+			return query
+		}
+		regexOperator := "~"
+		if operator == identifier.FilterOperatorRegexInsensitive {
+			regexOperator = "~*"
+		}
+		condition = fmt.Sprintf("%s %s ?", field, regexOperator)
+		args = []interface{}{value}
+
 	case identifier.FilterOperatorIn:
+		if len(values) == 0 && fa.errorOnEmptyIn {
+			_ = query.AddError(fa.emptyInError(filter))
+			return query
+		}
 		if len(values) > 0 {
 			condition = fmt.Sprintf("%s IN ?", field)
 			args = []interface{}{values}
@@ -117,6 +246,10 @@ func (fa *FilterApplier) applySingleFilter(query *gorm.DB, filter identifier.Fil
 		}
 
 	case identifier.FilterOperatorNotIn:
+		if len(values) == 0 && fa.errorOnEmptyIn {
+			_ = query.AddError(fa.emptyInError(filter))
+			return query
+		}
 		if len(values) > 0 {
 			condition = fmt.Sprintf("%s NOT IN ?", field)
 			args = []interface{}{values}
@@ -125,18 +258,50 @@ func (fa *FilterApplier) applySingleFilter(query *gorm.DB, filter identifier.Fil
 			condition = "1 = 1"
 		}
 
+	case identifier.FilterOperatorInSubquery, identifier.FilterOperatorNotInSubquery:
+		subQuery, err := fa.buildSubquery(query, filter.Subquery, operator == identifier.FilterOperatorNotInSubquery)
+		if err != nil {
+			_ = query.AddError(err)
+			return query
+		}
+		condition = fmt.Sprintf("%s IN (?)", field)
+		if operator == identifier.FilterOperatorNotInSubquery {
+			condition = fmt.Sprintf("%s NOT IN (?)", field)
+		}
+		args = []interface{}{subQuery}
+
 	case identifier.FilterOperatorIsNull:
 		condition = fmt.Sprintf("%s IS NULL", field)
 
 	case identifier.FilterOperatorIsNotNull:
 		condition = fmt.Sprintf("%s IS NOT NULL", field)
 
+	case identifier.FilterOperatorIsDistinctFrom:
+		condition = fmt.Sprintf("%s IS DISTINCT FROM ?", field)
+		args = []interface{}{value}
+
+	case identifier.FilterOperatorIsNotDistinctFrom:
+		condition = fmt.Sprintf("%s IS NOT DISTINCT FROM ?", field)
+		args = []interface{}{value}
+
 	case identifier.FilterOperatorBetween:
 		if len(values) >= 2 {
 			condition = fmt.Sprintf("%s BETWEEN ? AND ?", field)
 			args = []interface{}{values[0], values[1]}
 		}
 
+	case identifier.FilterOperatorValueInColumnRange:
+		// Both columns are interpolated, so they must be plain column names
+		endField := fa.columnName(filter.EndField)
+		for _, column := range []string{field, endField} {
+			if err := ValidateFieldName(column); err != nil {
+				_ = query.AddError(err)
+				return query
+			}
+		}
+		condition = fmt.Sprintf("? BETWEEN %s AND %s", field, endField)
+		args = []interface{}{value}
+
 	case identifier.FilterOperatorContains:
 		// For JSON fields - PostgreSQL specific
 		condition = fmt.Sprintf("%s @> ?", field)
@@ -162,82 +327,230 @@ func (fa *FilterApplier) applySingleFilter(query *gorm.DB, filter identifier.Fil
 	}
 }
 
+// ApplyHaving restricts a grouped query to the groups whose aggregate expression satisfies
+// having, as in "HAVING COUNT(*) > ?". A nil condition leaves the query unchanged; an
+// operator other than a comparison fails the query with a validation error.
+func (fa *FilterApplier) ApplyHaving(query *gorm.DB, expression string, having *HavingCondition) *gorm.DB {
+	if having == nil {
+		return query
+	}
+	operator, ok := havingOperators[having.Operator]
+	if !ok {
+		_ = query.AddError(domainerrors.NewValidationError("having", fmt.Sprintf("unsupported HAVING operator %q", having.Operator)))
+		return query
+	}
+	return query.Having(fmt.Sprintf("%s %s ?", expression, operator), having.Value)
+}
+
+// normalizeValue converts time values to UTC when configured; other values are returned as is
+func (fa *FilterApplier) normalizeValue(value interface{}) interface{} {
+	if !fa.utcTimes {
+		return value
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC()
+	case *time.Time:
+		if v != nil {
+			utc := v.UTC()
+			return &utc
+		}
+	}
+	return value
+}
+
+// emptyInError describes an In/NotIn filter rejected for having no values
+func (fa *FilterApplier) emptyInError(filter identifier.FilterCriteria) error {
+	return domainerrors.NewValidationError(filter.Field, fmt.Sprintf("%s filter requires at least one value", filter.Operator))
+}
+
 // ApplyQueryParams converts QueryParams to GORM query with filters, sorting, and soft-delete handling
 func (fa *FilterApplier) ApplyQueryParams(query *gorm.DB, params interface{}) *gorm.DB {
 	if params == nil {
-		return query
+		return fa.ApplyQueryConditions(query, nil)
 	}
 
-	// Use reflection to access QueryParams fields since we can't use generics in methods
-	// This is a safe workaround for the generic method limitation
-	val := reflect.ValueOf(params)
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
+	query = fa.ApplyQueryConditions(query, params)
+	val := queryParamsValue(params)
+
+	// Extract sorting
+	if sortField := lookupField(val, "Sort"); sortField.IsValid() {
+		sorts, _ := sortField.Interface().([]queryparams.SortField)
+		query = fa.applySortsOrDefault(query, sorts)
+	}
+
+	// Extract preloads
+	if preloadsField := lookupField(val, "Preloads"); preloadsField.IsValid() {
+		if preloads, ok := preloadsField.Interface().([]string); ok {
+			for _, preload := range preloads {
+				query = query.Preload(preload)
+			}
+		}
+	}
+	if preloadSpecsField := lookupField(val, "PreloadSpecs"); preloadSpecsField.IsValid() {
+		if specs, ok := preloadSpecsField.Interface().([]queryparams.PreloadSpec); ok {
+			for _, spec := range specs {
+				query = query.Preload(spec.Relation, spec.Args...)
+			}
+		}
+	}
+
+	return query
+}
+
+// ApplyQueryConditions applies only the row-selecting parts of QueryParams: filters, relation
+// filters, search, soft-delete visibility and the default scope override. Sorting, preloads
+// and pagination are left out, which suits aggregate and bulk statements.
+func (fa *FilterApplier) ApplyQueryConditions(query *gorm.DB, params interface{}) *gorm.DB {
+	if params == nil {
+		return fa.applyCallFilters(query, nil)
 	}
+	if builder, ok := params.(interface{ Err() error }); ok && builder.Err() != nil {
+		_ = query.AddError(builder.Err())
+	}
+	val := queryParamsValue(params)
 
 	// Extract filters
-	if filtersField := val.FieldByName("Filters"); filtersField.IsValid() {
-		if filters, ok := filtersField.Interface().([]identifier.FilterCriteria); ok && len(filters) > 0 {
-			query = fa.ApplyFilters(query, filters)
+	var filters []identifier.FilterCriteria
+	if filtersField := lookupField(val, "Filters"); filtersField.IsValid() {
+		filters, _ = filtersField.Interface().([]identifier.FilterCriteria)
+	}
+	query = fa.applyCallFilters(query, filters)
+
+	// Extract relation existence filters
+	if relationFiltersField := lookupField(val, "RelationFilters"); relationFiltersField.IsValid() {
+		if relationFilters, ok := relationFiltersField.Interface().([]queryparams.RelationFilter); ok && len(relationFilters) > 0 {
+			query = fa.ApplyRelationFilters(query, relationFilters)
+		}
+	}
+
+	// Extract raw conditions
+	if rawField := lookupField(val, "RawConditions"); rawField.IsValid() {
+		if conditions, ok := rawField.Interface().([]queryparams.RawCondition); ok {
+			query = fa.applyRawConditions(query, conditions)
 		}
 	}
 
 	// Extract search
-	if searchField := val.FieldByName("Search"); searchField.IsValid() {
+	if searchField := lookupField(val, "Search"); searchField.IsValid() {
 		if search, ok := searchField.Interface().(string); ok && search != "" {
-			// Basic search implementation - should be overridden in specific repositories
-			query = query.Where("CAST(id AS TEXT) LIKE ?", "%"+search+"%")
+			var fields []string
+			if searchFieldsField := lookupField(val, "SearchFields"); searchFieldsField.IsValid() {
+				fields, _ = searchFieldsField.Interface().([]string)
+			}
+			query = fa.applySearch(query, search, fields)
 		}
 	}
 
 	// Extract soft-delete visibility
 	var onlyDeleted, includeDeleted bool
-	if onlyDeletedField := val.FieldByName("OnlyDeleted"); onlyDeletedField.IsValid() {
+	if onlyDeletedField := lookupField(val, "OnlyDeleted"); onlyDeletedField.IsValid() {
 		onlyDeleted, _ = onlyDeletedField.Interface().(bool)
 	}
-	if includeDeletedField := val.FieldByName("IncludeDeleted"); includeDeletedField.IsValid() {
+	if includeDeletedField := lookupField(val, "IncludeDeleted"); includeDeletedField.IsValid() {
 		includeDeleted, _ = includeDeletedField.Interface().(bool)
 	}
 
-	if onlyDeleted {
-		query = query.Unscoped().Where("deleted_at IS NOT NULL")
-	} else if !includeDeleted {
-		query = query.Where("deleted_at IS NULL")
-	} else {
-		query = query.Unscoped()
+	query = fa.ApplyDeletedVisibility(query, includeDeleted, onlyDeleted)
+
+	// Extract default scope override
+	if ignoreField := lookupField(val, "IgnoreDefaultScope"); ignoreField.IsValid() {
+		if ignore, _ := ignoreField.Interface().(bool); ignore {
+			query = IgnoreDefaultScope(query)
+		}
 	}
 
-	// Extract sorting
-	if sortField := val.FieldByName("Sort"); sortField.IsValid() {
-		if sorts, ok := sortField.Interface().([]queryparams.SortField); ok && len(sorts) > 0 {
-			for _, sort := range sorts {
-				query = query.Order(fmt.Sprintf("%s %s", sort.Field, sort.Order))
-			}
-		} else {
-			query = query.Order("id ASC")
+	return query
+}
+
+// applyRawConditions ANDs each raw SQL condition, with its bound arguments, onto the query
+func (fa *FilterApplier) applyRawConditions(query *gorm.DB, conditions []queryparams.RawCondition) *gorm.DB {
+	for _, condition := range conditions {
+		query = query.Where(condition.SQL, condition.Args...)
+	}
+	return query
+}
+
+// applySearch matches the search term case-insensitively as a substring of any of fields.
+// An integer term also matches the numeric search fields exactly (id by default), so
+// searching "42" finds entity 42 as well as names containing 42. Fields are cast to text,
+// so integer or uuid columns can be searched too. Without fields it falls back to matching
+// the ID, the historical default.
+func (fa *FilterApplier) applySearch(query *gorm.DB, search string, fields []string) *gorm.DB {
+	if len(fields) == 0 {
+		return query.Where("CAST(id AS TEXT) LIKE ?", "%"+search+"%")
+	}
+
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(search)) + "%"
+	conditions := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		column := fa.columnName(field)
+		if err := ValidateFieldName(column); err != nil {
+			_ = query.AddError(err)
+			return query
 		}
+		conditions = append(conditions, fmt.Sprintf("LOWER(CAST(%s AS TEXT)) LIKE ? ESCAPE '\\'", column))
+		args = append(args, pattern)
 	}
 
-	// Extract preloads
-	if preloadsField := val.FieldByName("Preloads"); preloadsField.IsValid() {
-		if preloads, ok := preloadsField.Interface().([]string); ok {
-			for _, preload := range preloads {
-				query = query.Preload(preload)
+	if number, err := strconv.ParseInt(strings.TrimSpace(search), 10, 64); err == nil {
+		numericFields := fa.numericSearchFields
+		if numericFields == nil {
+			numericFields = []string{"id"}
+		}
+		for _, field := range numericFields {
+			column := fa.columnName(field)
+			if err := ValidateFieldName(column); err != nil {
+				_ = query.AddError(err)
+				return query
 			}
+			conditions = append(conditions, fmt.Sprintf("%s = ?", column))
+			args = append(args, number)
 		}
 	}
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
 
-	return query
+// WithNumericSearchFields sets the exact-match search columns; see the WithNumericSearchFields option
+func (fa *FilterApplier) WithNumericSearchFields(fields ...string) *FilterApplier {
+	fa.numericSearchFields = append([]string{}, fields...)
+	return fa
 }
 
-// ApplyIdentifier converts IIdentifier to GORM query conditions
-func (fa *FilterApplier) ApplyIdentifier(query *gorm.DB, identifier identifier.IIdentifier) *gorm.DB {
-	if identifier == nil {
-		return query
+// likeEscaper escapes LIKE wildcards so search terms match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// queryParamsValue dereferences params for field lookup. Reflection is used to access
+// QueryParams fields since we can't use generics in methods.
+func queryParamsValue(params interface{}) reflect.Value {
+	val := reflect.ValueOf(params)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
 	}
+	return val
+}
+
+// ApplyDeletedVisibility scopes the query to live rows (default), all rows, or only soft-deleted rows.
+// onlyDeleted takes precedence when both flags are set.
+// Trashed rows are selected with every column, so their deleted_at is loaded and callers can tell
+// them apart from live rows in the same result set.
+func (fa *FilterApplier) ApplyDeletedVisibility(query *gorm.DB, includeDeleted, onlyDeleted bool) *gorm.DB {
+	if onlyDeleted {
+		return query.Unscoped().Where(fa.deletedCondition("", true))
+	} else if !includeDeleted {
+		return query.Where(fa.deletedCondition("", false))
+	}
+	return query.Unscoped()
+}
 
-	filters := identifier.ToFilterCriteria()
-	return fa.ApplyFilters(query, filters)
+// ApplyIdentifier converts IIdentifier to GORM query conditions
+func (fa *FilterApplier) ApplyIdentifier(query *gorm.DB, ident identifier.IIdentifier) *gorm.DB {
+	var filters []identifier.FilterCriteria
+	if ident != nil {
+		filters = ident.ToFilterCriteria()
+	}
+	return fa.applyCallFilters(query, filters)
 }
 
 // BuildQueryFromIdentifier creates a complete query from an IIdentifier
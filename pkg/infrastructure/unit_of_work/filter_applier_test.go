@@ -1,12 +1,26 @@
 package unit_of_work
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
 	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
 )
 
+// dryRunSQL builds the SELECT statement for a query without executing it
+func dryRunSQL(query *gorm.DB) string {
+	var entities []testutil.TestEntity
+	return query.Session(&gorm.Session{DryRun: true}).Find(&entities).Statement.SQL.String()
+}
+
 // TestNewFilterApplier validates FilterApplier creation
 func TestNewFilterApplier(t *testing.T) {
 	// Arrange & Act
@@ -238,6 +252,60 @@ func TestFilterApplier_ApplyFilters_NotInOperator(t *testing.T) {
 	}
 }
 
+// TestFilterApplier_ApplyFiltersE_EmptyIn validates the selectable empty IN/NOT IN behavior
+func TestFilterApplier_ApplyFiltersE_EmptyIn(t *testing.T) {
+	tests := []struct {
+		name           string
+		operator       identifier.FilterOperator
+		errorOnEmptyIn bool
+		expectError    bool
+		expectedCount  int
+	}{
+		{"Empty IN matches nothing by default", identifier.FilterOperatorIn, false, false, 0},
+		{"Empty NOT IN matches everything by default", identifier.FilterOperatorNotIn, false, false, 2},
+		{"Empty IN rejected when configured", identifier.FilterOperatorIn, true, true, 0},
+		{"Empty NOT IN rejected when configured", identifier.FilterOperatorNotIn, true, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			seed := []*testutil.TestEntity{{Name: "Entity 1"}, {Name: "Entity 2"}}
+			if err := db.Create(&seed).Error; err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			fa := NewFilterApplier().WithErrorOnEmptyIn(tt.errorOnEmptyIn)
+			filter := identifier.FilterCriteria{Field: "id", Operator: tt.operator, Values: []interface{}{}}
+
+			// Act
+			filtered, err := fa.ApplyFiltersE(db.Model(&testutil.TestEntity{}), []identifier.FilterCriteria{filter})
+
+			// Assert
+			if tt.expectError {
+				var validationErr *domainerrors.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("Expected validation error, got: %v", err)
+				}
+				if validationErr.Field != "id" {
+					t.Errorf("Expected error on field 'id', got %q", validationErr.Field)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			var count int64
+			if err := filtered.Count(&count).Error; err != nil {
+				t.Fatalf("Failed to count: %v", err)
+			}
+			if count != int64(tt.expectedCount) {
+				t.Errorf("Expected %d rows, got %d", tt.expectedCount, count)
+			}
+		})
+	}
+}
+
 // TestFilterApplier_ApplyFilters_BetweenOperator validates BETWEEN operator handling
 func TestFilterApplier_ApplyFilters_BetweenOperator(t *testing.T) {
 	tests := []struct {
@@ -632,3 +700,650 @@ func TestFilterApplier_ApplyQueryParams_VariousTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestFilterApplier_ApplyFilters_RegexOperators validates regex predicate generation
+func TestFilterApplier_ApplyFilters_RegexOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected string
+	}{
+		{
+			name:     "Case-sensitive regex",
+			ident:    identifier.NewIdentifier().Regex("name", "^J.*n$"),
+			expected: "name ~ ?",
+		},
+		{
+			name:     "Case-insensitive regex",
+			ident:    identifier.NewIdentifier().RegexInsensitive("name", "^j.*N$"),
+			expected: "name ~* ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier()
+			query := db.Model(&testutil.TestEntity{})
+
+			// Act
+			result := fa.ApplyIdentifier(query, tt.ident)
+
+			// Assert
+			if result.Error != nil {
+				t.Fatalf("Expected no error, got: %v", result.Error)
+			}
+			sql := dryRunSQL(result)
+			if !strings.Contains(sql, tt.expected) {
+				t.Errorf("Expected SQL to contain %q, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyFilters_RegexInvalidField validates that unsafe field names are rejected
+func TestFilterApplier_ApplyFilters_RegexInvalidField(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	query := db.Model(&testutil.TestEntity{})
+	ident := identifier.NewIdentifier().Regex("name; DROP TABLE test_entities", ".*")
+
+	// Act
+	result := fa.ApplyIdentifier(query, ident)
+
+	// Assert
+	if result.Error == nil {
+		t.Fatal("Expected invalid field name to be rejected")
+	}
+}
+
+// TestFilterApplier_ApplyFilters_ValueInColumnRange validates that the bound value is compared against both columns
+func TestFilterApplier_ApplyFilters_ValueInColumnRange(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	query := db.Model(&testutil.TestEntity{})
+	ident := identifier.NewIdentifier().ValueInColumnRange(30, "age", "score")
+
+	// Act
+	result := fa.ApplyIdentifier(query, ident)
+
+	// Assert
+	if result.Error != nil {
+		t.Fatalf("Expected no error, got: %v", result.Error)
+	}
+	var entities []testutil.TestEntity
+	statement := result.Session(&gorm.Session{DryRun: true}).Find(&entities).Statement
+	if sql := statement.SQL.String(); !strings.Contains(sql, "(? BETWEEN age AND score)") {
+		t.Errorf("Expected SQL to contain %q, got: %s", "(? BETWEEN age AND score)", sql)
+	}
+	if len(statement.Vars) == 0 || statement.Vars[0] != 30 {
+		t.Errorf("Expected the value 30 to be bound first, got %v", statement.Vars)
+	}
+}
+
+// TestFilterApplier_ApplyFilters_ValueInColumnRangeInvalidField validates that both columns are checked
+func TestFilterApplier_ApplyFilters_ValueInColumnRangeInvalidField(t *testing.T) {
+	tests := []struct {
+		name     string
+		startCol string
+		endCol   string
+	}{
+		{"Invalid start column", "age; DROP TABLE test_entities", "score"},
+		{"Invalid end column", "age", "score) OR (1=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier()
+			query := db.Model(&testutil.TestEntity{})
+
+			// Act
+			result := fa.ApplyIdentifier(query, identifier.NewIdentifier().ValueInColumnRange(30, tt.startCol, tt.endCol))
+
+			// Assert
+			if result.Error == nil {
+				t.Fatal("Expected invalid field name to be rejected")
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyFilters_NullSafeOperators validates NULL-safe comparisons against NULL columns
+func TestFilterApplier_ApplyFilters_NullSafeOperators(t *testing.T) {
+	tests := []struct {
+		name          string
+		ident         identifier.IIdentifier
+		expectedNames []string
+	}{
+		{
+			name:          "Equal nil matches nothing",
+			ident:         identifier.NewIdentifier().Equal("description", nil),
+			expectedNames: []string{},
+		},
+		{
+			name:          "Not distinct from nil matches NULL rows",
+			ident:         identifier.NewIdentifier().IsNotDistinctFrom("description", nil),
+			expectedNames: []string{"No Description"},
+		},
+		{
+			name:          "Distinct from value includes NULL rows",
+			ident:         identifier.NewIdentifier().IsDistinctFrom("description", "Described"),
+			expectedNames: []string{"No Description"},
+		},
+		{
+			name:          "Distinct from nil matches non-NULL rows",
+			ident:         identifier.NewIdentifier().IsDistinctFrom("description", nil),
+			expectedNames: []string{"With Description"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			entities := []*testutil.TestEntity{
+				{Name: "With Description", Description: "Described", Status: "active"},
+				{Name: "No Description", Status: "active"},
+			}
+			if err := db.Create(&entities).Error; err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			if err := db.Model(entities[1]).UpdateColumn("description", gorm.Expr("NULL")).Error; err != nil {
+				t.Fatalf("Failed to clear description: %v", err)
+			}
+			fa := NewFilterApplier()
+
+			// Act
+			var results []testutil.TestEntity
+			err := fa.ApplyIdentifier(db.Model(&testutil.TestEntity{}), tt.ident).Find(&results).Error
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(results) != len(tt.expectedNames) {
+				t.Fatalf("Expected %d results, got %d", len(tt.expectedNames), len(results))
+			}
+			for i, name := range tt.expectedNames {
+				if results[i].Name != name {
+					t.Errorf("Expected %q, got %q", name, results[i].Name)
+				}
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyFilters_UTCTimes validates that time filter values are bound in UTC
+func TestFilterApplier_ApplyFilters_UTCTimes(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	zone := time.FixedZone("UTC-7", -7*60*60)
+	moment := time.Date(2024, 3, 1, 9, 0, 0, 0, zone)
+	fa := NewFilterApplier().WithUTCTimes(true)
+	ident := identifier.NewIdentifier().
+		GreaterThan("created_at", moment).
+		Between("updated_at", moment, moment.Add(time.Hour))
+
+	// Act
+	var entities []testutil.TestEntity
+	stmt := fa.ApplyIdentifier(db.Model(&testutil.TestEntity{}), ident).
+		Session(&gorm.Session{DryRun: true}).Find(&entities).Statement
+
+	// Assert
+	if len(stmt.Vars) != 3 {
+		t.Fatalf("Expected 3 bound values, got %d", len(stmt.Vars))
+	}
+	for i, v := range stmt.Vars {
+		bound, ok := v.(time.Time)
+		if !ok {
+			t.Fatalf("Expected time value at %d, got %T", i, v)
+		}
+		if bound.Location() != time.UTC {
+			t.Errorf("Expected value %d in UTC, got %v", i, bound.Location())
+		}
+	}
+	if !stmt.Vars[0].(time.Time).Equal(moment) {
+		t.Errorf("Expected the same instant, got %v", stmt.Vars[0])
+	}
+}
+
+// TestFilterApplier_ApplyIdentifier_Groups validates that grouped identifiers keep their precedence
+func TestFilterApplier_ApplyIdentifier_Groups(t *testing.T) {
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected string
+	}{
+		{
+			name: "AndGroup parenthesizes OR",
+			ident: identifier.NewIdentifier().Equal("status", "active").
+				AndGroup(identifier.NewIdentifier().Equal("name", "A").Or(identifier.NewIdentifier().Equal("name", "B"))),
+			expected: "WHERE status = ? AND (name = ? OR name = ?)",
+		},
+		{
+			name: "OrGroup parenthesizes AND",
+			ident: identifier.NewIdentifier().Equal("status", "active").
+				OrGroup(identifier.NewIdentifier().Equal("name", "A").Equal("age", 30)),
+			expected: "WHERE (status = ? OR (name = ? AND age = ?))",
+		},
+		{
+			name: "InOrNull groups IN and IS NULL",
+			ident: identifier.NewIdentifier().Equal("name", "A").
+				InOrNull("status", []interface{}{"active", "pending"}),
+			expected: "WHERE name = ? AND (status IN (?,?) OR status IS NULL)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier()
+
+			// Act
+			sql := dryRunSQL(fa.ApplyIdentifier(db.Model(&testutil.TestEntity{}), tt.ident))
+
+			// Assert
+			if !strings.Contains(sql, tt.expected) {
+				t.Errorf("Expected SQL to contain %q, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_TopLevelOr validates that filters set from an OR-combined
+// identifier are parenthesized as a whole, so soft-delete visibility and search apply to every
+// alternative
+func TestFilterApplier_ApplyQueryParams_TopLevelOr(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   *query.QueryParams[*testutil.TestEntity]
+		expected string
+	}{
+		{
+			name: "Two OR groups",
+			params: query.NewQueryParams[*testutil.TestEntity]().WithFilters(identifier.NewIdentifier().
+				AndGroup(identifier.NewIdentifier().Equal("status", "active").Equal("age", 30)).
+				OrGroup(identifier.NewIdentifier().Equal("status", "vip").GreaterThan("age", 60))),
+			expected: "WHERE ((status = ? AND age = ?) OR (status = ? AND age > ?)) AND deleted_at IS NULL",
+		},
+		{
+			name: "Flat OR with search",
+			params: query.NewQueryParams[*testutil.TestEntity]().
+				WithFilters(identifier.NewIdentifier().Equal("status", "active").Or(identifier.NewIdentifier().Equal("age", 30))).
+				WithSearch("john").
+				WithSearchFields("name"),
+			expected: "WHERE (status = ? OR age = ?) AND (LOWER(CAST(name AS TEXT)) LIKE ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier()
+
+			// Act
+			sql := dryRunSQL(fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), tt.params))
+
+			// Assert
+			if !strings.Contains(sql, tt.expected) {
+				t.Errorf("Expected SQL to contain %q, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_TopLevelOr_HidesTrashed validates that a trashed row matching one OR
+// alternative stays hidden
+func TestPostgresUnitOfWork_TopLevelOr_HidesTrashed(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "Live", Status: "active"}, {Name: "Trashed", Status: "active"}, {Name: "VIP", Status: "vip"}})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("status", "active").Or(identifier.NewIdentifier().Equal("status", "vip")))
+
+	// Act
+	result, total, err := uow.FindAllWithPagination(ctx, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if total != 2 || len(result) != 2 {
+		t.Fatalf("Expected 2 live entities, got %d (total %d)", len(result), total)
+	}
+	for _, entity := range result {
+		if entity.Name == "Trashed" {
+			t.Errorf("Expected the trashed entity to stay hidden")
+		}
+	}
+}
+
+// TestFilterApplier_ConflictingDeletedVisibility validates that params with both visibility
+// flags set render the same only-deleted condition before and after PrepareDefaults
+func TestFilterApplier_ConflictingDeletedVisibility(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	raw := &query.QueryParams[*testutil.TestEntity]{IncludeDeleted: true, OnlyDeleted: true}
+	prepared := (&query.QueryParams[*testutil.TestEntity]{IncludeDeleted: true, OnlyDeleted: true}).PrepareDefaults()
+
+	// Act
+	rawSQL := dryRunSQL(fa.ApplyQueryConditions(db.Model(&testutil.TestEntity{}), raw))
+	preparedSQL := dryRunSQL(fa.ApplyQueryConditions(db.Model(&testutil.TestEntity{}), prepared))
+
+	// Assert
+	if !strings.Contains(rawSQL, "WHERE deleted_at IS NOT NULL") {
+		t.Errorf("Expected only soft-deleted rows, got: %s", rawSQL)
+	}
+	if rawSQL != preparedSQL {
+		t.Errorf("Expected the same SQL after PrepareDefaults, got %s and %s", rawSQL, preparedSQL)
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_BuilderError validates that errors recorded while building params fail the query
+func TestFilterApplier_ApplyQueryParams_BuilderError(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFiltersMap(map[string]interface{}{"status) OR (1=1": "active"})
+
+	// Act
+	result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(result.Error, &validationErr) {
+		t.Fatalf("Expected validation error, got: %v", result.Error)
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_InvalidSortOrder validates that malformed orders are rejected
+func TestFilterApplier_ApplyQueryParams_InvalidSortOrder(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]()
+	params.Sort = []query.SortField{{Field: "name", Order: "asc; DROP TABLE test_entities"}}
+
+	// Act
+	result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(result.Error, &validationErr) {
+		t.Fatalf("Expected validation error, got: %v", result.Error)
+	}
+	if validationErr.Field != "name" {
+		t.Errorf("Expected error on field 'name', got %q", validationErr.Field)
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_NormalizesSortOrder validates that orders are normalized before use
+func TestFilterApplier_ApplyQueryParams_NormalizesSortOrder(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]()
+	params.Sort = []query.SortField{{Field: "email", Order: " DESC "}}
+
+	// Act
+	result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+	// Assert
+	if result.Error != nil {
+		t.Fatalf("Expected no error, got: %v", result.Error)
+	}
+	if sql := dryRunSQL(result); !strings.Contains(sql, "ORDER BY email desc") {
+		t.Errorf("Expected normalized order, got: %s", sql)
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_MixedSortDirections validates that multi-field sorts keep their order and directions
+func TestFilterApplier_ApplyQueryParams_MixedSortDirections(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]().AddSortAsc("is_active").AddSortDesc("created_at")
+
+	// Act
+	result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+	// Assert
+	if result.Error != nil {
+		t.Fatalf("Expected no error, got: %v", result.Error)
+	}
+	if sql := dryRunSQL(result); !strings.HasSuffix(sql, "ORDER BY is_active asc,created_at desc") {
+		t.Errorf("Expected is_active asc then created_at desc, got: %s", sql)
+	}
+}
+
+// TestValidateFieldName validates field name checks
+func TestValidateFieldName(t *testing.T) {
+	tests := []struct {
+		field     string
+		expectErr bool
+	}{
+		{"name", false},
+		{"created_at", false},
+		{"users.name", false},
+		{"", true},
+		{"1name", true},
+		{"name OR 1=1", true},
+		{"a.b.c", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			// Act
+			err := ValidateFieldName(tt.field)
+
+			// Assert
+			if tt.expectErr && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_PreloadSpecs validates that conditional preloads filter the relation
+func TestFilterApplier_ApplyQueryParams_PreloadSpecs(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+
+	owner := &testutil.TestEntity{
+		Name: "Owner",
+		Orders: []testutil.TestOrder{
+			{Status: "paid", Amount: 10},
+			{Status: "pending", Amount: 20},
+			{Status: "paid", Amount: 30},
+		},
+	}
+	if err := db.Create(owner).Error; err != nil {
+		t.Fatalf("Failed to seed entity with orders: %v", err)
+	}
+
+	params := query.NewQueryParams[*testutil.TestEntity]().WithPreload("Orders", "status = ?", "paid")
+
+	// Act
+	var results []*testutil.TestEntity
+	err := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params).Find(&results).Error
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 entity, got %d", len(results))
+	}
+	if len(results[0].Orders) != 2 {
+		t.Fatalf("Expected 2 paid orders to be preloaded, got %d", len(results[0].Orders))
+	}
+	for _, order := range results[0].Orders {
+		if order.Status != "paid" {
+			t.Errorf("Expected only paid orders, got status %q", order.Status)
+		}
+	}
+}
+
+// TestFilterApplier_Search_Fields validates case-insensitive search across configured fields
+func TestFilterApplier_Search_Fields(t *testing.T) {
+	tests := []struct {
+		name     string
+		search   string
+		fields   []string
+		expected []string
+	}{
+		{"Matches any field", "ACME", []string{"name", "email"}, []string{"Acme Corp", "Jane"}},
+		{"Restricted to one field", "acme", []string{"email"}, []string{"Jane"}},
+		{"Wildcards match literally", "100%", []string{"name"}, []string{"100% Cotton"}},
+		{"Falls back to ID", "2", nil, []string{"Jane"}},
+		{"Integer also matches ID exactly", "4", []string{"name"}, []string{"1000 Cotton"}},
+		{"Integer column cast to text", "3", []string{"age"}, []string{"Acme Corp", "100% Cotton"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			_, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+				{Name: "Acme Corp", Email: "sales@example.com", Age: 30},
+				{Name: "Jane", Email: "jane@acme.io", Age: 25},
+				{Name: "100% Cotton", Email: "shop@example.com", Age: 41},
+				{Name: "1000 Cotton", Email: "bulk@example.com", Age: 52},
+			})
+			if err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			params := query.NewQueryParams[*testutil.TestEntity]().WithSearch(tt.search).WithSearchFields(tt.fields...).PrepareDefaults()
+
+			// Act
+			results, _, err := uow.FindAllWithPagination(ctx, params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(results) != len(tt.expected) {
+				t.Fatalf("Expected %d results, got %d", len(tt.expected), len(results))
+			}
+			for i, name := range tt.expected {
+				if results[i].Name != name {
+					t.Errorf("Expected %q, got %q", name, results[i].Name)
+				}
+			}
+		})
+	}
+}
+
+// TestFilterApplier_Search_Numeric validates that an integer search term adds exact matches
+// on the numeric search fields to the OR group, and that other terms do not
+func TestFilterApplier_Search_Numeric(t *testing.T) {
+	tests := []struct {
+		name          string
+		search        string
+		numericFields []string
+		contains      []string
+		excludes      []string
+	}{
+		{"Integer matches id", "42", nil, []string{"LOWER(CAST(name AS TEXT)) LIKE ?", "LOWER(CAST(email AS TEXT)) LIKE ?", "OR id = ?"}, nil},
+		{"Configured fields", "42", []string{"id", "age"}, []string{"OR id = ?", "OR age = ?"}, nil},
+		{"Disabled", "42", []string{}, []string{"LOWER(CAST(name AS TEXT)) LIKE ?"}, []string{"id = ?"}},
+		{"Text term", "4x2", nil, []string{"LOWER(CAST(name AS TEXT)) LIKE ?"}, []string{"id = ?"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier()
+			if tt.numericFields != nil {
+				fa.WithNumericSearchFields(tt.numericFields...)
+			}
+			params := query.NewQueryParams[*testutil.TestEntity]().WithSearch(tt.search).WithSearchFields("name", "email").PrepareDefaults()
+
+			// Act
+			result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+			// Assert
+			if result.Error != nil {
+				t.Fatalf("Expected no error, got: %v", result.Error)
+			}
+			sql := dryRunSQL(result)
+			for _, fragment := range tt.contains {
+				if !strings.Contains(sql, fragment) {
+					t.Errorf("Expected SQL to contain %q, got: %s", fragment, sql)
+				}
+			}
+			for _, fragment := range tt.excludes {
+				if strings.Contains(sql, fragment) {
+					t.Errorf("Expected SQL not to contain %q, got: %s", fragment, sql)
+				}
+			}
+		})
+	}
+}
+
+// TestFilterApplier_Search_InvalidField validates that unsafe search fields are rejected
+func TestFilterApplier_Search_InvalidField(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	params := query.NewQueryParams[*testutil.TestEntity]().WithSearch("x").WithSearchFields("name) OR (1=1")
+
+	// Act
+	_, err := uow.Count(context.Background(), params)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError, got: %v", err)
+	}
+}
+
+// TestFilterApplier_ApplyQueryParams_RawConditions validates that raw fragments are ANDed and their arguments bound
+func TestFilterApplier_ApplyQueryParams_RawConditions(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("is_active", true)).
+		WhereRaw("age > ? OR name = ?", 30, "x' OR '1'='1")
+
+	// Act
+	stmt := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params).
+		Session(&gorm.Session{DryRun: true}).Find(&[]testutil.TestEntity{}).Statement
+
+	// Assert
+	sql := stmt.SQL.String()
+	if !strings.Contains(sql, "is_active = ? AND (age > ? OR name = ?)") {
+		t.Errorf("Expected raw condition to be grouped and ANDed, got %s", sql)
+	}
+	if strings.Contains(sql, "'1'='1") {
+		t.Errorf("Expected raw condition arguments to be parameterized, got %s", sql)
+	}
+	if len(stmt.Vars) != 3 || stmt.Vars[1] != 30 || stmt.Vars[2] != "x' OR '1'='1" {
+		t.Errorf("Expected bound arguments [true 30 x' OR '1'='1], got %v", stmt.Vars)
+	}
+}
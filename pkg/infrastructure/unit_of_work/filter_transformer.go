@@ -0,0 +1,30 @@
+package unit_of_work
+
+import (
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+
+	"gorm.io/gorm"
+)
+
+// FilterTransformer rewrites the filters of a call before they are translated to SQL, for
+// cross-cutting concerns such as mapping a virtual field to a real column or enforcing a
+// tenant filter. It receives the top-level filters of the call, which may be empty, and
+// returns the filters to apply. Filters are combined with the LogicalOp of the preceding
+// filter, so a transformer adding a mandatory condition should wrap the incoming filters
+// in a group rather than append to them.
+type FilterTransformer func(filters []identifier.FilterCriteria) []identifier.FilterCriteria
+
+// WithFilterTransformers registers transformers that run, in order, on the filters of every
+// call before they are applied
+func (fa *FilterApplier) WithFilterTransformers(transformers ...FilterTransformer) *FilterApplier {
+	fa.filterTransformers = append(fa.filterTransformers, transformers...)
+	return fa
+}
+
+// applyCallFilters applies the top-level filters of a call after running the registered transformers
+func (fa *FilterApplier) applyCallFilters(query *gorm.DB, filters []identifier.FilterCriteria) *gorm.DB {
+	for _, transform := range fa.filterTransformers {
+		filters = transform(filters)
+	}
+	return fa.ApplyFilters(query, filters)
+}
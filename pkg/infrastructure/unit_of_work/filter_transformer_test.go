@@ -0,0 +1,115 @@
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// tenantTransformer restricts every call to rows whose status is tenant
+func tenantTransformer(tenant string) FilterTransformer {
+	return func(filters []identifier.FilterCriteria) []identifier.FilterCriteria {
+		scoped := identifier.NewIdentifier().Equal("status", tenant).ToFilterCriteria()
+		if len(filters) == 0 {
+			return scoped
+		}
+		return append([]identifier.FilterCriteria{{Group: filters}}, scoped...)
+	}
+}
+
+// seedTenantEntities creates two entities for tenant "a" and one for tenant "b"
+func seedTenantEntities(t *testing.T, uow *PostgresUnitOfWork[*testutil.TestEntity]) {
+	t.Helper()
+
+	_, err := uow.BulkInsert(context.Background(), []*testutil.TestEntity{
+		{Name: "Alice", Status: "a"},
+		{Name: "Bob", Status: "b"},
+		{Name: "Carol", Status: "a"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+}
+
+// TestWithFilterTransformer_AppliesToEveryQuery validates that a tenant filter added by a transformer restricts all reads
+func TestWithFilterTransformer_AppliesToEveryQuery(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithFilterTransformer(tenantTransformer("a"))).(*PostgresUnitOfWork[*testutil.TestEntity])
+	seedTenantEntities(t, uow)
+	ctx := context.Background()
+	orFilter := identifier.NewIdentifier().Equal("name", "Alice").Or(identifier.NewIdentifier().Equal("name", "Bob"))
+
+	// Act
+	all, allErr := uow.FindAll(ctx)
+	page, total, pageErr := uow.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]().WithFilters(orFilter).PrepareDefaults())
+	count, countErr := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]())
+	_, findErr := uow.FindOneByIdentifier(ctx, identifier.NewIdentifier().Equal("name", "Bob"))
+	_, byIDErr := uow.FindOneById(ctx, 2)
+
+	// Assert
+	if allErr != nil || pageErr != nil || countErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v", allErr, pageErr, countErr)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected FindAll to return 2 entities of tenant a, got %d", len(all))
+	}
+	if total != 1 || len(page) != 1 || page[0].Name != "Alice" {
+		t.Errorf("Expected only Alice to match the OR filter within tenant a, got %d (total %d)", len(page), total)
+	}
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+	if findErr == nil || byIDErr == nil {
+		t.Errorf("Expected entities of tenant b to be hidden, got errors %v, %v", findErr, byIDErr)
+	}
+}
+
+// TestWithFilterTransformer_AppliesToDeletes validates that transformed filters also restrict deletes
+func TestWithFilterTransformer_AppliesToDeletes(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithFilterTransformer(tenantTransformer("a"))).(*PostgresUnitOfWork[*testutil.TestEntity])
+	seedTenantEntities(t, uow)
+
+	// Act
+	deleted, err := uow.DeleteE(context.Background(), identifier.NewIdentifier().Equal("name", "Bob"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("Expected no rows of tenant b to be deleted, got %d", deleted)
+	}
+}
+
+// TestWithFilterTransformer_RewritesFields validates that a transformer can map a virtual field to a real column
+func TestWithFilterTransformer_RewritesFields(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	rename := func(filters []identifier.FilterCriteria) []identifier.FilterCriteria {
+		for i := range filters {
+			if filters[i].Field == "display_name" {
+				filters[i].Field = "name"
+			}
+		}
+		return filters
+	}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithFilterTransformer(rename)).(*PostgresUnitOfWork[*testutil.TestEntity])
+	seedTenantEntities(t, uow)
+
+	// Act
+	entity, err := uow.FindOneByIdentifier(context.Background(), identifier.NewIdentifier().Equal("display_name", "Carol"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if entity.Name != "Carol" {
+		t.Errorf("Expected Carol, got %q", entity.Name)
+	}
+}
@@ -0,0 +1,57 @@
+package unit_of_work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MergeJSON merges the keys of patch into the JSONB column of the live entities matching the
+// identifier in a single UPDATE (column = column || patch::jsonb), so concurrent writers of
+// different keys do not overwrite each other as a read-modify-write would. Top-level keys in
+// patch replace existing ones and every other key is preserved; a NULL column is treated as an
+// empty document. It returns the number of rows updated. PostgreSQL and SQLite are supported;
+// other dialects fail with ErrUnsupportedDialect.
+func (uow *PostgresUnitOfWork[T]) MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error) {
+	if err := ValidateFieldName(column); err != nil {
+		return 0, err
+	}
+	if len(patch) == 0 {
+		return 0, nil
+	}
+	document, err := json.Marshal(patch)
+	if err != nil {
+		return 0, domainerrors.NewValidationError("patch", fmt.Sprintf("patch is not valid JSON: %v", err))
+	}
+
+	column = uow.filterApplier.columnName(column)
+	merge, err := jsonMergeExpr(uow.Dialect(), column, string(document))
+	if err != nil {
+		return 0, err
+	}
+
+	db := uow.getDB()
+	query := uow.excludeDeleted(uow.identifierQuery(db, identifier)).WithContext(ctx)
+	result := query.Update(column, merge)
+	return result.RowsAffected, result.Error
+}
+
+// jsonMergeExpr returns the expression merging document into column for the dialect.
+// SQLite has no JSONB type and merges with json_patch, which has the same top-level semantics.
+func jsonMergeExpr(dialect, column, document string) (clause.Expr, error) {
+	switch dialect {
+	case "postgres":
+		return gorm.Expr(fmt.Sprintf("COALESCE(%s, '{}'::jsonb) || ?::jsonb", column), document), nil
+	case "sqlite":
+		return gorm.Expr(fmt.Sprintf("json_patch(COALESCE(%s, '{}'), ?)", column), document), nil
+	default:
+		return clause.Expr{}, fmt.Errorf("%w: MergeJSON on %s", unit_of_work.ErrUnsupportedDialect, dialect)
+	}
+}
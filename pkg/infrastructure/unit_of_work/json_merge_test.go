@@ -0,0 +1,154 @@
+package unit_of_work
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// documentEntity stores free-form metadata in a JSON column
+type documentEntity struct {
+	types.BaseEntity
+	Name     string  `gorm:"column:name"`
+	Metadata *string `gorm:"column:metadata"`
+}
+
+// TableName returns the table name for GORM
+func (de *documentEntity) TableName() string {
+	return "document_entities"
+}
+
+// TestPostgresUnitOfWork_MergeJSON validates that merged keys are added or replaced while
+// prior keys and other rows are preserved
+func TestPostgresUnitOfWork_MergeJSON(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	if err := db.AutoMigrate(&documentEntity{}); err != nil {
+		t.Fatalf("Failed to migrate document entity: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*documentEntity](db)
+	ctx := context.Background()
+
+	target := `{"color":"red","size":2}`
+	other := `{"color":"blue"}`
+	entities, err := uow.BulkInsert(ctx, []*documentEntity{{Name: "Target", Metadata: &target}, {Name: "Other", Metadata: &other}, {Name: "Empty"}})
+	if err != nil {
+		t.Fatalf("Failed to insert document entities: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		entity   *documentEntity
+		patch    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			"Merges into existing document",
+			entities[0],
+			map[string]interface{}{"color": "green", "tags": []string{"new"}},
+			map[string]interface{}{"color": "green", "size": float64(2), "tags": []interface{}{"new"}},
+		},
+		{
+			"Merges into NULL document",
+			entities[2],
+			map[string]interface{}{"color": "black"},
+			map[string]interface{}{"color": "black"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			affected, err := uow.MergeJSON(ctx, identifier.NewIdentifier().Equal("id", tt.entity.GetID()), "metadata", tt.patch)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if affected != 1 {
+				t.Errorf("Expected 1 row affected, got %d", affected)
+			}
+			stored, err := uow.FindOneById(ctx, tt.entity.GetID())
+			if err != nil {
+				t.Fatalf("Failed to reload entity: %v", err)
+			}
+			var document map[string]interface{}
+			if err := json.Unmarshal([]byte(*stored.Metadata), &document); err != nil {
+				t.Fatalf("Failed to decode merged document: %v", err)
+			}
+			if !reflect.DeepEqual(document, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, document)
+			}
+		})
+	}
+
+	untouched, err := uow.FindOneById(ctx, entities[1].GetID())
+	if err != nil {
+		t.Fatalf("Failed to reload entity: %v", err)
+	}
+	if *untouched.Metadata != other {
+		t.Errorf("Expected other rows to keep %s, got %s", other, *untouched.Metadata)
+	}
+}
+
+// TestPostgresUnitOfWork_MergeJSON_Validation validates that invalid columns and patches are
+// rejected and that an empty patch is a no-op
+func TestPostgresUnitOfWork_MergeJSON_Validation(t *testing.T) {
+	// Arrange
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t))
+	ctx := context.Background()
+	ident := identifier.NewIdentifier().Equal("id", 1)
+
+	// Act
+	_, columnErr := uow.MergeJSON(ctx, ident, "metadata; DROP TABLE x", map[string]interface{}{"a": 1})
+	_, patchErr := uow.MergeJSON(ctx, ident, "metadata", map[string]interface{}{"a": make(chan int)})
+	affected, emptyErr := uow.MergeJSON(ctx, ident, "metadata", nil)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(columnErr, &validationErr) {
+		t.Errorf("Expected validation error for the column, got: %v", columnErr)
+	}
+	if !errors.As(patchErr, &validationErr) {
+		t.Errorf("Expected validation error for the patch, got: %v", patchErr)
+	}
+	if emptyErr != nil || affected != 0 {
+		t.Errorf("Expected an empty patch to do nothing, got %d rows and %v", affected, emptyErr)
+	}
+}
+
+// TestJSONMergeExpr validates the JSONB concatenation emitted for PostgreSQL
+func TestJSONMergeExpr(t *testing.T) {
+	// Act
+	expr, err := jsonMergeExpr("postgres", "metadata", `{"a":1}`)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if expr.SQL != "COALESCE(metadata, '{}'::jsonb) || ?::jsonb" {
+		t.Errorf("Unexpected merge expression: %s", expr.SQL)
+	}
+	if len(expr.Vars) != 1 || expr.Vars[0] != `{"a":1}` {
+		t.Errorf("Expected the patch to be bound, got %v", expr.Vars)
+	}
+}
+
+// TestJSONMergeExpr_UnsupportedDialect validates that dialects without a merge expression are rejected
+func TestJSONMergeExpr_UnsupportedDialect(t *testing.T) {
+	// Act
+	_, err := jsonMergeExpr("mysql", "metadata", `{"a":1}`)
+
+	// Assert
+	if !errors.Is(err, unit_of_work.ErrUnsupportedDialect) {
+		t.Errorf("Expected ErrUnsupportedDialect, got: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package unit_of_work
+
+import (
+	"fmt"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+
+	"gorm.io/gorm/clause"
+)
+
+// lockingClauses returns the row locking clause requested by params, none when it requests
+// no locking. It fails when the strength or option is unknown or when no transaction is
+// active, since the locks would be released as soon as the statement ends.
+func (uow *PostgresUnitOfWork[T]) lockingClauses(params *query.QueryParams[T]) ([]clause.Expression, error) {
+	if params == nil || params.Locking == nil {
+		return nil, nil
+	}
+	if uow.tx == nil {
+		return nil, unit_of_work.ErrLockingOutsideTransaction
+	}
+
+	locking := params.Locking
+	switch locking.Strength {
+	case query.LockStrengthUpdate, query.LockStrengthShare:
+	default:
+		return nil, domainerrors.NewValidationError("locking", fmt.Sprintf("unsupported lock strength %q", locking.Strength))
+	}
+	switch locking.Options {
+	case query.LockOptionWait, query.LockOptionNoWait, query.LockOptionSkipLocked:
+	default:
+		return nil, domainerrors.NewValidationError("locking", fmt.Sprintf("unsupported lock option %q", locking.Options))
+	}
+
+	return []clause.Expression{clause.Locking{Strength: string(locking.Strength), Options: string(locking.Options)}}, nil
+}
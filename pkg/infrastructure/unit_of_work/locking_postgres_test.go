@@ -0,0 +1,78 @@
+//go:build postgres
+
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// setupPostgresDB connects to the Postgres database in POSTGRES_DSN with a fresh
+// test_entities table, and skips the test when it is not set
+func setupPostgresDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := openPostgresDB(t, "POSTGRES_DSN")
+	if err := db.Migrator().DropTable(&testutil.TestEntity{}); err != nil {
+		t.Fatalf("Failed to drop test entities: %v", err)
+	}
+	if err := db.AutoMigrate(&testutil.TestEntity{}); err != nil {
+		t.Fatalf("Failed to migrate test entities: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Migrator().DropTable(&testutil.TestEntity{}) })
+	return db
+}
+
+// TestFindAllWithPagination_SkipLocked_DisjointClaims validates that two transactions claiming
+// jobs with FOR UPDATE SKIP LOCKED receive disjoint rows
+func TestFindAllWithPagination_SkipLocked_DisjointClaims(t *testing.T) {
+	// Arrange
+	db := setupPostgresDB(t)
+	ctx := context.Background()
+	jobs := make([]*testutil.TestEntity, 4)
+	for i := range jobs {
+		jobs[i] = &testutil.TestEntity{Name: "job", Status: "queued"}
+	}
+	if err := db.Create(&jobs).Error; err != nil {
+		t.Fatalf("Failed to seed jobs: %v", err)
+	}
+	claim := func() *query.QueryParams[*testutil.TestEntity] {
+		params := query.NewQueryParams[*testutil.TestEntity]().WithLocking(query.LockStrengthUpdate, query.LockOptionSkipLocked)
+		params.PageSize = 2
+		return params.PrepareDefaults()
+	}
+	first := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	second := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if err := first.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	if err := second.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer first.RollbackTransaction(ctx)
+	defer second.RollbackTransaction(ctx)
+
+	// Act
+	firstClaim, _, firstErr := first.FindAllWithPagination(ctx, claim())
+	secondClaim, _, secondErr := second.FindAllWithPagination(ctx, claim())
+
+	// Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", firstErr, secondErr)
+	}
+	if len(firstClaim) != 2 || len(secondClaim) != 2 {
+		t.Fatalf("Expected two jobs per claim, got %d and %d", len(firstClaim), len(secondClaim))
+	}
+	claimed := map[int]bool{}
+	for _, job := range append(firstClaim, secondClaim...) {
+		if claimed[job.GetID()] {
+			t.Errorf("Expected disjoint claims, job %d was claimed twice", job.GetID())
+		}
+		claimed[job.GetID()] = true
+	}
+}
@@ -0,0 +1,98 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// TestFindAllWithPagination_Locking validates the locking clause added to the select, but not
+// to the count. SQLite drops locking clauses, so its clause builder is removed and the
+// statements are only rendered.
+func TestFindAllWithPagination_Locking(t *testing.T) {
+	tests := []struct {
+		name     string
+		strength query.LockStrength
+		options  query.LockOption
+		expected string
+	}{
+		{"For update skip locked", query.LockStrengthUpdate, query.LockOptionSkipLocked, "LIMIT 10 FOR UPDATE SKIP LOCKED"},
+		{"For share nowait", query.LockStrengthShare, query.LockOptionNoWait, "LIMIT 10 FOR SHARE NOWAIT"},
+		{"For update", query.LockStrengthUpdate, query.LockOptionWait, "LIMIT 10 FOR UPDATE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			delete(db.ClauseBuilders, "FOR")
+			var statements []string
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db.Session(&gorm.Session{DryRun: true}),
+				WithSQLCapture(func(_ context.Context, statement CapturedSQL) {
+					statements = append(statements, statement.SQL)
+				}))
+			ctx := context.Background()
+			if err := uow.BeginTransaction(ctx); err != nil {
+				t.Fatalf("Failed to begin transaction: %v", err)
+			}
+			defer uow.RollbackTransaction(ctx)
+			params := query.NewQueryParams[*testutil.TestEntity]().WithLocking(tt.strength, tt.options)
+			params.PageSize = 10
+			params.PrepareDefaults()
+
+			// Act
+			_, _, err := uow.FindAllWithPagination(ctx, params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(statements) != 2 {
+				t.Fatalf("Expected the count and select statements, got %v", statements)
+			}
+			if strings.Contains(statements[0], "FOR ") {
+				t.Errorf("Expected the count not to lock, got %s", statements[0])
+			}
+			if !strings.HasSuffix(statements[1], tt.expected) {
+				t.Errorf("Expected the select to end with %q, got %s", tt.expected, statements[1])
+			}
+		})
+	}
+}
+
+// TestFindAllWithPagination_LockingErrors validates that locking reads are rejected outside a
+// transaction or with an unknown option
+func TestFindAllWithPagination_LockingErrors(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	outside := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	inside := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if err := inside.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer inside.RollbackTransaction(ctx)
+
+	// Act
+	_, _, outsideErr := outside.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]().
+		WithLocking(query.LockStrengthUpdate, query.LockOptionSkipLocked))
+	_, _, optionErr := inside.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]().
+		WithLocking(query.LockStrengthUpdate, "SKIP LOCKED; DROP TABLE test_entities"))
+
+	// Assert
+	if !errors.Is(outsideErr, unit_of_work.ErrLockingOutsideTransaction) {
+		t.Errorf("Expected ErrLockingOutsideTransaction, got: %v", outsideErr)
+	}
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(optionErr, &validationErr) {
+		t.Errorf("Expected validation error for the option, got: %v", optionErr)
+	}
+}
@@ -0,0 +1,26 @@
+package unit_of_work
+
+import (
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+// NamingStrategy maps a field name used in filters and sorting to a database column name
+type NamingStrategy func(field string) string
+
+// SnakeCaseNamingStrategy converts Go-style field names ("createdAt", "users.createdAt")
+// to snake_case column names using GORM's default naming rules
+func SnakeCaseNamingStrategy(field string) string {
+	namer := schema.NamingStrategy{}
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		parts[i] = namer.ColumnName("", part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// IdentityNamingStrategy passes field names through unchanged
+func IdentityNamingStrategy(field string) string {
+	return field
+}
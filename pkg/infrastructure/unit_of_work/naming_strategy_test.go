@@ -0,0 +1,105 @@
+package unit_of_work
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestSnakeCaseNamingStrategy validates Go-style to snake_case conversion
+func TestSnakeCaseNamingStrategy(t *testing.T) {
+	tests := []struct {
+		field    string
+		expected string
+	}{
+		{"createdAt", "created_at"},
+		{"created_at", "created_at"},
+		{"ID", "id"},
+		{"testEntityID", "test_entity_id"},
+		{"test_entities.createdAt", "test_entities.created_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			// Act
+			result := SnakeCaseNamingStrategy(tt.field)
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFilterApplier_NamingStrategy validates that filter and sort fields are mapped before building SQL
+func TestFilterApplier_NamingStrategy(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().GreaterThan("createdAt", "2024-01-01").Equal("isActive", true)).
+		AddSortDesc("updatedAt")
+
+	// Act
+	sql := dryRunSQL(fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params))
+
+	// Assert
+	for _, expected := range []string{"created_at > ?", "is_active = ?", "updated_at desc"} {
+		if !strings.Contains(sql, expected) {
+			t.Errorf("Expected SQL to contain %q, got: %s", expected, sql)
+		}
+	}
+	if strings.Contains(sql, "createdAt") {
+		t.Errorf("Expected camelCase field to be mapped, got: %s", sql)
+	}
+}
+
+// TestFilterApplier_NamingStrategy_Expressions validates that expressions are passed through untouched
+func TestFilterApplier_NamingStrategy_Expressions(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier()
+	ident := identifier.NewIdentifier().Equal("LOWER(Name)", "john")
+
+	// Act
+	sql := dryRunSQL(fa.ApplyIdentifier(db.Model(&testutil.TestEntity{}), ident))
+
+	// Assert
+	if !strings.Contains(sql, "LOWER(Name) = ?") {
+		t.Errorf("Expected expression to be unchanged, got: %s", sql)
+	}
+}
+
+// TestPostgresUnitOfWork_WithNamingStrategy validates a custom strategy configured on the unit of work
+func TestPostgresUnitOfWork_WithNamingStrategy(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	aliases := map[string]string{"fullName": "name"}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithNamingStrategy(func(field string) string {
+		if column, ok := aliases[field]; ok {
+			return column
+		}
+		return SnakeCaseNamingStrategy(field)
+	}))
+	ctx := context.Background()
+
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "John Doe", Status: "active"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	// Act
+	result, err := uow.FindOneByIdentifier(ctx, identifier.NewIdentifier().Equal("fullName", "John Doe"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Name != "John Doe" {
+		t.Errorf("Expected Name 'John Doe', got '%s'", result.Name)
+	}
+}
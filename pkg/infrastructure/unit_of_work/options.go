@@ -0,0 +1,342 @@
+package unit_of_work
+
+import (
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// defaultConnectionRetryDelay is the pause before retrying a read that failed with a connection error
+	defaultConnectionRetryDelay = 100 * time.Millisecond
+
+	// defaultTransactionAttempts is how many times RunInTransaction runs a body that fails transiently
+	defaultTransactionAttempts = 3
+
+	// defaultTransactionRetryBackoff is the pause before the first transaction retry, doubled
+	// for each further retry up to maxTransactionRetryBackoff
+	defaultTransactionRetryBackoff = 10 * time.Millisecond
+
+	// maxTransactionRetryBackoff caps the pause between transaction retries
+	maxTransactionRetryBackoff = 200 * time.Millisecond
+)
+
+// postgresConfig holds the optional behavior of a PostgresUnitOfWork
+type postgresConfig struct {
+	// retryOnConnectionError enables a single retry of non-transactional reads on connection loss
+	retryOnConnectionError bool
+
+	// retryDelay is the pause before the retry attempt
+	retryDelay time.Duration
+
+	// transactionAttempts bounds how many times RunInTransaction runs its body
+	transactionAttempts int
+
+	// transactionRetryBackoff is the pause before the first transaction retry
+	transactionRetryBackoff time.Duration
+
+	// namingStrategy overrides how filter and sort fields map to column names
+	namingStrategy NamingStrategy
+
+	// errorOnEmptyIn rejects In/NotIn filters with no values instead of matching none/all rows
+	errorOnEmptyIn bool
+
+	// utcTimestamps sets auto-managed timestamps and time filter values in UTC
+	utcTimestamps bool
+
+	// softDeleteStrategy selects the column and values used to mark soft-deleted rows
+	softDeleteStrategy SoftDeleteStrategy
+
+	// insertReturning repopulates inserted entities from the database with RETURNING *
+	insertReturning bool
+
+	// updateReturning repopulates entities saved by BulkUpdate from the database with RETURNING
+	updateReturning bool
+
+	// updateReturningColumns limits RETURNING to these columns (empty = all columns)
+	updateReturningColumns []string
+
+	// scopes are applied to every statement issued by the unit of work
+	scopes []func(*gorm.DB) *gorm.DB
+
+	// skipUpdatePreCheck updates in a single statement and detects missing rows from rows affected
+	skipUpdatePreCheck bool
+
+	// bulkInsertBatchSize splits BulkInsert into INSERT statements of at most this many rows (0 = one statement)
+	bulkInsertBatchSize int
+
+	// logger replaces the GORM logger of the connection, nil keeps it
+	logger logger.Interface
+
+	// logLevel overrides the level of the GORM logger when set
+	logLevel logger.LogLevel
+
+	// sqlCapture receives every executed statement when set
+	sqlCapture SQLCaptureFunc
+
+	// plugins are installed on the connection (and read replica) at construction
+	plugins []gorm.Plugin
+
+	// parseDateStrings parses date string filter values bound against timestamp columns
+	parseDateStrings bool
+
+	// readReplica serves reads with query parameters outside transactions, nil reads from the primary
+	readReplica *gorm.DB
+
+	// filterTransformers rewrite the filters of every call before they are applied
+	filterTransformers []FilterTransformer
+
+	// sortExpressions maps logical sort fields to the SQL expressions ordered by
+	sortExpressions map[string]string
+
+	// defaultSort orders queries whose params specify no sort
+	defaultSort []query.SortField
+
+	// numericSearchFields are matched exactly by integer search terms (id when nil)
+	numericSearchFields []string
+
+	// allowFullTableDelete lets hard deletes without conditions remove every row
+	allowFullTableDelete bool
+
+	// partitionResolver picks the physical table of paginated reads and counts from their filters
+	partitionResolver PartitionResolver
+}
+
+// PostgresOption configures optional behavior of a PostgresUnitOfWork
+type PostgresOption func(*postgresConfig)
+
+// WithConnectionRetry enables retrying read operations once after the given delay
+// when they fail with a connection error (connection reset, broken pipe, ...).
+// Reads executed inside a transaction are never retried. A non-positive delay uses the default.
+func WithConnectionRetry(delay time.Duration) PostgresOption {
+	return func(cfg *postgresConfig) {
+		if delay <= 0 {
+			delay = defaultConnectionRetryDelay
+		}
+		cfg.retryOnConnectionError = true
+		cfg.retryDelay = delay
+	}
+}
+
+// WithTransactionRetry sets how many times RunInTransaction runs its body when the
+// transaction fails with a transient error (serialization failure, deadlock). Retries
+// wait a short, doubling backoff so competing transactions can finish.
+// Values below 1 are treated as 1, which disables retries.
+func WithTransactionRetry(maxAttempts int) PostgresOption {
+	return func(cfg *postgresConfig) {
+		if maxAttempts < 1 {
+			maxAttempts = 1
+		}
+		cfg.transactionAttempts = maxAttempts
+	}
+}
+
+// WithNamingStrategy sets how filter and sort field names are mapped to column names.
+// The default converts Go-style names to snake_case (e.g. "createdAt" -> "created_at").
+func WithNamingStrategy(strategy NamingStrategy) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.namingStrategy = strategy
+	}
+}
+
+// WithErrorOnEmptyIn makes queries with an empty In or NotIn values list fail with a
+// validation error. By default an empty In matches no rows and an empty NotIn matches all rows.
+func WithErrorOnEmptyIn() PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.errorOnEmptyIn = true
+	}
+}
+
+// WithUTCTimestamps stores auto-managed timestamps (created_at, updated_at, deleted_at) in UTC
+// and converts time.Time filter values to UTC before they are bound, so comparisons do not
+// depend on the local time zone of the process.
+func WithUTCTimestamps() PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.utcTimestamps = true
+	}
+}
+
+// WithSoftDeleteStrategy selects how soft-deleted rows are marked. SoftDeleteBoolean uses an
+// is_deleted boolean column instead of the deleted_at timestamp: soft deletes set it to true,
+// restores set it to false and default queries only return rows where it is false.
+func WithSoftDeleteStrategy(strategy SoftDeleteStrategy) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.softDeleteStrategy = strategy
+	}
+}
+
+// WithInsertReturning makes Insert and BulkInsert request every column back with RETURNING *,
+// so the returned entities reflect all server-side defaults (sequences, default expressions,
+// generated columns) and not only the primary key.
+func WithInsertReturning() PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.insertReturning = true
+	}
+}
+
+// WithUpdateReturning makes BulkUpdate request the updated rows back with RETURNING, so the
+// returned entities reflect what was actually written, including trigger changes and
+// columns the model does not write. Only the given columns are read back when any are
+// listed; otherwise every column is. Invalid column names make BulkUpdate fail.
+func WithUpdateReturning(columns ...string) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.updateReturning = true
+		cfg.updateReturningColumns = columns
+	}
+}
+
+// WithScopes applies reusable GORM scopes (e.g. tenant scoping) to every statement issued by
+// the unit of work, inside and outside transactions. Scopes run when a statement executes,
+// after the filters of the call have been added.
+func WithScopes(scopes ...func(*gorm.DB) *gorm.DB) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.scopes = append(cfg.scopes, scopes...)
+	}
+}
+
+// WithSkipUpdatePreCheck makes Update and UpdateE issue a single UPDATE restricted to the
+// entity's primary key and the identifier, instead of loading the entity first. When no row
+// changes, gorm.ErrRecordNotFound is returned, as without the option.
+func WithSkipUpdatePreCheck() PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.skipUpdatePreCheck = true
+	}
+}
+
+// WithBulkInsertBatchSize makes BulkInsert issue one INSERT per batch of at most size rows,
+// keeping very large imports under PostgreSQL's limit of 65535 bind parameters per statement.
+// When the rows span several batches GORM runs them in one transaction, so a failing batch
+// rolls back the earlier ones, unless the connection was opened with SkipDefaultTransaction.
+// A size of zero or less keeps the single-statement default.
+func WithBulkInsertBatchSize(size int) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.bulkInsertBatchSize = size
+	}
+}
+
+// WithLogger sets the GORM logger used for every statement issued by the unit of work,
+// for example to route SQL logs to the application's logger
+func WithLogger(l logger.Interface) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.logger = l
+	}
+}
+
+// WithLogLevel changes the level of the unit of work's GORM logger (the one set by WithLogger,
+// or the connection's own), for example logger.Info to log every query in staging
+func WithLogLevel(level logger.LogLevel) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.logLevel = level
+	}
+}
+
+// WithSQLCapture reports the parameterized SQL and arguments of every statement the unit of
+// work executes to capture, whatever the log level, for debugging filters or auditing writes
+func WithSQLCapture(capture SQLCaptureFunc) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.sqlCapture = capture
+	}
+}
+
+// WithPlugins installs GORM plugins, such as encryption, metrics or optimizer hint plugins,
+// on the connection and the read replica when the unit of work is constructed. Plugins are
+// registered on the shared *gorm.DB, as with db.Use, so other users of it see them too.
+func WithPlugins(plugins ...gorm.Plugin) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.plugins = append(cfg.plugins, plugins...)
+	}
+}
+
+// WithDateStringParsing makes filters on timestamp columns parse date-like string values,
+// such as "2023-01-01" or RFC 3339 timestamps, into time.Time before they are bound, so
+// comparisons do not depend on how the driver converts strings. Strings without a zone
+// are read as UTC; strings that are not dates are bound unchanged.
+func WithDateStringParsing() PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.parseDateStrings = true
+	}
+}
+
+// WithReadReplica sends FindAllWithPagination, GetTrashedWithPagination, Count,
+// FindChangedSince and NearestNeighbors to replica when they run outside a transaction,
+// keeping that load off the primary. Replicas may lag, so reads that must see a recent
+// write can opt out with QueryParams.ForcePrimary. All other operations, and everything
+// inside a transaction, use the primary.
+func WithReadReplica(replica *gorm.DB) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.readReplica = replica
+	}
+}
+
+// WithFilterTransformer registers a transformer that rewrites the filters of every read,
+// update and delete before they are translated to SQL, including calls without filters.
+// Transformers run in registration order.
+func WithFilterTransformer(transformer FilterTransformer) PostgresOption {
+	return func(cfg *postgresConfig) {
+		if transformer != nil {
+			cfg.filterTransformers = append(cfg.filterTransformers, transformer)
+		}
+	}
+}
+
+// WithSortExpressions maps logical sort field names to SQL expressions, so QueryParams can
+// sort by "name" while the query orders by "lower(name)" or a computed column. Expressions
+// are trusted as written; sort fields without a mapping must be plain column names.
+func WithSortExpressions(expressions map[string]string) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.sortExpressions = expressions
+	}
+}
+
+// WithDefaultSort sets the ordering used when QueryParams carry no sort, replacing the
+// id ASC fallback, so an entity can be listed newest first by default
+func WithDefaultSort(sorts ...query.SortField) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.defaultSort = sorts
+	}
+}
+
+// WithNumericSearchFields sets the columns that an integer search term matches exactly, in
+// addition to the substring match on SearchFields, so searching "42" also finds the entity
+// with id 42. It defaults to id; call it with no fields to disable exact matching.
+func WithNumericSearchFields(fields ...string) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.numericSearchFields = append([]string{}, fields...)
+	}
+}
+
+// WithAllowFullTableDelete lets HardDelete, BulkHardDelete and PruneWhere run without any
+// condition, permanently removing every row, and lets SoftDeleteWhere trash every row.
+// Without it they fail with ErrUnboundedDelete.
+func WithAllowFullTableDelete() PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.allowFullTableDelete = true
+	}
+}
+
+// WithPartitionResolver makes FindAllWithPagination, Count and CountPages query the table
+// returned by resolver for the filters of their params, for time-partitioned tables such as
+// events_2024_01. The resolved name must be a plain or schema-qualified identifier.
+func WithPartitionResolver(resolver PartitionResolver) PostgresOption {
+	return func(cfg *postgresConfig) {
+		cfg.partitionResolver = resolver
+	}
+}
+
+// newPostgresConfig builds a postgresConfig from the provided options
+func newPostgresConfig(opts ...PostgresOption) postgresConfig {
+	cfg := postgresConfig{
+		retryDelay:              defaultConnectionRetryDelay,
+		transactionAttempts:     defaultTransactionAttempts,
+		transactionRetryBackoff: defaultTransactionRetryBackoff,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
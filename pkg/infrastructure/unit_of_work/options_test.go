@@ -0,0 +1,134 @@
+package unit_of_work
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// capturingLogger records the SQL of every traced statement
+type capturingLogger struct {
+	statements []string
+}
+
+// LogMode returns the logger unchanged
+func (cl *capturingLogger) LogMode(logger.LogLevel) logger.Interface { return cl }
+
+// Info discards informational messages
+func (cl *capturingLogger) Info(context.Context, string, ...interface{}) {}
+
+// Warn discards warnings
+func (cl *capturingLogger) Warn(context.Context, string, ...interface{}) {}
+
+// Error discards errors
+func (cl *capturingLogger) Error(context.Context, string, ...interface{}) {}
+
+// Trace records the statement SQL
+func (cl *capturingLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, _ := fc()
+	cl.statements = append(cl.statements, sql)
+}
+
+// TestWithLogger validates that statements are reported to the configured logger
+func TestWithLogger(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	capture := &capturingLogger{}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithLogger(capture))
+
+	// Act
+	_, err := uow.FindAll(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(capture.statements) != 1 || !strings.Contains(capture.statements[0], "SELECT * FROM `test_entities`") {
+		t.Errorf("Expected the query to be logged, got %v", capture.statements)
+	}
+}
+
+// TestWithLogLevel validates that raising the level enables query logging
+func TestWithLogLevel(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	var buf bytes.Buffer
+	silent := logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Silent})
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithLogger(silent), WithLogLevel(logger.Info))
+
+	// Act
+	_, err := uow.FindAll(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !strings.Contains(buf.String(), "SELECT * FROM `test_entities`") {
+		t.Errorf("Expected the query to be logged, got %q", buf.String())
+	}
+}
+
+// countingPlugin counts the queries executed through the connection it is installed on
+type countingPlugin struct {
+	queries int
+	initErr error
+}
+
+// Name returns the plugin name
+func (cp *countingPlugin) Name() string { return "counting" }
+
+// Initialize registers an after-query callback
+func (cp *countingPlugin) Initialize(db *gorm.DB) error {
+	if cp.initErr != nil {
+		return cp.initErr
+	}
+	return db.Callback().Query().After("gorm:query").Register("counting:after_query", func(*gorm.DB) {
+		cp.queries++
+	})
+}
+
+// TestWithPlugins validates that installed plugins' callbacks run for unit of work queries
+// and that constructing a second unit of work with the same plugin is harmless
+func TestWithPlugins(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	plugin := &countingPlugin{}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithPlugins(plugin))
+	again := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithPlugins(plugin))
+
+	// Act
+	_, err := uow.FindAll(context.Background())
+	_, againErr := again.FindAll(context.Background())
+
+	// Assert
+	if err != nil || againErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, againErr)
+	}
+	if plugin.queries != 2 {
+		t.Errorf("Expected the plugin callback to fire once per query, got %d", plugin.queries)
+	}
+}
+
+// TestWithPlugins_InitializeError validates that a plugin failing to initialize fails queries
+func TestWithPlugins_InitializeError(t *testing.T) {
+	// Arrange
+	initErr := errors.New("missing encryption key")
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t), WithPlugins(&countingPlugin{initErr: initErr}))
+
+	// Act
+	_, err := uow.FindAll(context.Background())
+
+	// Assert
+	if !errors.Is(err, initErr) {
+		t.Errorf("Expected the plugin error, got: %v", err)
+	}
+}
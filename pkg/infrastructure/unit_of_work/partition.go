@@ -0,0 +1,37 @@
+package unit_of_work
+
+import (
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+
+	"gorm.io/gorm"
+)
+
+// PartitionResolver returns the physical table queried for the given filters, such as
+// "events_2024_01" for a created_at range within January 2024 on a table partitioned by
+// month. An empty result queries the entity's own table.
+type PartitionResolver func(filters []identifier.FilterCriteria) string
+
+// paramsQuery starts a query on the entity, targeting the partition resolved from the
+// filters of params when a PartitionResolver is configured. Resolved names that are not
+// plain or schema-qualified identifiers fail the query.
+func (uow *PostgresUnitOfWork[T]) paramsQuery(db *gorm.DB, params *query.QueryParams[T]) *gorm.DB {
+	baseQuery := db.Model(new(T))
+	if uow.config.partitionResolver == nil {
+		return baseQuery
+	}
+
+	var filters []identifier.FilterCriteria
+	if params != nil {
+		filters = params.Filters
+	}
+	table := uow.config.partitionResolver(filters)
+	if table == "" {
+		return baseQuery
+	}
+	if err := ValidateFieldName(table); err != nil {
+		_ = baseQuery.AddError(err)
+		return baseQuery
+	}
+	return baseQuery.Table(table)
+}
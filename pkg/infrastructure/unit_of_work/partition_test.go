@@ -0,0 +1,82 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// monthlyPartition resolves test_entities_YYYY_MM from a created_at lower bound
+func monthlyPartition(filters []identifier.FilterCriteria) string {
+	for _, filter := range filters {
+		if from, ok := filter.Value.(time.Time); ok && filter.Field == "created_at" && filter.Operator == identifier.FilterOperatorGreaterEqual {
+			return from.Format("test_entities_2006_01")
+		}
+	}
+	return ""
+}
+
+// TestWithPartitionResolver validates that paginated reads and counts target the resolved partition
+func TestWithPartitionResolver(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	if err := db.Table("test_entities_2024_01").AutoMigrate(&testutil.TestEntity{}); err != nil {
+		t.Fatalf("Failed to create partition: %v", err)
+	}
+	january := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	partitioned := &testutil.TestEntity{Name: "January event"}
+	partitioned.CreatedAt = january
+	if err := db.Table("test_entities_2024_01").Create(partitioned).Error; err != nil {
+		t.Fatalf("Failed to insert into partition: %v", err)
+	}
+
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithPartitionResolver(monthlyPartition))
+	// Two rows in the entity table, so counting the wrong table cannot match the partition
+	if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "Unpartitioned"}, {Name: "Unpartitioned 2"}}); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().GreaterOrEqual("created_at", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Act
+	result, total, err := uow.FindAllWithPagination(ctx, params)
+	count, countErr := uow.Count(ctx, params)
+	unresolved, _, unresolvedErr := uow.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]())
+
+	// Assert
+	if err != nil || countErr != nil || unresolvedErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v", err, countErr, unresolvedErr)
+	}
+	if total != 1 || len(result) != 1 || result[0].Name != "January event" {
+		t.Errorf("Expected the partitioned row only, got %+v (total %d)", result, total)
+	}
+	if count != 1 {
+		t.Errorf("Expected Count to target the partition, got %d", count)
+	}
+	if len(unresolved) != 2 || unresolved[0].Name != "Unpartitioned" {
+		t.Errorf("Expected the entity table when nothing resolves, got %+v", unresolved)
+	}
+}
+
+// TestWithPartitionResolver_InvalidTable validates that resolved names must be identifiers
+func TestWithPartitionResolver_InvalidTable(t *testing.T) {
+	// Arrange
+	resolver := func([]identifier.FilterCriteria) string { return "events; DROP TABLE users" }
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t), WithPartitionResolver(resolver))
+
+	// Act
+	_, _, err := uow.FindAllWithPagination(context.Background(), query.NewQueryParams[*testutil.TestEntity]())
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package unit_of_work
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultMaxOpenConns bounds concurrent connections, well below PostgreSQL's default max_connections of 100
+	defaultMaxOpenConns = 25
+
+	// defaultMaxIdleConns keeps enough warm connections for steady traffic
+	defaultMaxIdleConns = 10
+
+	// defaultConnMaxLifetime recycles connections so load balancer and failover changes are picked up
+	defaultConnMaxLifetime = 30 * time.Minute
+
+	// defaultConnMaxIdleTime closes connections left unused after a burst
+	defaultConnMaxIdleTime = 5 * time.Minute
+)
+
+// PoolOptions sizes the connection pool of a *gorm.DB. Zero values use the defaults
+// (25 open, 10 idle, 30 minute lifetime, 5 minute idle time); negative values remove the
+// limit, or for MaxIdleConns keep no idle connections.
+type PoolOptions struct {
+	MaxOpenConns    int           // Maximum number of open connections
+	MaxIdleConns    int           // Maximum number of idle connections, capped at MaxOpenConns
+	ConnMaxLifetime time.Duration // Maximum time a connection may be reused
+	ConnMaxIdleTime time.Duration // Maximum time a connection may sit idle
+}
+
+// ConfigurePool applies opts to the sql.DB underlying db. Call it once after opening the
+// connection and before creating units of work, since all of them share the pool.
+func ConfigurePool(db *gorm.DB, opts PoolOptions) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	maxOpen := poolLimit(opts.MaxOpenConns, defaultMaxOpenConns)
+	maxIdle := poolLimit(opts.MaxIdleConns, defaultMaxIdleConns)
+	if maxOpen > 0 && maxIdle > maxOpen {
+		maxIdle = maxOpen
+	}
+
+	sqlDB.SetMaxOpenConns(maxOpen)
+	sqlDB.SetMaxIdleConns(maxIdle)
+	sqlDB.SetConnMaxLifetime(poolLimit(opts.ConnMaxLifetime, defaultConnMaxLifetime))
+	sqlDB.SetConnMaxIdleTime(poolLimit(opts.ConnMaxIdleTime, defaultConnMaxIdleTime))
+	return nil
+}
+
+// poolLimit resolves a pool setting: zero selects the default and negative values mean no limit (0)
+func poolLimit[N int | time.Duration](value, defaultValue N) N {
+	switch {
+	case value == 0:
+		return defaultValue
+	case value < 0:
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,69 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// TestConfigurePool validates that the pool limits are applied to the underlying sql.DB
+func TestConfigurePool(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            PoolOptions
+		expectedMaxOpen int
+		expectedIdle    int
+	}{
+		{"Defaults", PoolOptions{}, defaultMaxOpenConns, 3},
+		{"Custom limits", PoolOptions{MaxOpenConns: 5, MaxIdleConns: 1}, 5, 1},
+		{"Idle capped at open", PoolOptions{MaxOpenConns: 2, MaxIdleConns: 10}, 2, 2},
+		{"No idle connections", PoolOptions{MaxIdleConns: -1}, defaultMaxOpenConns, 0},
+		{"Unlimited open connections", PoolOptions{MaxOpenConns: -1}, 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := setupFileDB(t)
+			sqlDB, err := db.DB()
+			if err != nil {
+				t.Fatalf("Failed to get connection pool: %v", err)
+			}
+
+			// Act
+			err = ConfigurePool(db, tt.opts)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if stats := sqlDB.Stats(); stats.MaxOpenConnections != tt.expectedMaxOpen {
+				t.Errorf("Expected MaxOpenConnections %d, got %d", tt.expectedMaxOpen, stats.MaxOpenConnections)
+			}
+			releaseConns(t, sqlDB, 3)
+			if stats := sqlDB.Stats(); stats.Idle != tt.expectedIdle {
+				t.Errorf("Expected %d idle connections, got %d", tt.expectedIdle, stats.Idle)
+			}
+		})
+	}
+}
+
+// releaseConns checks out up to n connections at once and returns them to the pool
+func releaseConns(t *testing.T, sqlDB *sql.DB, n int) {
+	t.Helper()
+
+	var conns []*sql.Conn
+	for i := 0; i < n; i++ {
+		if limit := sqlDB.Stats().MaxOpenConnections; limit > 0 && len(conns) >= limit {
+			break
+		}
+		conn, err := sqlDB.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to open connection: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+}
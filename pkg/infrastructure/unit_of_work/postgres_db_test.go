@@ -0,0 +1,28 @@
+//go:build postgres || pgvector
+
+package unit_of_work
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openPostgresDB connects to the Postgres database whose DSN is in the environment variable
+// dsnVar, and skips the test when it is not set
+func openPostgresDB(t *testing.T, dsnVar string) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(dsnVar)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnVar)
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to connect to Postgres: %v", err)
+	}
+	return db
+}
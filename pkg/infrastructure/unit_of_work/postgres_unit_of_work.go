@@ -2,14 +2,21 @@ package unit_of_work
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PostgresUnitOfWork provides a GORM-based implementation of IUnitOfWork for PostgreSQL.
@@ -19,22 +26,134 @@ type PostgresUnitOfWork[T types.IBaseModel] struct {
 	db            *gorm.DB
 	filterApplier *FilterApplier
 	tx            *gorm.DB // Current transaction, nil if not in transaction
+	config        postgresConfig
+
+	lockMu        sync.Mutex              // Guards advisoryLocks
+	advisoryLocks map[int64]*advisoryLock // Connections holding advisory locks, by key
 }
 
 // NewPostgresUnitOfWork creates a new PostgreSQL UnitOfWork instance
-func NewPostgresUnitOfWork[T types.IBaseModel](db *gorm.DB) unit_of_work.IUnitOfWork[T] {
+func NewPostgresUnitOfWork[T types.IBaseModel](db *gorm.DB, opts ...PostgresOption) unit_of_work.IUnitOfWork[T] {
+	cfg := newPostgresConfig(opts...)
+	filterApplier := NewFilterApplier()
+	if cfg.namingStrategy != nil {
+		filterApplier.WithNamingStrategy(cfg.namingStrategy)
+	}
+	filterApplier.WithErrorOnEmptyIn(cfg.errorOnEmptyIn)
+	filterApplier.WithUTCTimes(cfg.utcTimestamps)
+	filterApplier.WithDateStringParsing(cfg.parseDateStrings)
+	filterApplier.WithSoftDeleteStrategy(cfg.softDeleteStrategy)
+	filterApplier.WithFilterTransformers(cfg.filterTransformers...)
+	filterApplier.WithSortExpressions(cfg.sortExpressions)
+	filterApplier.WithDefaultSort(cfg.defaultSort...)
+	if cfg.numericSearchFields != nil {
+		filterApplier.WithNumericSearchFields(cfg.numericSearchFields...)
+	}
+
+	if cfg.readReplica != nil {
+		cfg.readReplica = configureSession(cfg.readReplica, cfg)
+	}
+
 	return &PostgresUnitOfWork[T]{
-		db:            db,
-		filterApplier: NewFilterApplier(),
+		db:            configureSession(db, cfg),
+		filterApplier: filterApplier,
+		config:        cfg,
 	}
 }
 
+// configureSession applies the plugin, timestamp, logging and SQL capture options to a
+// connection. A plugin that fails to initialize fails every statement of the session with
+// its error; a plugin already registered under the same name is left as is.
+func configureSession(db *gorm.DB, cfg postgresConfig) *gorm.DB {
+	for _, plugin := range cfg.plugins {
+		if err := db.Use(plugin); err != nil && !errors.Is(err, gorm.ErrRegistered) {
+			db = db.Session(&gorm.Session{})
+			_ = db.AddError(fmt.Errorf("failed to initialize GORM plugin %s: %w", plugin.Name(), err))
+		}
+	}
+	if cfg.utcTimestamps {
+		db = db.Session(&gorm.Session{NowFunc: func() time.Time { return time.Now().UTC() }})
+	}
+	if cfg.logger != nil || cfg.logLevel != 0 || cfg.sqlCapture != nil {
+		l := cfg.logger
+		if l == nil {
+			l = db.Logger
+		}
+		if cfg.logLevel != 0 {
+			l = l.LogMode(cfg.logLevel)
+		}
+		if cfg.sqlCapture != nil {
+			l = newSQLCaptureLogger(l, cfg.sqlCapture)
+		}
+		db = db.Session(&gorm.Session{Logger: l})
+	}
+	return db
+}
+
 // getDB returns the current database connection (transaction if active, otherwise main db)
+// with the configured scopes and the entity's default scope attached
 func (uow *PostgresUnitOfWork[T]) getDB() *gorm.DB {
 	if uow.tx != nil {
-		return uow.tx
+		return uow.scoped(uow.tx)
 	}
-	return uow.db
+	return uow.scoped(uow.db)
+}
+
+// readDB returns the read replica for reads outside a transaction when one is configured
+// and forcePrimary is false, and getDB otherwise
+func (uow *PostgresUnitOfWork[T]) readDB(forcePrimary bool) *gorm.DB {
+	if uow.tx != nil || uow.config.readReplica == nil || forcePrimary {
+		return uow.getDB()
+	}
+	return uow.scoped(uow.config.readReplica)
+}
+
+// scoped attaches the configured scopes and the entity's default scope to db
+func (uow *PostgresUnitOfWork[T]) scoped(db *gorm.DB) *gorm.DB {
+	scopes := uow.config.scopes
+	if defaultScope := uow.defaultScope(); defaultScope != nil {
+		scopes = append(scopes[:len(scopes):len(scopes)], defaultScope)
+	}
+	if len(scopes) > 0 {
+		// A fresh session keeps the scoped statement safe to reuse across query chains
+		db = db.Scopes(scopes...).Session(&gorm.Session{})
+	}
+	return db
+}
+
+// QueryBuilder returns the *gorm.DB that FindAllWithPagination would execute, with filters,
+// search, soft-delete visibility, sorting and preloads applied but without pagination.
+// Nothing is executed, so callers can add joins or custom clauses and run it themselves.
+// The returned query shares the active transaction when one is in progress.
+func (uow *PostgresUnitOfWork[T]) QueryBuilder(ctx context.Context, params *query.QueryParams[T]) *gorm.DB {
+	db := uow.getDB().WithContext(ctx)
+	return uow.filterApplier.ApplyQueryParams(db.Model(new(T)), params)
+}
+
+// insertDB returns the connection used for inserts, requesting all columns back when configured
+func (uow *PostgresUnitOfWork[T]) insertDB(ctx context.Context) *gorm.DB {
+	db := uow.getDB().WithContext(ctx)
+	if uow.config.insertReturning {
+		db = db.Clauses(clause.Returning{})
+	}
+	return db
+}
+
+// returningClause builds a RETURNING clause for the given columns, or for every column when none are given
+func returningClause(columns []string) (clause.Returning, error) {
+	returning := clause.Returning{}
+	for _, column := range columns {
+		if err := ValidateFieldName(column); err != nil {
+			return clause.Returning{}, err
+		}
+		returning.Columns = append(returning.Columns, clause.Column{Name: column})
+	}
+	return returning, nil
+}
+
+// identifierQuery builds a model-scoped query from an identifier using this unit of work's filter applier
+func (uow *PostgresUnitOfWork[T]) identifierQuery(db *gorm.DB, identifier identifier.IIdentifier) *gorm.DB {
+	return uow.filterApplier.ApplyIdentifier(db.Model(new(T)), identifier)
 }
 
 // Transaction management
@@ -42,7 +161,7 @@ func (uow *PostgresUnitOfWork[T]) getDB() *gorm.DB {
 // BeginTransaction starts a new database transaction
 func (uow *PostgresUnitOfWork[T]) BeginTransaction(ctx context.Context) error {
 	if uow.tx != nil {
-		return fmt.Errorf("transaction already in progress")
+		return unit_of_work.ErrTransactionInProgress
 	}
 
 	tx := uow.db.WithContext(ctx).Begin()
@@ -57,7 +176,7 @@ func (uow *PostgresUnitOfWork[T]) BeginTransaction(ctx context.Context) error {
 // CommitTransaction commits the current transaction
 func (uow *PostgresUnitOfWork[T]) CommitTransaction(ctx context.Context) error {
 	if uow.tx == nil {
-		return fmt.Errorf("no active transaction to commit")
+		return fmt.Errorf("%w to commit", unit_of_work.ErrNoActiveTransaction)
 	}
 
 	err := uow.tx.Commit().Error
@@ -65,32 +184,129 @@ func (uow *PostgresUnitOfWork[T]) CommitTransaction(ctx context.Context) error {
 	return err
 }
 
-// RollbackTransaction rolls back the current transaction
+// RollbackTransaction rolls back the current transaction.
+// A failed rollback is reported through the configured GORM logger;
+// use RollbackTransactionE to handle the error directly.
 func (uow *PostgresUnitOfWork[T]) RollbackTransaction(ctx context.Context) {
-	if uow.tx != nil {
-		uow.tx.Rollback()
-		uow.tx = nil
+	if err := uow.RollbackTransactionE(ctx); err != nil && uow.db.Logger != nil {
+		uow.db.Logger.Error(ctx, "rollback transaction failed: %v", err)
 	}
 }
 
+// RollbackTransactionE rolls back the current transaction and returns the rollback error.
+// It is a no-op returning nil when no transaction is active.
+func (uow *PostgresUnitOfWork[T]) RollbackTransactionE(ctx context.Context) error {
+	if uow.tx == nil {
+		return nil
+	}
+
+	err := uow.tx.Rollback().Error
+	uow.tx = nil
+	return err
+}
+
+// RunInTransaction runs fn inside a transaction, committing when it returns nil and rolling
+// back otherwise. When the body or the commit fails with a transient error (serialization
+// failure, deadlock) the whole transaction is retried after a short backoff, up to the
+// configured attempt count; the last error is returned if ctx ends while waiting.
+// Operations inside fn must go through this unit of work to share the transaction.
+func (uow *PostgresUnitOfWork[T]) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := uow.runTransactionAttempt(ctx, fn)
+		if err == nil {
+			return nil
+		}
+		if attempt >= uow.config.transactionAttempts || !IsTransientTransactionError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(transactionRetryBackoff(uow.config.transactionRetryBackoff, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// transactionRetryBackoff returns the pause after the given failed attempt, doubling base
+// for each earlier retry and capping it at maxTransactionRetryBackoff
+func transactionRetryBackoff(base time.Duration, attempt int) time.Duration {
+	backoff := base
+	for i := 1; i < attempt && backoff < maxTransactionRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxTransactionRetryBackoff)
+}
+
+// runTransactionAttempt executes a single transaction attempt, rolling back on error or panic
+func (uow *PostgresUnitOfWork[T]) runTransactionAttempt(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if err := uow.BeginTransaction(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			uow.RollbackTransaction(ctx)
+			panic(r)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		uow.RollbackTransaction(ctx)
+		return err
+	}
+	return uow.CommitTransaction(ctx)
+}
+
+// InTransaction reports whether a transaction is in progress
+func (uow *PostgresUnitOfWork[T]) InTransaction() bool {
+	return uow.tx != nil
+}
+
+// Ping checks that the database answers by running SELECT 1, honoring ctx cancellation.
+// Inside a transaction the check runs on the transaction's connection.
+func (uow *PostgresUnitOfWork[T]) Ping(ctx context.Context) error {
+	return uow.getDB().WithContext(ctx).Exec("SELECT 1").Error
+}
+
+// Dialect returns the name of the GORM dialector the unit of work was created with
+func (uow *PostgresUnitOfWork[T]) Dialect() string {
+	return uow.db.Dialector.Name()
+}
+
 // Basic queries
 
-// FindAll retrieves all entities (excluding soft-deleted by default)
+// FindAll retrieves all entities, excluding soft-deleted ones.
+// The deleted_at filter is applied explicitly, the same way FindAllWithPagination does,
+// so both methods agree on visibility regardless of how the entity declares DeletedAt.
 func (uow *PostgresUnitOfWork[T]) FindAll(ctx context.Context) ([]T, error) {
 	var entities []T
 	db := uow.getDB()
-	if err := db.WithContext(ctx).Find(&entities).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		query := uow.filterApplier.ApplyDeletedVisibility(uow.identifierQuery(db, nil), false, false)
+		return query.WithContext(ctx).Find(&entities).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	return entities, nil
 }
 
-// FindAllWithPagination retrieves entities with pagination support and returns total count
+// FindAllWithPagination retrieves entities with pagination support and returns total count.
+// With CountOnlyFirstPage set, the count is skipped after the first page and total is -1.
+// With Locking set, the returned rows (not the count) are locked until the transaction ends.
 func (uow *PostgresUnitOfWork[T]) FindAllWithPagination(ctx context.Context, query *query.QueryParams[T]) ([]T, int64, error) {
-	db := uow.getDB()
+	locks, err := uow.lockingClauses(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	db := uow.readDB(query.ForcePrimary)
 
 	// Start with base query
-	baseQuery := db.Model(new(T))
+	baseQuery := uow.paramsQuery(db, query)
 
 	// Apply QueryParams filters, sorting, etc.
 	filteredQuery := uow.filterApplier.ApplyQueryParams(baseQuery, query)
@@ -102,27 +318,52 @@ func (uow *PostgresUnitOfWork[T]) FindAllWithPagination(ctx context.Context, que
 		limit = 50 // Default limit
 	}
 
-	// Count total records first
-	var total int64
-	countQuery := filteredQuery.Session(&gorm.Session{NewDB: true})
-	if err := countQuery.WithContext(ctx).Model(new(T)).Count(&total).Error; err != nil {
-		return nil, 0, err
+	// Count total records first, on the same table as the page but without sorting or preloads
+	var total int64 = -1
+	if !query.CountOnlyFirstPage || query.Page <= 1 {
+		countQuery := uow.filterApplier.ApplyQueryConditions(uow.paramsQuery(db, query), query)
+		err := uow.withReadRetry(ctx, func() error {
+			return countQuery.WithContext(ctx).Count(&total).Error
+		})
+		if err != nil {
+			return nil, 0, err
+		}
 	}
 
 	// Get paginated results
 	var entities []T
-	if err := filteredQuery.WithContext(ctx).Offset(offset).Limit(limit).Find(&entities).Error; err != nil {
+	err = uow.withReadRetry(ctx, func() error {
+		entities = nil
+		return filteredQuery.WithContext(ctx).Clauses(locks...).Offset(offset).Limit(limit).Find(&entities).Error
+	})
+	if err != nil {
 		return nil, 0, err
 	}
 
 	return entities, total, nil
 }
 
-// FindOne retrieves a single entity matching the provided filter
+// FindOne retrieves the first live entity matching the non-zero fields of filter.
+// Soft-deleted rows are excluded explicitly, as in FindAll, so entities that do not embed
+// gorm.DeletedAt behave the same way.
 func (uow *PostgresUnitOfWork[T]) FindOne(ctx context.Context, filter T) (T, error) {
+	return uow.findOne(ctx, filter, false)
+}
+
+// FindOneIncludingTrashed behaves like FindOne but also matches soft-deleted entities
+func (uow *PostgresUnitOfWork[T]) FindOneIncludingTrashed(ctx context.Context, filter T) (T, error) {
+	return uow.findOne(ctx, filter, true)
+}
+
+// findOne retrieves the first entity matching filter, with or without soft-deleted rows
+func (uow *PostgresUnitOfWork[T]) findOne(ctx context.Context, filter T, includeDeleted bool) (T, error) {
 	var entity T
 	db := uow.getDB()
-	if err := db.WithContext(ctx).Where(filter).First(&entity).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		query := uow.identifierQuery(db, nil).WithContext(ctx).Where(filter)
+		return uow.filterApplier.ApplyDeletedVisibility(query, includeDeleted, false).First(&entity).Error
+	})
+	if err != nil {
 		var zero T
 		return zero, err
 	}
@@ -133,19 +374,94 @@ func (uow *PostgresUnitOfWork[T]) FindOne(ctx context.Context, filter T) (T, err
 func (uow *PostgresUnitOfWork[T]) FindOneById(ctx context.Context, id int) (T, error) {
 	var entity T
 	db := uow.getDB()
-	if err := db.WithContext(ctx).First(&entity, id).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		return uow.excludeDeleted(uow.identifierQuery(db, nil).WithContext(ctx)).First(&entity, id).Error
+	})
+	if err != nil {
 		var zero T
 		return zero, err
 	}
 	return entity, nil
 }
 
+// FindByIDs retrieves the live entities whose IDs are in ids with a single query, ordered by ID
+// ascending. Missing or soft-deleted IDs are skipped, so the result may be shorter than ids.
+func (uow *PostgresUnitOfWork[T]) FindByIDs(ctx context.Context, ids []int) ([]T, error) {
+	if len(ids) == 0 {
+		return []T{}, nil
+	}
+
+	var entities []T
+	db := uow.getDB()
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		query := uow.filterApplier.ApplyDeletedVisibility(uow.identifierQuery(db, nil), false, false)
+		return query.WithContext(ctx).Where("id IN ?", ids).Order("id ASC").Find(&entities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// FindChangedSince retrieves the entities whose updated_at is at or after since, ordered by
+// updated_at and then ID so consumers can resume from the last change they processed.
+// Filters, search and soft-delete visibility from params apply; its sorting and pagination
+// are ignored. When params includes trashed rows and the timestamp soft-delete strategy is
+// used, rows deleted at or after since are returned too, carrying their deleted_at, since
+// that strategy soft-deletes without touching updated_at. It reads from the replica
+// configured with WithReadReplica unless params force the primary.
+func (uow *PostgresUnitOfWork[T]) FindChangedSince(ctx context.Context, since time.Time, params *query.QueryParams[T]) ([]T, error) {
+	changed := clause.Expr{SQL: "updated_at >= ?", Vars: []interface{}{since}}
+	if params != nil && (params.IncludeDeleted || params.OnlyDeleted) && uow.config.softDeleteStrategy == SoftDeleteTimestamp {
+		changed = clause.Expr{SQL: "(updated_at >= ? OR deleted_at >= ?)", Vars: []interface{}{since, since}}
+	}
+
+	var entities []T
+	db := uow.readDB(params != nil && params.ForcePrimary)
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		filtered := uow.filterApplier.ApplyQueryConditions(db.WithContext(ctx).Model(new(T)), params)
+		return filtered.Where(changed).Order("updated_at ASC").Order("id ASC").Find(&entities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// FindTop retrieves at most n live entities matching the identifier, ordered by sort, for
+// leaderboard-style reads such as the ten most recent entries. Unlike FindAllWithPagination
+// it runs no COUNT. Without sort fields the default sort applies. A nil identifier matches
+// every entity; n must be positive. It always reads from the primary.
+func (uow *PostgresUnitOfWork[T]) FindTop(ctx context.Context, n int, sort []query.SortField, identifier identifier.IIdentifier) ([]T, error) {
+	if n <= 0 {
+		return nil, domainerrors.NewValidationError("n", "must be positive")
+	}
+
+	var entities []T
+	db := uow.getDB()
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+		query = uow.filterApplier.applySortsOrDefault(query, sort)
+		return query.WithContext(ctx).Limit(n).Find(&entities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
 // FindOneByIdentifier retrieves a single entity using the IIdentifier filter system
 func (uow *PostgresUnitOfWork[T]) FindOneByIdentifier(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
 	var entity T
 	db := uow.getDB()
-	query := BuildQueryFromIdentifier[T](db, identifier)
-	if err := query.WithContext(ctx).First(&entity).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+		return query.WithContext(ctx).First(&entity).Error
+	})
+	if err != nil {
 		var zero T
 		return zero, err
 	}
@@ -156,8 +472,8 @@ func (uow *PostgresUnitOfWork[T]) FindOneByIdentifier(ctx context.Context, ident
 
 // Insert creates a new entity and returns the created entity with populated fields
 func (uow *PostgresUnitOfWork[T]) Insert(ctx context.Context, entity T) (T, error) {
-	db := uow.getDB()
-	if err := db.WithContext(ctx).Create(entity).Error; err != nil {
+	db := uow.insertDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
 		var zero T
 		return zero, err
 	}
@@ -166,32 +482,100 @@ func (uow *PostgresUnitOfWork[T]) Insert(ctx context.Context, entity T) (T, erro
 
 // Update modifies entities matching the identifier with the provided entity data
 func (uow *PostgresUnitOfWork[T]) Update(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	updated, _, err := uow.UpdateE(ctx, identifier, entity)
+	return updated, err
+}
+
+// UpdateE modifies entities matching the identifier and also returns the number of rows
+// affected by the save, for optimistic-lock and idempotency checks.
+// It returns gorm.ErrRecordNotFound when the identifier matches no entity.
+func (uow *PostgresUnitOfWork[T]) UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error) {
+	if uow.config.skipUpdatePreCheck {
+		return uow.updateWithoutPreCheck(ctx, identifier, entity)
+	}
+
 	// First verify the entity exists
 	_, err := uow.FindOneByIdentifier(ctx, identifier)
 	if err != nil {
 		var zero T
-		return zero, err
+		return zero, 0, err
 	}
 
 	// Update the entity (this preserves the ID and other fields)
 	db := uow.getDB()
-	if err := db.WithContext(ctx).Save(entity).Error; err != nil {
+	result := db.WithContext(ctx).Save(entity)
+	if result.Error != nil {
+		var zero T
+		return zero, 0, result.Error
+	}
+	return entity, result.RowsAffected, nil
+}
+
+// updateWithoutPreCheck writes every field of the entity in one statement matching both its
+// primary key and the identifier, reporting a missing row through rows affected with
+// gorm.ErrRecordNotFound, as the pre-checked path does
+func (uow *PostgresUnitOfWork[T]) updateWithoutPreCheck(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, int64, error) {
+	db := uow.getDB()
+	query := uow.excludeDeleted(uow.filterApplier.ApplyIdentifier(db.WithContext(ctx).Model(entity), identifier))
+	result := query.Select("*").Updates(entity)
+	if result.Error != nil {
+		var zero T
+		return zero, 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		var zero T
+		return zero, 0, gorm.ErrRecordNotFound
+	}
+	return entity, result.RowsAffected, nil
+}
+
+// UpdateIncludingTrashed modifies an entity matching the identifier even when it is soft-deleted.
+// The soft-delete marker is not written, so a trashed entity stays in the trash; use Restore to recover it.
+func (uow *PostgresUnitOfWork[T]) UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	db := uow.getDB()
+
+	// First verify the entity exists, including soft-deleted rows
+	var existing T
+	if err := uow.identifierQuery(db, identifier).Unscoped().WithContext(ctx).First(&existing).Error; err != nil {
+		var zero T
+		return zero, err
+	}
+
+	if err := db.WithContext(ctx).Unscoped().Omit(uow.filterApplier.softDeleteColumn()).Save(entity).Error; err != nil {
 		var zero T
 		return zero, err
 	}
 	return entity, nil
 }
 
-// Delete performs a logical operation (soft-delete by default)
+// Save inserts the entity when GetID() is 0 and otherwise updates the live entity with that ID,
+// within the active transaction if there is one. Updating an ID that does not exist (or is
+// soft-deleted) fails with gorm.ErrRecordNotFound rather than inserting it.
+func (uow *PostgresUnitOfWork[T]) Save(ctx context.Context, entity T) (T, error) {
+	if entity.GetID() == 0 {
+		return uow.Insert(ctx, entity)
+	}
+	return uow.Update(ctx, identifier.NewIdentifier().Equal("id", entity.GetID()), entity)
+}
+
+// Delete performs a logical operation (soft-delete by default). Rows already in the trash are
+// left untouched, so deleting them again does not refresh their deletion marker or updated_at.
 func (uow *PostgresUnitOfWork[T]) Delete(ctx context.Context, identifier identifier.IIdentifier) error {
+	_, err := uow.DeleteE(ctx, identifier)
+	return err
+}
+
+// DeleteE behaves like Delete but returns the number of rows deleted. Rows that were already
+// soft-deleted do not count, so zero means nothing live matched the identifier.
+func (uow *PostgresUnitOfWork[T]) DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
 	db := uow.getDB()
-	query := BuildQueryFromIdentifier[T](db, identifier)
-	return query.WithContext(ctx).Delete(new(T)).Error
+	query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+	return uow.markDeleted(query.WithContext(ctx))
 }
 
 // Soft-delete lifecycle management
 
-// SoftDelete performs soft deletion by setting DeletedAt timestamp
+// SoftDelete performs soft deletion by setting the configured soft-delete marker
 func (uow *PostgresUnitOfWork[T]) SoftDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
 	// First find the entity
 	entity, err := uow.FindOneByIdentifier(ctx, identifier)
@@ -202,8 +586,8 @@ func (uow *PostgresUnitOfWork[T]) SoftDelete(ctx context.Context, identifier ide
 
 	// Perform soft delete
 	db := uow.getDB()
-	query := BuildQueryFromIdentifier[T](db, identifier)
-	if err := query.WithContext(ctx).Delete(new(T)).Error; err != nil {
+	query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+	if _, err := uow.markDeleted(query.WithContext(ctx)); err != nil {
 		var zero T
 		return zero, err
 	}
@@ -211,11 +595,76 @@ func (uow *PostgresUnitOfWork[T]) SoftDelete(ctx context.Context, identifier ide
 	return entity, nil
 }
 
-// HardDelete permanently removes entities from the database
+// SoftDeleteWithNote soft-deletes the entity and stores note in its audit note within the same
+// UPDATE statement. The note is only written when the entity supports audit notes (for example
+// by embedding types.AuditableEntity); otherwise it behaves like SoftDelete.
+func (uow *PostgresUnitOfWork[T]) SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (T, error) {
+	entity, err := uow.FindOneByIdentifier(ctx, identifier)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	auditable, ok := any(entity).(auditNoteSetter)
+	if !ok {
+		return uow.SoftDelete(ctx, identifier)
+	}
+
+	db := uow.getDB()
+	query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+	if err := uow.markDeletedWithColumns(query.WithContext(ctx), map[string]interface{}{auditNoteColumn: note}); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	auditable.SetAuditNote(note)
+	return entity, nil
+}
+
+// Replace soft-deletes the live entities matching the identifier and inserts entity in their
+// place, for flows such as replacing the active configuration. Both happen atomically: within
+// the active transaction, or in a transaction of their own when none is active. Matching no
+// entity is not an error, the entity is then simply inserted. An empty identifier is rejected
+// since it would trash every live entity.
+func (uow *PostgresUnitOfWork[T]) Replace(ctx context.Context, identifier identifier.IIdentifier, entity T) (T, error) {
+	if identifier == nil || len(identifier.ToFilterCriteria()) == 0 {
+		var zero T
+		return zero, domainerrors.NewValidationError("identifier", "replace requires an identifier selecting the entities to trash")
+	}
+
+	replace := func(ctx context.Context) error {
+		query := uow.excludeDeleted(uow.identifierQuery(uow.getDB(), identifier))
+		if _, err := uow.markDeleted(query.WithContext(ctx)); err != nil {
+			return err
+		}
+		_, err := uow.Insert(ctx, entity)
+		return err
+	}
+
+	var err error
+	if uow.tx != nil {
+		err = replace(ctx)
+	} else {
+		err = uow.RunInTransaction(ctx, replace)
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return entity, nil
+}
+
+// HardDelete permanently removes entities from the database. An identifier without criteria
+// fails with ErrUnboundedDelete unless WithAllowFullTableDelete is set.
 func (uow *PostgresUnitOfWork[T]) HardDelete(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
+	db, err := uow.deleteDB(identifier == nil || len(identifier.ToFilterCriteria()) == 0)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
 	// First find the entity (including soft-deleted ones)
-	db := uow.getDB()
-	query := BuildQueryFromIdentifier[T](db, identifier).Unscoped()
+	query := uow.identifierQuery(db, identifier).Unscoped()
 	var entity T
 	if err := query.WithContext(ctx).First(&entity).Error; err != nil {
 		var zero T
@@ -235,7 +684,28 @@ func (uow *PostgresUnitOfWork[T]) HardDelete(ctx context.Context, identifier ide
 func (uow *PostgresUnitOfWork[T]) GetTrashed(ctx context.Context) ([]T, error) {
 	db := uow.getDB()
 	var entities []T
-	if err := db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Find(&entities).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		query := uow.filterApplier.ApplyDeletedVisibility(uow.identifierQuery(db, nil), false, true)
+		return query.WithContext(ctx).Find(&entities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// GetTrashedByIdentifier retrieves soft-deleted entities matching the identifier, most recently deleted first
+func (uow *PostgresUnitOfWork[T]) GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]T, error) {
+	db := uow.getDB()
+	var entities []T
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		query := uow.identifierQuery(db, identifier)
+		query = uow.filterApplier.ApplyDeletedVisibility(query, false, true)
+		return query.WithContext(ctx).Order(uow.trashOrder()).Find(&entities).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	return entities, nil
@@ -251,27 +721,31 @@ func (uow *PostgresUnitOfWork[T]) GetTrashedWithPagination(ctx context.Context,
 	return uow.FindAllWithPagination(ctx, params)
 }
 
-// Restore recovers soft-deleted entities by clearing their DeletedAt timestamp
+// Restore recovers a single soft-deleted entity by clearing its soft-delete marker. When the
+// identifier matches several trashed entities, the most recently deleted one is restored and
+// the others stay trashed; use RestoreAllMatching to restore all of them.
 func (uow *PostgresUnitOfWork[T]) Restore(ctx context.Context, identifier identifier.IIdentifier) (T, error) {
 	db := uow.getDB()
-	query := BuildQueryFromIdentifier[T](db, identifier).Unscoped()
+	query := uow.identifierQuery(db, identifier).Unscoped()
 
-	// First find the soft-deleted entity
+	// First find the most recently deleted matching entity
 	var entity T
-	if err := query.WithContext(ctx).Where("deleted_at IS NOT NULL").First(&entity).Error; err != nil {
+	trashed := uow.filterApplier.ApplyDeletedVisibility(query.WithContext(ctx), false, true)
+	if err := trashed.Order(uow.trashOrder()).Order("id DESC").First(&entity).Error; err != nil {
 		var zero T
 		return zero, err
 	}
 
-	// Restore the entity by setting deleted_at to NULL
-	if err := query.WithContext(ctx).Update("deleted_at", nil).Error; err != nil {
+	// Restore the entity by clearing its soft-delete marker
+	restore := uow.identifierQuery(db, nil).Unscoped().WithContext(ctx).Where("id = ?", entity.GetID())
+	if _, err := uow.markRestored(restore); err != nil {
 		var zero T
 		return zero, err
 	}
 
 	// Return the restored entity by finding it again
 	var restoredEntity T
-	if err := db.WithContext(ctx).First(&restoredEntity, uint(entity.GetID())).Error; err != nil {
+	if err := db.WithContext(ctx).First(&restoredEntity, entity.GetID()).Error; err != nil {
 		var zero T
 		return zero, err
 	}
@@ -282,19 +756,36 @@ func (uow *PostgresUnitOfWork[T]) Restore(ctx context.Context, identifier identi
 // RestoreAll recovers all soft-deleted entities of type T
 func (uow *PostgresUnitOfWork[T]) RestoreAll(ctx context.Context) error {
 	db := uow.getDB()
-	return db.WithContext(ctx).Model(new(T)).Unscoped().Where("deleted_at IS NOT NULL").Update("deleted_at", nil).Error
+	query := uow.filterApplier.ApplyDeletedVisibility(uow.identifierQuery(db, nil).WithContext(ctx), false, true)
+	_, err := uow.markRestored(query)
+	return err
+}
+
+// RestoreAllMatching recovers every soft-deleted entity matching the identifier and returns
+// how many were restored; matching no trashed entity is not an error
+func (uow *PostgresUnitOfWork[T]) RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	db := uow.getDB()
+	query := uow.identifierQuery(db, identifier).Unscoped().WithContext(ctx)
+	return uow.markRestored(uow.filterApplier.ApplyDeletedVisibility(query, false, true))
 }
 
 // Bulk operations
 
-// BulkInsert creates multiple entities in a single operation
+// BulkInsert creates multiple entities in a single operation, or in batches when
+// WithBulkInsertBatchSize is configured
 func (uow *PostgresUnitOfWork[T]) BulkInsert(ctx context.Context, entities []T) ([]T, error) {
 	if len(entities) == 0 {
 		return entities, nil
 	}
 
-	db := uow.getDB()
-	if err := db.WithContext(ctx).Create(&entities).Error; err != nil {
+	db := uow.insertDB(ctx)
+	if size := uow.config.bulkInsertBatchSize; size > 0 {
+		if err := db.CreateInBatches(&entities, size).Error; err != nil {
+			return nil, err
+		}
+		return entities, nil
+	}
+	if err := db.Create(&entities).Error; err != nil {
 		return nil, err
 	}
 
@@ -308,6 +799,13 @@ func (uow *PostgresUnitOfWork[T]) BulkUpdate(ctx context.Context, entities []T)
 	}
 
 	db := uow.getDB()
+	if uow.config.updateReturning {
+		returning, err := returningClause(uow.config.updateReturningColumns)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Clauses(returning)
+	}
 
 	// GORM doesn't have a direct bulk update, so we update each entity
 	// In a transaction, this is still efficient
@@ -321,40 +819,163 @@ func (uow *PostgresUnitOfWork[T]) BulkUpdate(ctx context.Context, entities []T)
 	return entities, nil
 }
 
-// BulkSoftDelete soft-deletes multiple entities identified by the provided identifiers
+// BulkSoftDelete soft-deletes multiple entities identified by the provided identifiers.
+// As with Delete, rows already in the trash are left untouched.
 func (uow *PostgresUnitOfWork[T]) BulkSoftDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
+	_, err := uow.BulkSoftDeleteE(ctx, identifiers)
+	return err
+}
+
+// BulkSoftDeleteE soft-deletes multiple entities and returns how many rows were moved to the
+// trash across all identifiers. Rows that were already soft-deleted are not counted, so a row
+// matched by several identifiers is counted once. On error, the count covers the identifiers
+// processed before the failure.
+func (uow *PostgresUnitOfWork[T]) BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error) {
 	if len(identifiers) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	db := uow.getDB()
 
-	for _, identifier := range identifiers {
-		query := BuildQueryFromIdentifier[T](db, identifier)
-		if err := query.WithContext(ctx).Delete(new(T)).Error; err != nil {
-			return err
+	var affected int64
+	for _, identifier := range coalesceIDIdentifiers(identifiers) {
+		query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+		rows, err := uow.markDeleted(query.WithContext(ctx))
+		affected += rows
+		if err != nil {
+			return affected, err
 		}
 	}
 
-	return nil
+	return affected, nil
+}
+
+// SoftDeleteWhere soft-deletes every live entity matching the filters, relation filters and raw
+// conditions of params in a single statement and returns the number of rows moved to the
+// trash, so matches need not be enumerated as identifiers first. Like PruneWhere, params
+// without any condition fail with ErrUnboundedDelete unless WithAllowFullTableDelete is set.
+func (uow *PostgresUnitOfWork[T]) SoftDeleteWhere(ctx context.Context, params *query.QueryParams[T]) (int64, error) {
+	return uow.markDeleted(uow.excludeDeleted(uow.whereQuery(ctx, params)))
+}
+
+// bulkDeleteChunkSize bounds the IDs bound in one coalesced bulk delete statement, well below
+// the PostgreSQL limit of 65535 parameters
+const bulkDeleteChunkSize = 1000
+
+// coalesceIDIdentifiers replaces identifiers that are all a single "id = value" equality with
+// "id IN (...)" identifiers of at most bulkDeleteChunkSize values, so bulk deletes by ID take
+// one statement per chunk instead of one per identifier. Any other mix is returned unchanged.
+func coalesceIDIdentifiers(identifiers []identifier.IIdentifier) []identifier.IIdentifier {
+	ids := make([]interface{}, 0, len(identifiers))
+	for _, ident := range identifiers {
+		if ident == nil {
+			return identifiers
+		}
+		criteria := ident.ToFilterCriteria()
+		if len(criteria) != 1 || len(criteria[0].Group) > 0 || criteria[0].Operator != identifier.FilterOperatorEqual ||
+			!strings.EqualFold(criteria[0].Field, "id") || criteria[0].Value == nil {
+			return identifiers
+		}
+		ids = append(ids, criteria[0].Value)
+	}
+
+	coalesced := make([]identifier.IIdentifier, 0, len(ids)/bulkDeleteChunkSize+1)
+	for start := 0; start < len(ids); start += bulkDeleteChunkSize {
+		end := min(start+bulkDeleteChunkSize, len(ids))
+		coalesced = append(coalesced, identifier.NewIdentifier().In("id", ids[start:end]))
+	}
+	return coalesced
 }
 
-// BulkHardDelete permanently removes multiple entities identified by the provided identifiers
+// BulkHardDelete permanently removes multiple entities identified by the provided identifiers.
+// The deletes run in one transaction, the active one if any, so a failing identifier leaves
+// every row in place.
 func (uow *PostgresUnitOfWork[T]) BulkHardDelete(ctx context.Context, identifiers []identifier.IIdentifier) error {
 	if len(identifiers) == 0 {
 		return nil
 	}
 
+	deleteAll := func(ctx context.Context) error {
+		for _, identifier := range coalesceIDIdentifiers(identifiers) {
+			db, err := uow.deleteDB(identifier == nil || len(identifier.ToFilterCriteria()) == 0)
+			if err != nil {
+				return err
+			}
+			query := uow.identifierQuery(db, identifier).Unscoped()
+			if err := query.WithContext(ctx).Delete(new(T)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if uow.tx != nil {
+		return deleteAll(ctx)
+	}
+	return uow.RunInTransaction(ctx, deleteAll)
+}
+
+// PruneWhere permanently removes every entity matching the filters, relation filters and raw
+// conditions of params, including soft-deleted ones, and returns the number of deleted rows.
+// It runs a single DELETE statement, so no IDs are loaded. Params without any condition fail
+// with ErrUnboundedDelete rather than wiping the table, unless WithAllowFullTableDelete is set.
+func (uow *PostgresUnitOfWork[T]) PruneWhere(ctx context.Context, params *query.QueryParams[T]) (int64, error) {
+	result := uow.whereQuery(ctx, params).Unscoped().Delete(new(T))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// PruneWhereReturning behaves like PruneWhere but returns the deleted rows, as captured by
+// RETURNING in the same DELETE statement, for audit logs. Only the given columns are
+// populated when any are listed; otherwise every column is returned.
+func (uow *PostgresUnitOfWork[T]) PruneWhereReturning(ctx context.Context, params *query.QueryParams[T], columns ...string) ([]T, error) {
+	returning, err := returningClause(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var entities []T
+	if err := uow.whereQuery(ctx, params).Unscoped().Clauses(returning).Delete(&entities).Error; err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// deleteDB returns the connection for a hard or filtered soft delete. Unbounded deletes are
+// refused with ErrUnboundedDelete, which also matches gorm.ErrMissingWhereClause for existing
+// callers, unless WithAllowFullTableDelete is set, in which case GORM's own global delete and
+// update guard is lifted too.
+func (uow *PostgresUnitOfWork[T]) deleteDB(unbounded bool) (*gorm.DB, error) {
 	db := uow.getDB()
+	if !unbounded {
+		return db, nil
+	}
+	if !uow.config.allowFullTableDelete {
+		return db, fmt.Errorf("%w: %w", unit_of_work.ErrUnboundedDelete, gorm.ErrMissingWhereClause)
+	}
+	return db.Session(&gorm.Session{AllowGlobalUpdate: true}), nil
+}
 
-	for _, identifier := range identifiers {
-		query := BuildQueryFromIdentifier[T](db, identifier).Unscoped()
-		if err := query.WithContext(ctx).Delete(new(T)).Error; err != nil {
-			return err
+// whereQuery builds the query matching the filters, relation filters and raw conditions of
+// params for the bulk deletes, failing with ErrUnboundedDelete when params have none of them
+func (uow *PostgresUnitOfWork[T]) whereQuery(ctx context.Context, params *query.QueryParams[T]) *gorm.DB {
+	db, err := uow.deleteDB(params == nil ||
+		len(params.Filters) == 0 && len(params.RelationFilters) == 0 && len(params.RawConditions) == 0)
+	whereQuery := db.WithContext(ctx).Model(new(T))
+	if err != nil {
+		_ = whereQuery.AddError(err)
+	}
+	if params != nil {
+		if err := params.Err(); err != nil {
+			_ = whereQuery.AddError(err)
 		}
+		whereQuery = uow.filterApplier.applyCallFilters(whereQuery, params.Filters)
+		whereQuery = uow.filterApplier.ApplyRelationFilters(whereQuery, params.RelationFilters)
+		whereQuery = uow.filterApplier.applyRawConditions(whereQuery, params.RawConditions)
 	}
-
-	return nil
+	return whereQuery
 }
 
 // Utility operations
@@ -364,21 +985,104 @@ func (uow *PostgresUnitOfWork[T]) ResolveIDByUniqueField(ctx context.Context, mo
 	var entity T
 	db := uow.getDB()
 
-	if err := db.WithContext(ctx).Model(new(T)).Where(fmt.Sprintf("%s = ?", field), value).First(&entity).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		query := uow.identifierQuery(db, nil).WithContext(ctx).Where(fmt.Sprintf("%s = ?", field), value)
+		return uow.excludeDeleted(query).First(&entity).Error
+	})
+	if err != nil {
 		return 0, err
 	}
 
 	return entity.GetID(), nil
 }
 
+// ResolveIDByFields finds the ID of a live entity by a composite unique key such as
+// org_id + email, matching every field in fields with equality. Fields are applied in
+// name order so the generated SQL is stable.
+func (uow *PostgresUnitOfWork[T]) ResolveIDByFields(ctx context.Context, model types.IBaseModel, fields map[string]interface{}) (int, error) {
+	if len(fields) == 0 {
+		return 0, domainerrors.NewValidationError("fields", "at least one field is required")
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		if err := ValidateFieldName(name); err != nil {
+			return 0, err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	key := identifier.NewIdentifier()
+	for _, name := range names {
+		key = key.Equal(name, fields[name])
+	}
+
+	entity, err := uow.FindOneByIdentifier(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return entity.GetID(), nil
+}
+
 // Count returns the total number of entities matching the query parameters
 func (uow *PostgresUnitOfWork[T]) Count(ctx context.Context, query *query.QueryParams[T]) (int64, error) {
-	db := uow.getDB()
-	baseQuery := db.Model(new(T))
+	db := uow.readDB(query != nil && query.ForcePrimary)
+	baseQuery := uow.paramsQuery(db, query)
 	filteredQuery := uow.filterApplier.ApplyQueryParams(baseQuery, query)
 
 	var count int64
-	if err := filteredQuery.WithContext(ctx).Count(&count).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		return filteredQuery.WithContext(ctx).Count(&count).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountPages runs only the COUNT of FindAllWithPagination and derives the number of pages from
+// PageSize (50 when unset), for rendering pagination controls before any data is loaded
+func (uow *PostgresUnitOfWork[T]) CountPages(ctx context.Context, params *query.QueryParams[T]) (int64, int, error) {
+	total, err := uow.Count(ctx, params)
+	if err != nil {
+		return 0, 0, err
+	}
+	pageSize := 0
+	if params != nil {
+		pageSize = params.PageSize
+	}
+	return total, query.TotalPages(total, pageSize), nil
+}
+
+// CountIncludingTrashed returns the number of entities matching the identifier, live and
+// soft-deleted together, e.g. for "total orders ever" figures on admin dashboards.
+// A nil identifier counts every row.
+func (uow *PostgresUnitOfWork[T]) CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	return uow.CountBy(ctx, identifier, unit_of_work.CountOptions{IncludeDeleted: true})
+}
+
+// CountBy returns the number of entities matching the identifier: live ones by default, all
+// of them with IncludeDeleted or only soft-deleted ones with OnlyDeleted. With Distinct set
+// it counts the distinct non-NULL values of that field instead. A nil identifier matches
+// every row.
+func (uow *PostgresUnitOfWork[T]) CountBy(ctx context.Context, identifier identifier.IIdentifier, opts unit_of_work.CountOptions) (int64, error) {
+	if opts.Distinct != "" {
+		if err := ValidateFieldName(opts.Distinct); err != nil {
+			return 0, err
+		}
+	}
+	db := uow.getDB()
+
+	var count int64
+	err := uow.withReadRetry(ctx, func() error {
+		query := uow.filterApplier.ApplyDeletedVisibility(uow.identifierQuery(db, identifier), opts.IncludeDeleted, opts.OnlyDeleted)
+		if opts.Distinct != "" {
+			query = query.Distinct(uow.filterApplier.columnName(opts.Distinct))
+		}
+		return query.WithContext(ctx).Count(&count).Error
+	})
+	if err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -387,11 +1091,39 @@ func (uow *PostgresUnitOfWork[T]) Count(ctx context.Context, query *query.QueryP
 // Exists checks if any entity matches the provided identifier
 func (uow *PostgresUnitOfWork[T]) Exists(ctx context.Context, identifier identifier.IIdentifier) (bool, error) {
 	db := uow.getDB()
-	query := BuildQueryFromIdentifier[T](db, identifier)
 
 	var count int64
-	if err := query.WithContext(ctx).Count(&count).Error; err != nil {
+	err := uow.withReadRetry(ctx, func() error {
+		query := uow.excludeDeleted(uow.identifierQuery(db, identifier))
+		return query.WithContext(ctx).Count(&count).Error
+	})
+	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+// MustExist returns unit_of_work.ErrEntityNotFound when no live entity matches the identifier,
+// for a clean not-found precondition before Update or Delete without loading the entity.
+// The error also matches gorm.ErrRecordNotFound, as returned by FindOneByIdentifier and Update.
+func (uow *PostgresUnitOfWork[T]) MustExist(ctx context.Context, identifier identifier.IIdentifier) error {
+	exists, err := uow.Exists(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		var zero T
+		return fmt.Errorf("%w: %T: %w", unit_of_work.ErrEntityNotFound, zero, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// ExistsWhere checks if any live entity matches both ident and extra, each kept in its own
+// parenthesized group so OR conditions in one cannot widen the other. It suits uniqueness
+// checks during updates, e.g. "an active user with this email other than id X".
+func (uow *PostgresUnitOfWork[T]) ExistsWhere(ctx context.Context, ident identifier.IIdentifier, extra identifier.IIdentifier) (bool, error) {
+	return uow.Exists(ctx, identifier.NewIdentifier().AndGroup(ident).AndGroup(extra))
+}
+
+// Compile-time check to ensure PostgresUnitOfWork implements IUnitOfWork
+var _ unit_of_work.IUnitOfWork[types.IBaseModel] = (*PostgresUnitOfWork[types.IBaseModel])(nil)
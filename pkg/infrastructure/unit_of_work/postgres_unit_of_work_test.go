@@ -2,12 +2,23 @@ package unit_of_work
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
 	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func TestNewPostgresUnitOfWork(t *testing.T) {
@@ -125,6 +136,50 @@ func TestPostgresUnitOfWork_CommitTransaction(t *testing.T) {
 	}
 }
 
+// TestPostgresUnitOfWork_TransactionMisuseErrors validates that misuse is reported with sentinel errors
+func TestPostgresUnitOfWork_TransactionMisuseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		misuse   func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error
+		expected error
+	}{
+		{
+			name: "Commit without transaction",
+			misuse: func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+				return uow.CommitTransaction(ctx)
+			},
+			expected: unit_of_work.ErrNoActiveTransaction,
+		},
+		{
+			name: "Begin while in transaction",
+			misuse: func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+				if err := uow.BeginTransaction(ctx); err != nil {
+					t.Fatalf("Failed to begin transaction: %v", err)
+				}
+				defer uow.RollbackTransaction(ctx)
+				return uow.BeginTransaction(ctx)
+			},
+			expected: unit_of_work.ErrTransactionInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+
+			// Act
+			err := tt.misuse(context.Background(), uow)
+
+			// Assert
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
 func TestPostgresUnitOfWork_RollbackTransaction(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -155,6 +210,293 @@ func TestPostgresUnitOfWork_RollbackTransaction(t *testing.T) {
 	}
 }
 
+// captureLogger records GORM error logs for assertions
+type captureLogger struct {
+	logger.Interface
+	errors []string
+}
+
+func (l *captureLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprintf(msg, data...))
+}
+
+func TestPostgresUnitOfWork_RollbackTransactionE(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx := context.Background()
+
+	if err := uow.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	// Close the underlying transaction so the rollback fails
+	if err := uow.tx.Commit().Error; err != nil {
+		t.Fatalf("Failed to close transaction: %v", err)
+	}
+
+	// Act
+	err := uow.RollbackTransactionE(ctx)
+
+	// Assert
+	if !errors.Is(err, sql.ErrTxDone) {
+		t.Errorf("Expected sql.ErrTxDone, got: %v", err)
+	}
+	if uow.tx != nil {
+		t.Error("Expected transaction state to be cleared after rollback")
+	}
+	if err := uow.RollbackTransactionE(ctx); err != nil {
+		t.Errorf("Expected nil error without active transaction, got: %v", err)
+	}
+}
+
+func TestPostgresUnitOfWork_RollbackTransaction_LogsFailure(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	capture := &captureLogger{Interface: logger.Discard}
+	db.Logger = capture
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx := context.Background()
+
+	if err := uow.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	if err := uow.tx.Commit().Error; err != nil {
+		t.Fatalf("Failed to close transaction: %v", err)
+	}
+
+	// Act
+	uow.RollbackTransaction(ctx)
+
+	// Assert
+	if len(capture.errors) != 1 {
+		t.Fatalf("Expected 1 logged error, got %d", len(capture.errors))
+	}
+	if !strings.Contains(capture.errors[0], "rollback transaction failed") {
+		t.Errorf("Unexpected log message: %s", capture.errors[0])
+	}
+}
+
+// TestPostgresUnitOfWork_InTransaction validates that the flag is only set between begin and commit or rollback
+func TestPostgresUnitOfWork_InTransaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error
+	}{
+		{"Commit", func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+			return uow.CommitTransaction(ctx)
+		}},
+		{"Rollback", func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+			return uow.RollbackTransactionE(ctx)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			ctx := context.Background()
+			before := uow.InTransaction()
+
+			// Act
+			if err := uow.BeginTransaction(ctx); err != nil {
+				t.Fatalf("Failed to begin transaction: %v", err)
+			}
+			during := uow.InTransaction()
+			if err := tt.finish(ctx, uow); err != nil {
+				t.Fatalf("Failed to finish transaction: %v", err)
+			}
+			after := uow.InTransaction()
+
+			// Assert
+			if before || !during || after {
+				t.Errorf("Expected false, true, false; got %v, %v, %v", before, during, after)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_Ping validates the health check against live, closed and cancelled connections
+func TestPostgresUnitOfWork_Ping(t *testing.T) {
+	tests := []struct {
+		name        string
+		closeDB     bool
+		cancelCtx   bool
+		expectError bool
+	}{
+		{"Live database", false, false, false},
+		{"Closed connection", true, false, true},
+		{"Cancelled context", false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.closeDB {
+				sqlDB, err := db.DB()
+				if err != nil {
+					t.Fatalf("Failed to get sql.DB: %v", err)
+				}
+				_ = sqlDB.Close()
+			}
+			if tt.cancelCtx {
+				cancel()
+			}
+
+			// Act
+			err := uow.Ping(ctx)
+
+			// Assert
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_RunInTransaction(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []PostgresOption
+		failures         int
+		failure          error
+		expectError      bool
+		expectedAttempts int
+		expectedRows     int64
+	}{
+		{
+			name:             "Commits on success",
+			expectedAttempts: 1,
+			expectedRows:     1,
+		},
+		{
+			name:             "Retries transient failure",
+			failures:         2,
+			failure:          &sqlStateTestError{state: "40001"},
+			expectedAttempts: 3,
+			expectedRows:     1,
+		},
+		{
+			name:             "Stops after configured attempts",
+			opts:             []PostgresOption{WithTransactionRetry(2)},
+			failures:         5,
+			failure:          &sqlStateTestError{state: "40P01"},
+			expectError:      true,
+			expectedAttempts: 2,
+			expectedRows:     0,
+		},
+		{
+			name:             "Does not retry non-transient failure",
+			failures:         1,
+			failure:          errors.New("validation failed"),
+			expectError:      true,
+			expectedAttempts: 1,
+			expectedRows:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, tt.opts...)
+			ctx := context.Background()
+			attempts := 0
+
+			// Act
+			err := uow.RunInTransaction(ctx, func(ctx context.Context) error {
+				attempts++
+				if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Transactional"}); err != nil {
+					return err
+				}
+				if attempts <= tt.failures {
+					return tt.failure
+				}
+				return nil
+			})
+
+			// Assert
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.expectedAttempts, attempts)
+			}
+
+			count, err := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]())
+			if err != nil {
+				t.Fatalf("Failed to count entities: %v", err)
+			}
+			if count != tt.expectedRows {
+				t.Errorf("Expected %d committed rows, got %d", tt.expectedRows, count)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_RunInTransaction_BackoffCanceled validates that a context ending
+// during the retry backoff stops further attempts
+func TestPostgresUnitOfWork_RunInTransaction_BackoffCanceled(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	uow.config.transactionRetryBackoff = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	failure := &sqlStateTestError{state: "40001"}
+	attempts := 0
+
+	// Act
+	time.AfterFunc(20*time.Millisecond, cancel)
+	err := uow.RunInTransaction(ctx, func(ctx context.Context) error {
+		attempts++
+		return failure
+	})
+
+	// Assert
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the transient error, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+// TestTransactionRetryBackoff validates the doubling, capped retry backoff
+func TestTransactionRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 1, expected: 10 * time.Millisecond},
+		{attempt: 2, expected: 20 * time.Millisecond},
+		{attempt: 3, expected: 40 * time.Millisecond},
+		{attempt: 10, expected: maxTransactionRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempt), func(t *testing.T) {
+			// Act
+			backoff := transactionRetryBackoff(defaultTransactionRetryBackoff, tt.attempt)
+
+			// Assert
+			if backoff != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, backoff)
+			}
+		})
+	}
+}
+
 func TestPostgresUnitOfWork_Insert(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
@@ -185,6 +527,93 @@ func TestPostgresUnitOfWork_Insert(t *testing.T) {
 	}
 }
 
+func TestPostgresUnitOfWork_Insert_UTCTimestamps(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithUTCTimestamps())
+	ctx := context.Background()
+
+	// Act
+	inserted, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Entity 1", Status: "active"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if inserted.CreatedAt.Location() != time.UTC {
+		t.Errorf("Expected CreatedAt in UTC, got %v", inserted.CreatedAt.Location())
+	}
+	if inserted.UpdatedAt.Location() != time.UTC {
+		t.Errorf("Expected UpdatedAt in UTC, got %v", inserted.UpdatedAt.Location())
+	}
+
+	// A filter expressed in another zone must still match the stored UTC timestamp
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	found, err := uow.FindOneByIdentifier(ctx, identifier.NewIdentifier().
+		GreaterOrEqual("created_at", inserted.CreatedAt.Add(-time.Minute).In(zone)))
+	if err != nil {
+		t.Fatalf("Expected entity to match the zoned filter, got: %v", err)
+	}
+	if found.GetID() != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), found.GetID())
+	}
+}
+
+// defaultedEntity has a column whose value is only ever set by a database default
+type defaultedEntity struct {
+	types.BaseEntity
+	Name string `gorm:"column:name"`
+	Code string `gorm:"column:code;<-:false"`
+}
+
+// TableName returns the table name for GORM
+func (de *defaultedEntity) TableName() string {
+	return "defaulted_entities"
+}
+
+func TestPostgresUnitOfWork_Insert_Returning(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []PostgresOption
+		expectedCode string
+	}{
+		{"Without returning", nil, ""},
+		{"With returning", []PostgresOption{WithInsertReturning()}, "generated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			err := db.Exec(`CREATE TABLE defaulted_entities (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at DATETIME, updated_at DATETIME, deleted_at DATETIME,
+				version INTEGER DEFAULT 1,
+				name TEXT,
+				code TEXT NOT NULL DEFAULT 'generated'
+			)`).Error
+			if err != nil {
+				t.Fatalf("Failed to create table: %v", err)
+			}
+			uow := NewPostgresUnitOfWork[*defaultedEntity](db, tt.opts...)
+
+			// Act
+			inserted, err := uow.Insert(context.Background(), &defaultedEntity{Name: "Entity 1"})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if inserted.GetID() == 0 {
+				t.Error("Expected ID to be populated")
+			}
+			if inserted.Code != tt.expectedCode {
+				t.Errorf("Expected Code %q, got %q", tt.expectedCode, inserted.Code)
+			}
+		})
+	}
+}
+
 func TestPostgresUnitOfWork_FindAll(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
@@ -217,42 +646,40 @@ func TestPostgresUnitOfWork_FindAll(t *testing.T) {
 	}
 }
 
-func TestPostgresUnitOfWork_FindOneById(t *testing.T) {
+func TestPostgresUnitOfWork_FindAll_ExcludesSoftDeleted(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
-	entity := &testutil.TestEntity{
-		Name:        "Test Entity",
-		Description: "Test Description",
-		Status:      "active",
+	kept, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Kept", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
 	}
-
-	inserted, err := uow.Insert(ctx, entity)
+	deleted, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Deleted", Status: "active"})
 	if err != nil {
 		t.Fatalf("Failed to insert test entity: %v", err)
 	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", deleted.GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
 
 	// Act
-	result, err := uow.FindOneById(ctx, inserted.GetID())
+	results, err := uow.FindAll(ctx)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if result == nil {
-		t.Fatal("Expected non-nil result")
-	}
-	if result.GetID() != inserted.GetID() {
-		t.Errorf("Expected ID %d, got %d", inserted.GetID(), result.GetID())
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 entity, got %d", len(results))
 	}
-	if result.Name != "Test Entity" {
-		t.Errorf("Expected Name 'Test Entity', got '%s'", result.Name)
+	if results[0].GetID() != kept.GetID() {
+		t.Errorf("Expected ID %d, got %d", kept.GetID(), results[0].GetID())
 	}
 }
 
-func TestPostgresUnitOfWork_FindOneByIdentifier(t *testing.T) {
+func TestPostgresUnitOfWork_FindOneById(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
@@ -269,10 +696,8 @@ func TestPostgresUnitOfWork_FindOneByIdentifier(t *testing.T) {
 		t.Fatalf("Failed to insert test entity: %v", err)
 	}
 
-	identifierBuilder := identifier.NewIdentifier().Equal("name", "Test Entity")
-
 	// Act
-	result, err := uow.FindOneByIdentifier(ctx, identifierBuilder)
+	result, err := uow.FindOneById(ctx, inserted.GetID())
 
 	// Assert
 	if err != nil {
@@ -284,50 +709,125 @@ func TestPostgresUnitOfWork_FindOneByIdentifier(t *testing.T) {
 	if result.GetID() != inserted.GetID() {
 		t.Errorf("Expected ID %d, got %d", inserted.GetID(), result.GetID())
 	}
+	if result.Name != "Test Entity" {
+		t.Errorf("Expected Name 'Test Entity', got '%s'", result.Name)
+	}
 }
 
-func TestPostgresUnitOfWork_Update(t *testing.T) {
+func TestPostgresUnitOfWork_FindByIDs(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
-	entity := &testutil.TestEntity{
-		Name:        "Original Name",
-		Description: "Original Description",
-		Status:      "active",
-	}
-
-	inserted, err := uow.Insert(ctx, entity)
+	entities := []*testutil.TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "active"},
+		{Name: "Entity 3", Status: "active"},
+	}
+	if _, err := uow.BulkInsert(ctx, entities); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	// Act
+	result, err := uow.FindByIDs(ctx, []int{entities[2].GetID(), 99999, entities[1].GetID(), entities[0].GetID()})
+
+	// Assert
 	if err != nil {
-		t.Fatalf("Failed to insert test entity: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 entities, got %d", len(result))
 	}
+	if result[0].GetID() != entities[0].GetID() || result[1].GetID() != entities[2].GetID() {
+		t.Errorf("Expected entities ordered by ID, got %d and %d", result[0].GetID(), result[1].GetID())
+	}
+}
 
-	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
+func TestPostgresUnitOfWork_FindByIDs_Empty(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 
-	// Modify the entity
-	inserted.Name = "Updated Name"
-	inserted.Description = "Updated Description"
+	// Act
+	result, err := uow.FindByIDs(context.Background(), nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result == nil || len(result) != 0 {
+		t.Errorf("Expected an empty slice, got %v", result)
+	}
+}
+
+func TestPostgresUnitOfWork_FindOne_EmbeddedFields(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	for _, name := range []string{"First", "Second"} {
+		if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: name, Status: "active"}); err != nil {
+			t.Fatalf("Failed to insert test entity: %v", err)
+		}
+	}
+
+	// The ID lives in the embedded BaseEntity and must contribute to the filter
+	filter := &testutil.TestEntity{Status: "active"}
+	filter.ID = 2
 
 	// Act
-	result, err := uow.Update(ctx, identifierBuilder, inserted)
+	result, err := uow.FindOne(ctx, filter)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if result == nil {
-		t.Fatal("Expected non-nil result")
+	if result.GetID() != 2 {
+		t.Errorf("Expected ID 2, got %d", result.GetID())
 	}
-	if result.Name != "Updated Name" {
-		t.Errorf("Expected Name 'Updated Name', got '%s'", result.Name)
+	if result.Name != "Second" {
+		t.Errorf("Expected Name 'Second', got '%s'", result.Name)
 	}
-	if result.Description != "Updated Description" {
-		t.Errorf("Expected Description 'Updated Description', got '%s'", result.Description)
+}
+
+// TestPostgresUnitOfWork_FindOne_ExcludesSoftDeleted validates that FindOne hides soft-deleted rows unless asked not to
+func TestPostgresUnitOfWork_FindOne_ExcludesSoftDeleted(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx := context.Background()
+
+	entity, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Trashed", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entity.GetID())); err != nil {
+		t.Fatalf("Failed to soft delete test entity: %v", err)
+	}
+	filter := &testutil.TestEntity{Name: "Trashed"}
+
+	// Act
+	_, findErr := uow.FindOne(ctx, filter)
+	trashed, trashedErr := uow.FindOneIncludingTrashed(ctx, filter)
+
+	// Assert
+	if !errors.Is(findErr, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected record not found for a soft-deleted row, got: %v", findErr)
+	}
+	if trashedErr != nil {
+		t.Fatalf("Expected no error including trashed rows, got: %v", trashedErr)
+	}
+	if trashed.GetID() != entity.GetID() {
+		t.Errorf("Expected ID %d, got %d", entity.GetID(), trashed.GetID())
 	}
 }
 
-func TestPostgresUnitOfWork_SoftDelete(t *testing.T) {
+func TestPostgresUnitOfWork_FindOneByIdentifier(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
@@ -344,10 +844,10 @@ func TestPostgresUnitOfWork_SoftDelete(t *testing.T) {
 		t.Fatalf("Failed to insert test entity: %v", err)
 	}
 
-	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
+	identifierBuilder := identifier.NewIdentifier().Equal("name", "Test Entity")
 
 	// Act
-	result, err := uow.SoftDelete(ctx, identifierBuilder)
+	result, err := uow.FindOneByIdentifier(ctx, identifierBuilder)
 
 	// Assert
 	if err != nil {
@@ -356,23 +856,20 @@ func TestPostgresUnitOfWork_SoftDelete(t *testing.T) {
 	if result == nil {
 		t.Fatal("Expected non-nil result")
 	}
-
-	// Verify entity is soft-deleted by trying to find it
-	_, findErr := uow.FindOneById(ctx, inserted.GetID())
-	if findErr == nil {
-		t.Error("Expected entity to be soft-deleted and not findable")
+	if result.GetID() != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), result.GetID())
 	}
 }
 
-func TestPostgresUnitOfWork_HardDelete(t *testing.T) {
+func TestPostgresUnitOfWork_Update(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
 	entity := &testutil.TestEntity{
-		Name:        "Test Entity",
-		Description: "Test Description",
+		Name:        "Original Name",
+		Description: "Original Description",
 		Status:      "active",
 	}
 
@@ -383,8 +880,12 @@ func TestPostgresUnitOfWork_HardDelete(t *testing.T) {
 
 	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
 
+	// Modify the entity
+	inserted.Name = "Updated Name"
+	inserted.Description = "Updated Description"
+
 	// Act
-	result, err := uow.HardDelete(ctx, identifierBuilder)
+	result, err := uow.Update(ctx, identifierBuilder, inserted)
 
 	// Assert
 	if err != nil {
@@ -393,194 +894,172 @@ func TestPostgresUnitOfWork_HardDelete(t *testing.T) {
 	if result == nil {
 		t.Fatal("Expected non-nil result")
 	}
-	if result.GetID() != inserted.GetID() {
-		t.Errorf("Expected ID %d, got %d", inserted.GetID(), result.GetID())
+	if result.Name != "Updated Name" {
+		t.Errorf("Expected Name 'Updated Name', got '%s'", result.Name)
 	}
-
-	// Verify entity is hard-deleted
-	_, findErr := uow.FindOneById(ctx, inserted.GetID())
-	if findErr == nil {
-		t.Error("Expected entity to be hard-deleted and not findable")
+	if result.Description != "Updated Description" {
+		t.Errorf("Expected Description 'Updated Description', got '%s'", result.Description)
 	}
 }
 
-func TestPostgresUnitOfWork_GetTrashed(t *testing.T) {
+// TestPostgresUnitOfWork_Save validates that Save inserts new entities and updates loaded ones
+func TestPostgresUnitOfWork_Save(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
-	// Insert and soft-delete some entities
-	entities := []*testutil.TestEntity{
-		{Name: "Entity 1", Status: "active"},
-		{Name: "Entity 2", Status: "active"},
+	// Act
+	inserted, insertErr := uow.Save(ctx, &testutil.TestEntity{Name: "Draft", Status: "draft"})
+	if insertErr != nil {
+		t.Fatalf("Expected no error on insert, got: %v", insertErr)
 	}
-
-	for _, entity := range entities {
-		inserted, err := uow.Insert(ctx, entity)
-		if err != nil {
-			t.Fatalf("Failed to insert entity: %v", err)
-		}
-
-		identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
-
-		_, err = uow.SoftDelete(ctx, identifierBuilder)
-		if err != nil {
-			t.Fatalf("Failed to soft delete entity: %v", err)
-		}
+	loaded, err := uow.FindOneById(ctx, inserted.GetID())
+	if err != nil {
+		t.Fatalf("Failed to load saved entity: %v", err)
 	}
-
-	// Act
-	trashedEntities, err := uow.GetTrashed(ctx)
+	loaded.Status = "published"
+	updated, updateErr := uow.Save(ctx, loaded)
 
 	// Assert
+	if inserted.GetID() == 0 {
+		t.Fatal("Expected the insert path to assign an ID")
+	}
+	if updateErr != nil {
+		t.Fatalf("Expected no error on update, got: %v", updateErr)
+	}
+	if updated.GetID() != inserted.GetID() {
+		t.Errorf("Expected the update to keep ID %d, got %d", inserted.GetID(), updated.GetID())
+	}
+	count, err := uow.Count(ctx, nil)
 	if err != nil {
-		t.Fatalf("Expected no error, got: %v", err)
+		t.Fatalf("Failed to count entities: %v", err)
 	}
-	if len(trashedEntities) != 2 {
-		t.Errorf("Expected 2 trashed entities, got %d", len(trashedEntities))
+	if count != 1 {
+		t.Errorf("Expected 1 entity after insert and update, got %d", count)
+	}
+	reloaded, err := uow.FindOneById(ctx, inserted.GetID())
+	if err != nil {
+		t.Fatalf("Failed to reload entity: %v", err)
+	}
+	if reloaded.Status != "published" {
+		t.Errorf("Expected status 'published', got '%s'", reloaded.Status)
 	}
 }
 
-func TestPostgresUnitOfWork_Restore(t *testing.T) {
+// TestPostgresUnitOfWork_Save_UnknownID validates that saving an entity with a missing ID does not insert it
+func TestPostgresUnitOfWork_Save_UnknownID(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
-	ctx := context.Background()
+	entity := &testutil.TestEntity{Name: "Ghost"}
+	entity.ID = 42
 
-	entity := &testutil.TestEntity{
-		Name:        "Test Entity",
-		Description: "Test Description",
-		Status:      "active",
-	}
+	// Act
+	_, err := uow.Save(context.Background(), entity)
 
-	inserted, err := uow.Insert(ctx, entity)
-	if err != nil {
-		t.Fatalf("Failed to insert test entity: %v", err)
+	// Assert
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected record not found, got: %v", err)
 	}
+}
 
-	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
+func TestPostgresUnitOfWork_UpdateE(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
 
-	// Soft delete first
-	_, err = uow.SoftDelete(ctx, identifierBuilder)
+	inserted, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Original Name", Status: "active"})
 	if err != nil {
-		t.Fatalf("Failed to soft delete entity: %v", err)
+		t.Fatalf("Failed to insert test entity: %v", err)
 	}
+	inserted.Name = "Updated Name"
 
 	// Act
-	result, err := uow.Restore(ctx, identifierBuilder)
+	result, rowsAffected, err := uow.UpdateE(ctx, identifier.NewIdentifier().Equal("id", inserted.GetID()), inserted)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if result == nil {
-		t.Fatal("Expected non-nil result")
-	}
-
-	// Verify entity is restored and findable again
-	found, findErr := uow.FindOneById(ctx, inserted.GetID())
-	if findErr != nil {
-		t.Fatalf("Expected to find restored entity, got error: %v", findErr)
+	if rowsAffected != 1 {
+		t.Errorf("Expected 1 affected row, got %d", rowsAffected)
 	}
-	if found.GetID() != inserted.GetID() {
-		t.Errorf("Expected ID %d, got %d", inserted.GetID(), found.GetID())
+	if result.Name != "Updated Name" {
+		t.Errorf("Expected Name 'Updated Name', got '%s'", result.Name)
 	}
 }
 
-func TestPostgresUnitOfWork_BulkInsert(t *testing.T) {
-	tests := []struct {
-		name          string
-		entities      []*testutil.TestEntity
-		expectError   bool
-		expectedCount int
-	}{
-		{
-			name: "Insert multiple entities successfully",
-			entities: []*testutil.TestEntity{
-				{Name: "Entity 1", Status: "active"},
-				{Name: "Entity 2", Status: "active"},
-				{Name: "Entity 3", Status: "inactive"},
-			},
-			expectError:   false,
-			expectedCount: 3,
-		},
-		{
-			name:          "Insert empty slice",
-			entities:      []*testutil.TestEntity{},
-			expectError:   false,
-			expectedCount: 0,
-		},
-	}
+func TestPostgresUnitOfWork_UpdateE_NoMatch(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Arrange
-			db := testutil.SetupTestDB(t)
-			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
-			ctx := context.Background()
+	entity := &testutil.TestEntity{Name: "Missing", Status: "active"}
+	entity.ID = 99999
 
-			// Act
-			result, err := uow.BulkInsert(ctx, tt.entities)
+	// Act
+	_, rowsAffected, err := uow.UpdateE(ctx, identifier.NewIdentifier().Equal("id", 99999), entity)
 
-			// Assert
-			if tt.expectError && err == nil {
-				t.Error("Expected error but got none")
-			}
-			if !tt.expectError && err != nil {
-				t.Errorf("Expected no error but got: %v", err)
-			}
-			if len(result) != tt.expectedCount {
-				t.Errorf("Expected %d entities, got %d", tt.expectedCount, len(result))
-			}
+	// Assert
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected record not found, got: %v", err)
+	}
+	if rowsAffected != 0 {
+		t.Errorf("Expected 0 affected rows, got %d", rowsAffected)
+	}
 
-			// Verify all entities have IDs if successful
-			if !tt.expectError && len(result) > 0 {
-				for i, entity := range result {
-					if entity.GetID() == 0 {
-						t.Errorf("Entity %d should have ID set", i)
-					}
-				}
-			}
-		})
+	count, _ := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]())
+	if count != 0 {
+		t.Errorf("Expected no entity to be created, got %d", count)
 	}
 }
 
-func TestPostgresUnitOfWork_Count(t *testing.T) {
+func TestPostgresUnitOfWork_UpdateIncludingTrashed(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
-	// Insert test data
-	entities := []*testutil.TestEntity{
-		{Name: "Entity 1", Status: "active"},
-		{Name: "Entity 2", Status: "active"},
-		{Name: "Entity 3", Status: "inactive"},
+	inserted, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Original Name", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
 	}
-
-	for _, entity := range entities {
-		_, err := uow.Insert(ctx, entity)
-		if err != nil {
-			t.Fatalf("Failed to insert test entity: %v", err)
-		}
+	byID := identifier.NewIdentifier().Equal("id", inserted.GetID())
+	if _, err := uow.SoftDelete(ctx, byID); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
 	}
 
-	queryParams := query.NewQueryParams[*testutil.TestEntity]()
+	// The regular update path cannot see the trashed row
+	if _, err := uow.Update(ctx, byID, inserted); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Expected Update to miss the trashed row, got: %v", err)
+	}
 
 	// Act
-	count, err := uow.Count(ctx, queryParams)
+	update := &testutil.TestEntity{Name: "Edited In Trash", Status: "archived"}
+	update.ID = inserted.GetID()
+	update.CreatedAt = inserted.CreatedAt
+	_, err = uow.UpdateIncludingTrashed(ctx, byID, update)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if count != 3 {
-		t.Errorf("Expected count 3, got %d", count)
+	trashed, err := uow.GetTrashedByIdentifier(ctx, byID)
+	if err != nil {
+		t.Fatalf("Failed to list trash: %v", err)
+	}
+	if len(trashed) != 1 {
+		t.Fatalf("Expected the entity to stay in the trash, got %d rows", len(trashed))
+	}
+	if trashed[0].Name != "Edited In Trash" || trashed[0].Status != "archived" {
+		t.Errorf("Expected the update to apply, got %+v", trashed[0])
 	}
 }
 
-func TestPostgresUnitOfWork_Exists(t *testing.T) {
+func TestPostgresUnitOfWork_SoftDelete(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
@@ -597,158 +1076,116 @@ func TestPostgresUnitOfWork_Exists(t *testing.T) {
 		t.Fatalf("Failed to insert test entity: %v", err)
 	}
 
-	tests := []struct {
-		name       string
-		identifier identifier.IIdentifier
-		expected   bool
-	}{
-		{
-			name:       "Entity exists",
-			identifier: identifier.NewIdentifier().Equal("id", inserted.GetID()),
-			expected:   true,
-		},
-		{
-			name:       "Entity does not exist",
-			identifier: identifier.NewIdentifier().Equal("id", 99999),
-			expected:   false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Act
-			exists, err := uow.Exists(ctx, tt.identifier)
-
-			// Assert
-			if err != nil {
-				t.Fatalf("Expected no error, got: %v", err)
-			}
-			if exists != tt.expected {
-				t.Errorf("Expected exists %v, got %v", tt.expected, exists)
-			}
-		})
-	}
-}
-
-func TestPostgresUnitOfWork_FindAllWithPagination(t *testing.T) {
-	// Arrange
-	db := testutil.SetupTestDB(t)
-	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
-	ctx := context.Background()
-
-	// Insert test data
-	for i := 1; i <= 10; i++ {
-		entity := &testutil.TestEntity{
-			Name:   "Entity " + string(rune(i+'0')),
-			Status: "active",
-		}
-		_, err := uow.Insert(ctx, entity)
-		if err != nil {
-			t.Fatalf("Failed to insert test entity: %v", err)
-		}
-	}
-
-	queryParams := query.NewQueryParams[*testutil.TestEntity]()
-	queryParams.Limit = 5
-	queryParams.Offset = 0
+	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
 
 	// Act
-	results, total, err := uow.FindAllWithPagination(ctx, queryParams)
+	result, err := uow.SoftDelete(ctx, identifierBuilder)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if len(results) != 5 {
-		t.Errorf("Expected 5 results, got %d", len(results))
+	if result == nil {
+		t.Fatal("Expected non-nil result")
 	}
-	if total != 10 {
-		t.Errorf("Expected total 10, got %d", total)
+
+	// Verify entity is soft-deleted by trying to find it
+	_, findErr := uow.FindOneById(ctx, inserted.GetID())
+	if findErr == nil {
+		t.Error("Expected entity to be soft-deleted and not findable")
 	}
 }
 
-func TestPostgresUnitOfWork_Transaction_Lifecycle(t *testing.T) {
+// TestPostgresUnitOfWork_Replace validates that Replace leaves the new entity as the only live
+// match and trashes the previous one, and that a failed insert keeps the previous one live
+func TestPostgresUnitOfWork_Replace(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
-
-	// Act & Assert - Complete transaction lifecycle
-	err := uow.BeginTransaction(ctx)
+	current, err := uow.Insert(ctx, &testutil.TestEntity{Name: "v1", Status: "active"})
 	if err != nil {
-		t.Fatalf("Failed to begin transaction: %v", err)
+		t.Fatalf("Failed to insert test entity: %v", err)
 	}
+	byStatus := identifier.NewIdentifier().Equal("status", "active")
 
-	// Insert entity within transaction
-	entity := &testutil.TestEntity{
-		Name:        "Transaction Entity",
-		Description: "Test in transaction",
-		Status:      "active",
-	}
+	// Act
+	replacement, err := uow.Replace(ctx, byStatus, &testutil.TestEntity{Name: "v2", Status: "active"})
+	conflicting := &testutil.TestEntity{Name: "v3", Status: "active"}
+	conflicting.ID = current.GetID()
+	_, conflictErr := uow.Replace(ctx, byStatus, conflicting)
+	_, emptyErr := uow.Replace(ctx, identifier.NewIdentifier(), &testutil.TestEntity{Name: "v4"})
 
-	inserted, err := uow.Insert(ctx, entity)
+	// Assert
 	if err != nil {
-		t.Fatalf("Failed to insert entity in transaction: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-
-	// Commit transaction
-	err = uow.CommitTransaction(ctx)
+	live, err := uow.FindAll(ctx)
 	if err != nil {
-		t.Fatalf("Failed to commit transaction: %v", err)
+		t.Fatalf("Failed to load live entities: %v", err)
 	}
-
-	// Verify entity persisted after commit
-	found, err := uow.FindOneById(ctx, inserted.GetID())
+	if len(live) != 1 || live[0].GetID() != replacement.GetID() || live[0].Name != "v2" {
+		t.Errorf("Expected the replacement as the only live entity, got %+v", live)
+	}
+	trashed, err := uow.GetTrashed(ctx)
 	if err != nil {
-		t.Fatalf("Failed to find entity after commit: %v", err)
+		t.Fatalf("Failed to load trashed entities: %v", err)
 	}
-	if found.GetID() != inserted.GetID() {
-		t.Errorf("Expected ID %d, got %d", inserted.GetID(), found.GetID())
+	if len(trashed) != 1 || trashed[0].GetID() != current.GetID() {
+		t.Errorf("Expected the previous entity to be trashed, got %+v", trashed)
+	}
+	if conflictErr == nil {
+		t.Errorf("Expected the conflicting insert to fail")
+	}
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(emptyErr, &validationErr) {
+		t.Errorf("Expected validation error for an empty identifier, got: %v", emptyErr)
 	}
 }
 
-func TestPostgresUnitOfWork_Transaction_Rollback_Lifecycle(t *testing.T) {
+// TestPostgresUnitOfWork_Replace_InTransaction validates that Replace joins the active
+// transaction, so rolling it back undoes both the soft delete and the insert
+func TestPostgresUnitOfWork_Replace_InTransaction(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
-
-	// Act & Assert - Transaction rollback lifecycle
-	err := uow.BeginTransaction(ctx)
-	if err != nil {
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "v1", Status: "active"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	if err := uow.BeginTransaction(ctx); err != nil {
 		t.Fatalf("Failed to begin transaction: %v", err)
 	}
 
-	// Insert entity within transaction
-	entity := &testutil.TestEntity{
-		Name:        "Rollback Entity",
-		Description: "Test rollback",
-		Status:      "active",
-	}
+	// Act
+	_, err := uow.Replace(ctx, identifier.NewIdentifier().Equal("status", "active"), &testutil.TestEntity{Name: "v2", Status: "active"})
+	stillInTransaction := uow.InTransaction()
+	uow.RollbackTransaction(ctx)
 
-	inserted, err := uow.Insert(ctx, entity)
+	// Assert
 	if err != nil {
-		t.Fatalf("Failed to insert entity in transaction: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-
-	// Rollback transaction
-	uow.RollbackTransaction(ctx)
-
-	// Verify entity was not persisted after rollback
-	_, err = uow.FindOneById(ctx, inserted.GetID())
-	if err == nil {
-		t.Error("Expected entity to not exist after rollback, but it was found")
+	if !stillInTransaction {
+		t.Errorf("Expected Replace to leave the caller's transaction open")
+	}
+	live, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load live entities: %v", err)
+	}
+	if len(live) != 1 || live[0].Name != "v1" {
+		t.Errorf("Expected the rollback to restore the previous entity, got %+v", live)
 	}
 }
 
-func TestPostgresUnitOfWork_ResolveIDByUniqueField(t *testing.T) {
+func TestPostgresUnitOfWork_HardDelete(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
 	entity := &testutil.TestEntity{
-		Name:        "Unique Name",
+		Name:        "Test Entity",
 		Description: "Test Description",
 		Status:      "active",
 	}
@@ -758,38 +1195,46 @@ func TestPostgresUnitOfWork_ResolveIDByUniqueField(t *testing.T) {
 		t.Fatalf("Failed to insert test entity: %v", err)
 	}
 
+	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
+
 	// Act
-	resolvedID, err := uow.ResolveIDByUniqueField(ctx, inserted, "name", "Unique Name")
+	result, err := uow.HardDelete(ctx, identifierBuilder)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if resolvedID != inserted.GetID() {
-		t.Errorf("Expected ID %d, got %d", inserted.GetID(), resolvedID)
+	if result == nil {
+		t.Fatal("Expected non-nil result")
+	}
+	if result.GetID() != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), result.GetID())
+	}
+
+	// Verify entity is hard-deleted
+	_, findErr := uow.FindOneById(ctx, inserted.GetID())
+	if findErr == nil {
+		t.Error("Expected entity to be hard-deleted and not findable")
 	}
 }
 
-func TestPostgresUnitOfWork_RestoreAll(t *testing.T) {
+func TestPostgresUnitOfWork_GetTrashed(t *testing.T) {
 	// Arrange
 	db := testutil.SetupTestDB(t)
 	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
 	ctx := context.Background()
 
-	// Insert and soft-delete multiple entities
+	// Insert and soft-delete some entities
 	entities := []*testutil.TestEntity{
 		{Name: "Entity 1", Status: "active"},
 		{Name: "Entity 2", Status: "active"},
-		{Name: "Entity 3", Status: "active"},
 	}
 
-	var insertedIDs []int
 	for _, entity := range entities {
 		inserted, err := uow.Insert(ctx, entity)
 		if err != nil {
 			t.Fatalf("Failed to insert entity: %v", err)
 		}
-		insertedIDs = append(insertedIDs, inserted.GetID())
 
 		identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
 
@@ -800,74 +1245,2088 @@ func TestPostgresUnitOfWork_RestoreAll(t *testing.T) {
 	}
 
 	// Act
-	err := uow.RestoreAll(ctx)
+	trashedEntities, err := uow.GetTrashed(ctx)
 
 	// Assert
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
+	if len(trashedEntities) != 2 {
+		t.Errorf("Expected 2 trashed entities, got %d", len(trashedEntities))
+	}
+}
 
-	// Verify all entities are restored
-	for _, id := range insertedIDs {
-		found, findErr := uow.FindOneById(ctx, id)
-		if findErr != nil {
-			t.Errorf("Expected to find restored entity with ID %d, got error: %v", id, findErr)
+func TestPostgresUnitOfWork_GetTrashedByIdentifier(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	entities := []*testutil.TestEntity{
+		{Name: "Trashed 1", Status: "mine"},
+		{Name: "Trashed 2", Status: "mine"},
+		{Name: "Trashed Other", Status: "other"},
+		{Name: "Live", Status: "mine"},
+	}
+	for _, entity := range entities {
+		if _, err := uow.Insert(ctx, entity); err != nil {
+			t.Fatalf("Failed to insert entity: %v", err)
 		}
-		if found.GetID() != id {
-			t.Errorf("Expected ID %d, got %d", id, found.GetID())
+	}
+	for _, entity := range entities[:3] {
+		if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entity.GetID())); err != nil {
+			t.Fatalf("Failed to soft delete entity: %v", err)
 		}
 	}
+
+	// Act
+	trashed, err := uow.GetTrashedByIdentifier(ctx, identifier.NewIdentifier().Equal("status", "mine"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(trashed) != 2 {
+		t.Fatalf("Expected 2 trashed entities, got %d", len(trashed))
+	}
+	// Most recently deleted first
+	if trashed[0].Name != "Trashed 2" || trashed[1].Name != "Trashed 1" {
+		t.Errorf("Expected [Trashed 2, Trashed 1], got [%s, %s]", trashed[0].Name, trashed[1].Name)
+	}
 }
 
-func TestPostgresUnitOfWork_Error_Cases(t *testing.T) {
-	tests := []struct {
-		name        string
-		testFunc    func(*testing.T, unit_of_work.IUnitOfWork[*testutil.TestEntity])
-		expectError bool
-	}{
-		{
-			name: "FindOneById with non-existent ID",
-			testFunc: func(t *testing.T, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) {
-				_, err := uow.FindOneById(context.Background(), 99999)
-				if err == nil {
-					t.Error("Expected error for non-existent ID")
-				}
-			},
-			expectError: true,
-		},
-		{
-			name: "Update non-existent entity",
-			testFunc: func(t *testing.T, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) {
-				identifierBuilder := identifier.NewIdentifier().Equal("id", 99999)
-				entity := &testutil.TestEntity{Name: "Non-existent"}
-				_, err := uow.Update(context.Background(), identifierBuilder, entity)
-				if err == nil {
-					t.Error("Expected error for updating non-existent entity")
-				}
-			},
-			expectError: true,
-		},
-		{
-			name: "SoftDelete non-existent entity",
-			testFunc: func(t *testing.T, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) {
-				identifierBuilder := identifier.NewIdentifier().Equal("id", 99999)
-				_, err := uow.SoftDelete(context.Background(), identifierBuilder)
-				if err == nil {
-					t.Error("Expected error for soft deleting non-existent entity")
-				}
-			},
-			expectError: true,
-		},
+func TestPostgresUnitOfWork_Restore(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	entity := &testutil.TestEntity{
+		Name:        "Test Entity",
+		Description: "Test Description",
+		Status:      "active",
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Arrange
-			db := testutil.SetupTestDB(t)
-			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	inserted, err := uow.Insert(ctx, entity)
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
 
-			// Act & Assert
-			tt.testFunc(t, uow)
+	identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
+
+	// Soft delete first
+	_, err = uow.SoftDelete(ctx, identifierBuilder)
+	if err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	// Act
+	result, err := uow.Restore(ctx, identifierBuilder)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result == nil {
+		t.Fatal("Expected non-nil result")
+	}
+
+	// Verify entity is restored and findable again
+	found, findErr := uow.FindOneById(ctx, inserted.GetID())
+	if findErr != nil {
+		t.Fatalf("Expected to find restored entity, got error: %v", findErr)
+	}
+	if found.GetID() != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), found.GetID())
+	}
+}
+
+// TestPostgresUnitOfWork_RestoreAllMatching validates that every trashed entity matching the
+// identifier is restored, while Restore only recovers the most recently deleted one
+func TestPostgresUnitOfWork_RestoreAllMatching(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "Mine 1", Status: "mine"},
+		{Name: "Mine 2", Status: "mine"},
+		{Name: "Mine 3", Status: "mine"},
+		{Name: "Other", Status: "other"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.BulkSoftDeleteE(ctx, []identifier.IIdentifier{identifier.NewIdentifier().In("status", []interface{}{"mine", "other"})}); err != nil {
+		t.Fatalf("Failed to soft delete entities: %v", err)
+	}
+	if err := db.Exec("UPDATE test_entities SET deleted_at = ? WHERE id = ?", time.Now().Add(time.Minute), entities[1].GetID()).Error; err != nil {
+		t.Fatalf("Failed to set deletion time: %v", err)
+	}
+	mine := identifier.NewIdentifier().Equal("status", "mine")
+
+	// Act
+	single, singleErr := uow.Restore(ctx, mine)
+	afterSingle, _ := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]())
+	restored, err := uow.RestoreAllMatching(ctx, mine)
+
+	// Assert
+	if singleErr != nil || err != nil {
+		t.Fatalf("Expected no error, got: %v, %v", singleErr, err)
+	}
+	if single.GetID() != entities[1].GetID() || afterSingle != 1 {
+		t.Errorf("Expected Restore to recover only the most recently deleted entity, got %s and %d live", single.Name, afterSingle)
+	}
+	if restored != 2 {
+		t.Errorf("Expected the 2 remaining trashed entities to be restored, got %d", restored)
+	}
+	live, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load live entities: %v", err)
+	}
+	if len(live) != 3 {
+		t.Errorf("Expected every matching entity to be live, got %d", len(live))
+	}
+	trashed, err := uow.GetTrashed(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load trashed entities: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].Name != "Other" {
+		t.Errorf("Expected non-matching entities to stay trashed, got %+v", trashed)
+	}
+}
+
+func TestPostgresUnitOfWork_BulkInsert(t *testing.T) {
+	tests := []struct {
+		name          string
+		entities      []*testutil.TestEntity
+		expectError   bool
+		expectedCount int
+	}{
+		{
+			name: "Insert multiple entities successfully",
+			entities: []*testutil.TestEntity{
+				{Name: "Entity 1", Status: "active"},
+				{Name: "Entity 2", Status: "active"},
+				{Name: "Entity 3", Status: "inactive"},
+			},
+			expectError:   false,
+			expectedCount: 3,
+		},
+		{
+			name:          "Insert empty slice",
+			entities:      []*testutil.TestEntity{},
+			expectError:   false,
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			ctx := context.Background()
+
+			// Act
+			result, err := uow.BulkInsert(ctx, tt.entities)
+
+			// Assert
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if len(result) != tt.expectedCount {
+				t.Errorf("Expected %d entities, got %d", tt.expectedCount, len(result))
+			}
+
+			// Verify all entities have IDs if successful
+			if !tt.expectError && len(result) > 0 {
+				for i, entity := range result {
+					if entity.GetID() == 0 {
+						t.Errorf("Entity %d should have ID set", i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_PruneWhere(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	entities := []*testutil.TestEntity{
+		{Name: "Old 1", Status: "active"},
+		{Name: "Old 2", Status: "active"},
+		{Name: "New", Status: "active"},
+	}
+	if _, err := uow.BulkInsert(ctx, entities); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	for _, old := range entities[:2] {
+		if err := db.Model(old).UpdateColumn("created_at", cutoff.Add(-time.Hour)).Error; err != nil {
+			t.Fatalf("Failed to backdate entity: %v", err)
+		}
+	}
+	// A trashed old row must be pruned as well
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().LessThan("created_at", cutoff))
+
+	// Act
+	pruned, err := uow.PruneWhere(ctx, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if pruned != 2 {
+		t.Errorf("Expected 2 pruned rows, got %d", pruned)
+	}
+
+	var remaining []testutil.TestEntity
+	if err := db.Unscoped().Find(&remaining).Error; err != nil {
+		t.Fatalf("Failed to list remaining entities: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "New" {
+		t.Errorf("Expected only the new entity to remain, got %+v", remaining)
+	}
+}
+
+func TestPostgresUnitOfWork_PruneWhere_WithoutFilters(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Entity 1", Status: "active"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	// Act
+	pruned, err := uow.PruneWhere(ctx, query.NewQueryParams[*testutil.TestEntity]())
+
+	// Assert
+	if !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("Expected missing where clause error, got: %v", err)
+	}
+	if pruned != 0 {
+		t.Errorf("Expected 0 pruned rows, got %d", pruned)
+	}
+}
+
+// TestPostgresUnitOfWork_PruneWhere_RawConditions validates that raw conditions narrow the
+// delete exactly as they narrow Count, and bound it on their own
+func TestPostgresUnitOfWork_PruneWhere_RawConditions(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         *query.QueryParams[*testutil.TestEntity]
+		expectedPruned int64
+	}{
+		{
+			name: "Raw condition narrows filters",
+			params: query.NewQueryParams[*testutil.TestEntity]().
+				WithFilters(identifier.NewIdentifier().Equal("status", "active")).
+				WhereRaw("1 = 0"),
+			expectedPruned: 0,
+		},
+		{
+			name:           "Raw condition alone",
+			params:         query.NewQueryParams[*testutil.TestEntity]().WhereRaw("name = ?", "Entity 1"),
+			expectedPruned: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			ctx := context.Background()
+			for _, name := range []string{"Entity 1", "Entity 2", "Entity 3"} {
+				if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: name, Status: "active"}); err != nil {
+					t.Fatalf("Failed to insert test entity: %v", err)
+				}
+			}
+
+			// Act
+			count, err := uow.Count(ctx, tt.params)
+			if err != nil {
+				t.Fatalf("Failed to count entities: %v", err)
+			}
+			pruned, err := uow.PruneWhere(ctx, tt.params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if pruned != count || pruned != tt.expectedPruned {
+				t.Errorf("Expected %d pruned rows matching Count %d, got %d", tt.expectedPruned, count, pruned)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_UnboundedHardDelete validates that hard deletes without conditions need explicit permission
+func TestPostgresUnitOfWork_UnboundedHardDelete(t *testing.T) {
+	operations := []struct {
+		name   string
+		delete func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error
+	}{
+		{"HardDelete", func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+			_, err := uow.HardDelete(ctx, identifier.NewIdentifier())
+			return err
+		}},
+		{"BulkHardDelete", func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+			return uow.BulkHardDelete(ctx, []identifier.IIdentifier{nil})
+		}},
+		{"PruneWhere", func(ctx context.Context, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) error {
+			_, err := uow.PruneWhere(ctx, nil)
+			return err
+		}},
+	}
+
+	for _, op := range operations {
+		for _, allow := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s allowed=%v", op.name, allow), func(t *testing.T) {
+				// Arrange
+				db := testutil.SetupTestDB(t)
+				var opts []PostgresOption
+				if allow {
+					opts = append(opts, WithAllowFullTableDelete())
+				}
+				uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, opts...)
+				ctx := context.Background()
+				if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "Entity 1"}, {Name: "Entity 2"}}); err != nil {
+					t.Fatalf("Failed to insert test entities: %v", err)
+				}
+
+				// Act
+				err := op.delete(ctx, uow)
+
+				// Assert
+				var remaining int64
+				if err := db.Unscoped().Model(&testutil.TestEntity{}).Count(&remaining).Error; err != nil {
+					t.Fatalf("Failed to count rows: %v", err)
+				}
+				if allow {
+					if err != nil {
+						t.Errorf("Expected no error, got: %v", err)
+					}
+					if remaining != 0 {
+						t.Errorf("Expected every row to be deleted, %d remain", remaining)
+					}
+					return
+				}
+				if !errors.Is(err, unit_of_work.ErrUnboundedDelete) {
+					t.Errorf("Expected ErrUnboundedDelete, got: %v", err)
+				}
+				if remaining != 2 {
+					t.Errorf("Expected both rows to remain, %d remain", remaining)
+				}
+			})
+		}
+	}
+}
+
+// TestPostgresUnitOfWork_PruneWhereReturning validates that the deleted rows are returned from the DELETE itself
+func TestPostgresUnitOfWork_PruneWhereReturning(t *testing.T) {
+	tests := []struct {
+		name          string
+		columns       []string
+		expectedEmail string
+	}{
+		{"All columns", nil, "b@example.com"},
+		{"Selected columns", []string{"id", "name"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+			ctx := context.Background()
+			if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+				{Name: "A", Email: "a@example.com", Status: "active"},
+				{Name: "B", Email: "b@example.com", Status: "inactive"},
+			}); err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			params := query.NewQueryParams[*testutil.TestEntity]().
+				WithFilters(identifier.NewIdentifier().Equal("status", "inactive"))
+
+			// Act
+			pruned, err := uow.PruneWhereReturning(ctx, params, tt.columns...)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(pruned) != 1 || pruned[0].Name != "B" || pruned[0].GetID() != 2 {
+				t.Fatalf("Expected entity B to be returned, got %+v", pruned)
+			}
+			if pruned[0].Email != tt.expectedEmail {
+				t.Errorf("Expected Email %q, got %q", tt.expectedEmail, pruned[0].Email)
+			}
+			var remaining int64
+			if err := db.Unscoped().Model(&testutil.TestEntity{}).Count(&remaining).Error; err != nil {
+				t.Fatalf("Failed to count remaining entities: %v", err)
+			}
+			if remaining != 1 {
+				t.Errorf("Expected 1 remaining entity, got %d", remaining)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_BulkUpdate_Returning validates that updated entities are repopulated from the database
+func TestPostgresUnitOfWork_BulkUpdate_Returning(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []PostgresOption
+		expectedCode string
+	}{
+		{"Without returning", nil, ""},
+		{"With returning", []PostgresOption{WithUpdateReturning()}, "generated"},
+		{"With selected columns", []PostgresOption{WithUpdateReturning("id", "code")}, "generated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			err := db.Exec(`CREATE TABLE defaulted_entities (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				created_at DATETIME, updated_at DATETIME, deleted_at DATETIME,
+				version INTEGER DEFAULT 1,
+				name TEXT,
+				code TEXT NOT NULL DEFAULT 'generated'
+			)`).Error
+			if err != nil {
+				t.Fatalf("Failed to create table: %v", err)
+			}
+			if err := db.Create(&[]*defaultedEntity{{Name: "One"}, {Name: "Two"}}).Error; err != nil {
+				t.Fatalf("Failed to insert entities: %v", err)
+			}
+			uow := NewPostgresUnitOfWork[*defaultedEntity](db, tt.opts...)
+			entities := []*defaultedEntity{
+				{BaseEntity: types.BaseEntity{ID: 1}, Name: "One updated"},
+				{BaseEntity: types.BaseEntity{ID: 2}, Name: "Two updated"},
+			}
+
+			// Act
+			updated, err := uow.BulkUpdate(context.Background(), entities)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			for i, entity := range updated {
+				if entity.Code != tt.expectedCode {
+					t.Errorf("Expected Code %q for entity %d, got %q", tt.expectedCode, i, entity.Code)
+				}
+				if entity.Name != entities[i].Name {
+					t.Errorf("Expected Name %q, got %q", entities[i].Name, entity.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_BulkUpdate_ReturningInvalidColumn validates that unsafe RETURNING columns are rejected
+func TestPostgresUnitOfWork_BulkUpdate_ReturningInvalidColumn(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithUpdateReturning("name; DROP TABLE test_entities"))
+
+	// Act
+	_, err := uow.BulkUpdate(context.Background(), []*testutil.TestEntity{{Name: "Entity"}})
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
+
+func TestPostgresUnitOfWork_Count(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	// Insert test data
+	entities := []*testutil.TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "active"},
+		{Name: "Entity 3", Status: "inactive"},
+	}
+
+	for _, entity := range entities {
+		_, err := uow.Insert(ctx, entity)
+		if err != nil {
+			t.Fatalf("Failed to insert test entity: %v", err)
+		}
+	}
+
+	queryParams := query.NewQueryParams[*testutil.TestEntity]()
+
+	// Act
+	count, err := uow.Count(ctx, queryParams)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected count 3, got %d", count)
+	}
+}
+
+// TestPostgresUnitOfWork_CountPages validates the item total and page count without fetching rows
+func TestPostgresUnitOfWork_CountPages(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	entities := make([]*testutil.TestEntity, 7)
+	for i := range entities {
+		status := "active"
+		if i%2 == 1 {
+			status = "inactive"
+		}
+		entities[i] = &testutil.TestEntity{Name: fmt.Sprintf("Entity %d", i), Status: status}
+	}
+	if _, err := uow.BulkInsert(ctx, entities); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		pageSize      int
+		status        string
+		expectedTotal int64
+		expectedPages int
+	}{
+		{"Partial last page", 3, "", 7, 3},
+		{"Single page", 10, "", 7, 1},
+		{"Filtered", 2, "active", 4, 2},
+		{"No matches", 5, "archived", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := query.NewQueryParams[*testutil.TestEntity]()
+			params.PageSize = tt.pageSize
+			if tt.status != "" {
+				params.WithFilters(identifier.NewIdentifier().Equal("status", tt.status))
+			}
+
+			// Act
+			total, pages, err := uow.CountPages(ctx, params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if total != tt.expectedTotal {
+				t.Errorf("Expected total %d, got %d", tt.expectedTotal, total)
+			}
+			if pages != tt.expectedPages {
+				t.Errorf("Expected %d pages, got %d", tt.expectedPages, pages)
+			}
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_Count_ErrorOnEmptyIn(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithErrorOnEmptyIn())
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().In("id", []interface{}{}))
+
+	// Act
+	_, err := uow.Count(context.Background(), params)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected empty IN filter to be rejected")
+	}
+}
+
+// TestPostgresUnitOfWork_CountIncludingTrashed validates that live and soft-deleted rows are counted together
+func TestPostgresUnitOfWork_CountIncludingTrashed(t *testing.T) {
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected int64
+	}{
+		{"All rows", nil, 4},
+		{"Filtered", identifier.NewIdentifier().Equal("status", "active"), 3},
+		{"Only trashed match", identifier.NewIdentifier().Equal("name", "Entity 4"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			ctx := context.Background()
+			if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+				{Name: "Entity 1", Status: "active"},
+				{Name: "Entity 2", Status: "active"},
+				{Name: "Entity 3", Status: "inactive"},
+				{Name: "Entity 4", Status: "active"},
+			}); err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			trashed := identifier.NewIdentifier().In("name", []interface{}{"Entity 2", "Entity 4"})
+			if err := uow.Delete(ctx, trashed); err != nil {
+				t.Fatalf("Failed to soft delete entities: %v", err)
+			}
+
+			// Act
+			count, err := uow.CountIncludingTrashed(ctx, tt.ident)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected count %d, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_CountBy validates each combination of count options against live and trashed rows
+func TestPostgresUnitOfWork_CountBy(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "active"},
+		{Name: "Entity 3", Status: "inactive"},
+		{Name: "Entity 4", Status: "pending"},
+		{Name: "Entity 5", Status: "active"},
+	}); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	trashed := identifier.NewIdentifier().In("name", []interface{}{"Entity 2", "Entity 4"})
+	if err := uow.Delete(ctx, trashed); err != nil {
+		t.Fatalf("Failed to soft delete entities: %v", err)
+	}
+	active := identifier.NewIdentifier().Equal("status", "active")
+
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		opts     unit_of_work.CountOptions
+		expected int64
+	}{
+		{"Live only by default", nil, unit_of_work.CountOptions{}, 3},
+		{"Include deleted", nil, unit_of_work.CountOptions{IncludeDeleted: true}, 5},
+		{"Only deleted", nil, unit_of_work.CountOptions{OnlyDeleted: true}, 2},
+		{"Only deleted wins over include deleted", nil, unit_of_work.CountOptions{IncludeDeleted: true, OnlyDeleted: true}, 2},
+		{"Filtered live", active, unit_of_work.CountOptions{}, 2},
+		{"Filtered including deleted", active, unit_of_work.CountOptions{IncludeDeleted: true}, 3},
+		{"Filtered only deleted", active, unit_of_work.CountOptions{OnlyDeleted: true}, 1},
+		{"Distinct live", nil, unit_of_work.CountOptions{Distinct: "status"}, 2},
+		{"Distinct including deleted", nil, unit_of_work.CountOptions{Distinct: "status", IncludeDeleted: true}, 3},
+		{"Distinct only deleted", nil, unit_of_work.CountOptions{Distinct: "status", OnlyDeleted: true}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			count, err := uow.CountBy(ctx, tt.ident, tt.opts)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected count %d, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_CountBy_InvalidDistinct validates that an unsafe distinct field is rejected
+func TestPostgresUnitOfWork_CountBy_InvalidDistinct(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+
+	// Act
+	_, err := uow.CountBy(context.Background(), nil, unit_of_work.CountOptions{Distinct: "status) FROM x; --"})
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
+
+func TestPostgresUnitOfWork_Exists(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	entity := &testutil.TestEntity{
+		Name:        "Test Entity",
+		Description: "Test Description",
+		Status:      "active",
+	}
+
+	inserted, err := uow.Insert(ctx, entity)
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		identifier identifier.IIdentifier
+		expected   bool
+	}{
+		{
+			name:       "Entity exists",
+			identifier: identifier.NewIdentifier().Equal("id", inserted.GetID()),
+			expected:   true,
+		},
+		{
+			name:       "Entity does not exist",
+			identifier: identifier.NewIdentifier().Equal("id", 99999),
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			exists, err := uow.Exists(ctx, tt.identifier)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if exists != tt.expected {
+				t.Errorf("Expected exists %v, got %v", tt.expected, exists)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_MustExist validates that MustExist passes for live entities and
+// returns ErrEntityNotFound, matching gorm.ErrRecordNotFound too, for missing or trashed ones
+func TestPostgresUnitOfWork_MustExist(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "Live"}, {Name: "Trashed"}})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected error
+	}{
+		{"Live entity", identifier.NewIdentifier().Equal("id", entities[0].GetID()), nil},
+		{"Missing entity", identifier.NewIdentifier().Equal("id", 999), unit_of_work.ErrEntityNotFound},
+		{"Trashed entity", identifier.NewIdentifier().Equal("id", entities[1].GetID()), unit_of_work.ErrEntityNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := uow.MustExist(ctx, tt.ident)
+
+			// Assert
+			if !errors.Is(err, tt.expected) || (tt.expected == nil && err != nil) {
+				t.Errorf("Expected %v, got: %v", tt.expected, err)
+			}
+			if tt.expected != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("Expected the error to match gorm.ErrRecordNotFound, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_ExistsWhere validates an update-uniqueness check that excludes the row being updated
+func TestPostgresUnitOfWork_ExistsWhere(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "Self", Email: "self@example.com", Status: "active"},
+		{Name: "Other", Email: "other@example.com", Status: "active"},
+		{Name: "Deleted", Email: "deleted@example.com", Status: "active"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[2].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete test entity: %v", err)
+	}
+	excludeSelf := identifier.NewIdentifier().NotEqual("id", entities[0].GetID())
+
+	tests := []struct {
+		name     string
+		email    string
+		extra    identifier.IIdentifier
+		expected bool
+	}{
+		{"Own email is not a conflict", "self@example.com", excludeSelf, false},
+		{"Another row's email is a conflict", "other@example.com", excludeSelf, true},
+		{"Soft-deleted row is not a conflict", "deleted@example.com", excludeSelf, false},
+		{"OR in extra does not widen the identifier", "nobody@example.com", excludeSelf.Or(identifier.NewIdentifier().Equal("status", "active")), false},
+		{"Nil extra behaves like Exists", "self@example.com", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			exists, err := uow.ExistsWhere(ctx, identifier.NewIdentifier().Equal("email", tt.email), tt.extra)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if exists != tt.expected {
+				t.Errorf("Expected exists %v, got %v", tt.expected, exists)
+			}
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_FindAllWithPagination(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	// Insert test data
+	for i := 1; i <= 10; i++ {
+		entity := &testutil.TestEntity{
+			Name:   "Entity " + string(rune(i+'0')),
+			Status: "active",
+		}
+		_, err := uow.Insert(ctx, entity)
+		if err != nil {
+			t.Fatalf("Failed to insert test entity: %v", err)
+		}
+	}
+
+	queryParams := query.NewQueryParams[*testutil.TestEntity]()
+	queryParams.Limit = 5
+	queryParams.Offset = 0
+
+	// Act
+	results, total, err := uow.FindAllWithPagination(ctx, queryParams)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 5 {
+		t.Errorf("Expected 5 results, got %d", len(results))
+	}
+	if total != 10 {
+		t.Errorf("Expected total 10, got %d", total)
+	}
+}
+
+func TestPostgresUnitOfWork_Transaction_Lifecycle(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	// Act & Assert - Complete transaction lifecycle
+	err := uow.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+
+	// Insert entity within transaction
+	entity := &testutil.TestEntity{
+		Name:        "Transaction Entity",
+		Description: "Test in transaction",
+		Status:      "active",
+	}
+
+	inserted, err := uow.Insert(ctx, entity)
+	if err != nil {
+		t.Fatalf("Failed to insert entity in transaction: %v", err)
+	}
+
+	// Commit transaction
+	err = uow.CommitTransaction(ctx)
+	if err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+
+	// Verify entity persisted after commit
+	found, err := uow.FindOneById(ctx, inserted.GetID())
+	if err != nil {
+		t.Fatalf("Failed to find entity after commit: %v", err)
+	}
+	if found.GetID() != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), found.GetID())
+	}
+}
+
+func TestPostgresUnitOfWork_Transaction_Rollback_Lifecycle(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	// Act & Assert - Transaction rollback lifecycle
+	err := uow.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+
+	// Insert entity within transaction
+	entity := &testutil.TestEntity{
+		Name:        "Rollback Entity",
+		Description: "Test rollback",
+		Status:      "active",
+	}
+
+	inserted, err := uow.Insert(ctx, entity)
+	if err != nil {
+		t.Fatalf("Failed to insert entity in transaction: %v", err)
+	}
+
+	// Rollback transaction
+	uow.RollbackTransaction(ctx)
+
+	// Verify entity was not persisted after rollback
+	_, err = uow.FindOneById(ctx, inserted.GetID())
+	if err == nil {
+		t.Error("Expected entity to not exist after rollback, but it was found")
+	}
+}
+
+func TestPostgresUnitOfWork_ResolveIDByUniqueField(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	entity := &testutil.TestEntity{
+		Name:        "Unique Name",
+		Description: "Test Description",
+		Status:      "active",
+	}
+
+	inserted, err := uow.Insert(ctx, entity)
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	// Act
+	resolvedID, err := uow.ResolveIDByUniqueField(ctx, inserted, "name", "Unique Name")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resolvedID != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), resolvedID)
+	}
+}
+
+// TestPostgresUnitOfWork_IDTypes validates that IDs flow between GetID, ResolveIDByUniqueField,
+// FindOneById and FindByIDs through the IUnitOfWork interface without conversions
+func TestPostgresUnitOfWork_IDTypes(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	var uow unit_of_work.IUnitOfWork[*testutil.TestEntity] = NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	inserted, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Typed", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	// Act
+	id, err := uow.ResolveIDByUniqueField(ctx, inserted, "name", "Typed")
+	if err != nil {
+		t.Fatalf("Failed to resolve ID: %v", err)
+	}
+	found, findErr := uow.FindOneById(ctx, id)
+	batch, batchErr := uow.FindByIDs(ctx, []int{inserted.GetID(), id})
+
+	// Assert
+	if findErr != nil || batchErr != nil {
+		t.Fatalf("Expected no error, got: %v / %v", findErr, batchErr)
+	}
+	if found.GetID() != inserted.GetID() {
+		t.Errorf("Expected ID %d, got %d", inserted.GetID(), found.GetID())
+	}
+	if len(batch) != 1 || batch[0].GetID() != id {
+		t.Errorf("Expected one entity with ID %d, got %+v", id, batch)
+	}
+}
+
+// TestPostgresUnitOfWork_ResolveIDByFields validates resolution by a composite key
+func TestPostgresUnitOfWork_ResolveIDByFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      map[string]interface{}
+		expectedIdx int
+		expectErr   bool
+	}{
+		{"Composite match", map[string]interface{}{"status": "active", "email": "shared@example.com"}, 1, false},
+		{"Other half of the key", map[string]interface{}{"status": "inactive", "email": "shared@example.com"}, 0, false},
+		{"No match", map[string]interface{}{"status": "archived", "email": "shared@example.com"}, 0, true},
+		{"No fields", map[string]interface{}{}, 0, true},
+		{"Invalid field", map[string]interface{}{"email = email OR 1": 1}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+				{Name: "Inactive", Email: "shared@example.com", Status: "inactive"},
+				{Name: "Active", Email: "shared@example.com", Status: "active"},
+			})
+			if err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+
+			// Act
+			id, err := uow.ResolveIDByFields(ctx, &testutil.TestEntity{}, tt.fields)
+
+			// Assert
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got ID %d", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if id != entities[tt.expectedIdx].GetID() {
+				t.Errorf("Expected ID %d, got %d", entities[tt.expectedIdx].GetID(), id)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_BulkSoftDeleteE validates the summed affected count across identifiers
+func TestPostgresUnitOfWork_BulkSoftDeleteE(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "A", Status: "inactive"},
+		{Name: "B", Status: "inactive"},
+		{Name: "C", Status: "active"},
+		{Name: "D", Status: "active"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	identifiers := []identifier.IIdentifier{
+		identifier.NewIdentifier().Equal("status", "inactive"),
+		identifier.NewIdentifier().Equal("id", entities[2].GetID()),
+		identifier.NewIdentifier().Equal("id", entities[0].GetID()), // already trashed by the first identifier
+		identifier.NewIdentifier().Equal("name", "Missing"),
+	}
+
+	// Act
+	affected, err := uow.BulkSoftDeleteE(ctx, identifiers)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if affected != 3 {
+		t.Errorf("Expected 3 affected rows, got %d", affected)
+	}
+	remaining, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list entities: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "D" {
+		t.Errorf("Expected only D to remain, got %+v", remaining)
+	}
+}
+
+// TestPostgresUnitOfWork_SoftDeleteWhere validates that every live entity matching the filters
+// is trashed and that already trashed rows are not counted
+func TestPostgresUnitOfWork_SoftDeleteWhere(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "A", Status: "inactive"},
+		{Name: "B", Status: "inactive"},
+		{Name: "C", Status: "inactive"},
+		{Name: "D", Status: "active"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[2].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+	params := query.NewQueryParams[*testutil.TestEntity]().WithFilters(identifier.NewIdentifier().Equal("status", "inactive"))
+
+	// Act
+	affected, err := uow.SoftDeleteWhere(ctx, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if affected != 2 {
+		t.Errorf("Expected 2 affected rows, got %d", affected)
+	}
+	remaining, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list entities: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "D" {
+		t.Errorf("Expected only D to remain, got %+v", remaining)
+	}
+	trashed, err := uow.GetTrashed(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list trashed entities: %v", err)
+	}
+	if len(trashed) != 3 {
+		t.Errorf("Expected 3 trashed entities, got %d", len(trashed))
+	}
+}
+
+// TestPostgresUnitOfWork_SoftDeleteWhere_RawConditions validates that raw conditions narrow the
+// soft delete exactly as they narrow Count
+func TestPostgresUnitOfWork_SoftDeleteWhere_RawConditions(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "A", Status: "inactive"},
+		{Name: "B", Status: "inactive"},
+		{Name: "C", Status: "inactive"},
+	}); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("status", "inactive")).
+		WhereRaw("name <> ?", "B")
+
+	// Act
+	count, err := uow.Count(ctx, params)
+	if err != nil {
+		t.Fatalf("Failed to count entities: %v", err)
+	}
+	affected, err := uow.SoftDeleteWhere(ctx, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if affected != 2 || affected != count {
+		t.Errorf("Expected 2 affected rows matching Count %d, got %d", count, affected)
+	}
+	remaining, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list entities: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "B" {
+		t.Errorf("Expected only B to remain, got %+v", remaining)
+	}
+}
+
+// TestPostgresUnitOfWork_SoftDeleteWhere_WithoutFilters validates that params without filters
+// are refused rather than trashing the whole table
+func TestPostgresUnitOfWork_SoftDeleteWhere_WithoutFilters(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Entity", Status: "active"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	// Act
+	affected, err := uow.SoftDeleteWhere(ctx, query.NewQueryParams[*testutil.TestEntity]())
+
+	// Assert
+	if !errors.Is(err, unit_of_work.ErrUnboundedDelete) {
+		t.Errorf("Expected ErrUnboundedDelete, got: %v", err)
+	}
+	if affected != 0 {
+		t.Errorf("Expected 0 affected rows, got %d", affected)
+	}
+	if count, _ := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]()); count != 1 {
+		t.Errorf("Expected the entity to stay live, got %d live entities", count)
+	}
+}
+
+// TestFindAllMapped validates that entities read through the unit of work are transformed
+func TestFindAllMapped(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if _, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "First"}, {Name: "Second"}}); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+
+	// Act
+	names, err := unit_of_work.FindAllMapped(ctx, uow, func(e *testutil.TestEntity) string { return e.Name })
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(names) != 2 || names[0] != "First" || names[1] != "Second" {
+		t.Errorf("Expected [First Second], got %v", names)
+	}
+}
+
+// TestPostgresUnitOfWork_FindAllWithPagination_CountOnlyFirstPage validates that later pages skip the count
+func TestPostgresUnitOfWork_FindAllWithPagination_CountOnlyFirstPage(t *testing.T) {
+	tests := []struct {
+		name          string
+		page          int
+		expectedTotal int64
+		expectedRows  int
+		expectedCount int
+	}{
+		{"First page counts", 1, 5, 2, 2},
+		{"Second page skips count", 2, -1, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			entities := make([]*testutil.TestEntity, 5)
+			for i := range entities {
+				entities[i] = &testutil.TestEntity{Name: fmt.Sprintf("Entity %d", i)}
+			}
+			if _, err := uow.BulkInsert(ctx, entities); err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			statements := countStatements(t, db)
+			params := query.NewQueryParams[*testutil.TestEntity]().WithCountOnlyFirstPage()
+			params.Page, params.PageSize = tt.page, 2
+			params.PrepareDefaults()
+
+			// Act
+			results, total, err := uow.FindAllWithPagination(ctx, params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if total != tt.expectedTotal {
+				t.Errorf("Expected total %d, got %d", tt.expectedTotal, total)
+			}
+			if len(results) != tt.expectedRows {
+				t.Errorf("Expected %d rows, got %d", tt.expectedRows, len(results))
+			}
+			if *statements != tt.expectedCount {
+				t.Errorf("Expected %d queries, got %d", tt.expectedCount, *statements)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_DeleteE validates the rows-affected count returned by DeleteE
+func TestPostgresUnitOfWork_DeleteE(t *testing.T) {
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected int64
+	}{
+		{"Matching rows", identifier.NewIdentifier().Equal("status", "active"), 2},
+		{"No matching rows", identifier.NewIdentifier().Equal("status", "archived"), 0},
+		{"Already trashed rows", identifier.NewIdentifier().Equal("status", "trashed"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			_, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+				{Name: "A", Status: "active"},
+				{Name: "B", Status: "active"},
+				{Name: "C", Status: "trashed"},
+			})
+			if err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			if err := uow.Delete(ctx, identifier.NewIdentifier().Equal("status", "trashed")); err != nil {
+				t.Fatalf("Failed to trash entity: %v", err)
+			}
+
+			// Act
+			affected, err := uow.DeleteE(ctx, tt.ident)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if affected != tt.expected {
+				t.Errorf("Expected %d affected rows, got %d", tt.expected, affected)
+			}
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_RestoreAll(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	// Insert and soft-delete multiple entities
+	entities := []*testutil.TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "active"},
+		{Name: "Entity 3", Status: "active"},
+	}
+
+	var insertedIDs []int
+	for _, entity := range entities {
+		inserted, err := uow.Insert(ctx, entity)
+		if err != nil {
+			t.Fatalf("Failed to insert entity: %v", err)
+		}
+		insertedIDs = append(insertedIDs, inserted.GetID())
+
+		identifierBuilder := identifier.NewIdentifier().Equal("id", inserted.GetID())
+
+		_, err = uow.SoftDelete(ctx, identifierBuilder)
+		if err != nil {
+			t.Fatalf("Failed to soft delete entity: %v", err)
+		}
+	}
+
+	// Act
+	err := uow.RestoreAll(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify all entities are restored
+	for _, id := range insertedIDs {
+		found, findErr := uow.FindOneById(ctx, id)
+		if findErr != nil {
+			t.Errorf("Expected to find restored entity with ID %d, got error: %v", id, findErr)
+		}
+		if found.GetID() != id {
+			t.Errorf("Expected ID %d, got %d", id, found.GetID())
+		}
+	}
+}
+
+func TestPostgresUnitOfWork_Error_Cases(t *testing.T) {
+	tests := []struct {
+		name        string
+		testFunc    func(*testing.T, unit_of_work.IUnitOfWork[*testutil.TestEntity])
+		expectError bool
+	}{
+		{
+			name: "FindOneById with non-existent ID",
+			testFunc: func(t *testing.T, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) {
+				_, err := uow.FindOneById(context.Background(), 99999)
+				if err == nil {
+					t.Error("Expected error for non-existent ID")
+				}
+			},
+			expectError: true,
+		},
+		{
+			name: "Update non-existent entity",
+			testFunc: func(t *testing.T, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) {
+				identifierBuilder := identifier.NewIdentifier().Equal("id", 99999)
+				entity := &testutil.TestEntity{Name: "Non-existent"}
+				_, err := uow.Update(context.Background(), identifierBuilder, entity)
+				if err == nil {
+					t.Error("Expected error for updating non-existent entity")
+				}
+			},
+			expectError: true,
+		},
+		{
+			name: "SoftDelete non-existent entity",
+			testFunc: func(t *testing.T, uow unit_of_work.IUnitOfWork[*testutil.TestEntity]) {
+				identifierBuilder := identifier.NewIdentifier().Equal("id", 99999)
+				_, err := uow.SoftDelete(context.Background(), identifierBuilder)
+				if err == nil {
+					t.Error("Expected error for soft deleting non-existent entity")
+				}
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+
+			// Act & Assert
+			tt.testFunc(t, uow)
+		})
+	}
+}
+
+func TestPostgresUnitOfWork_QueryBuilder(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx := context.Background()
+
+	for i, status := range []string{"active", "inactive", "active", "active"} {
+		entity := &testutil.TestEntity{Name: fmt.Sprintf("Entity %d", i), Status: status}
+		if _, err := uow.Insert(ctx, entity); err != nil {
+			t.Fatalf("Failed to insert test entity: %v", err)
+		}
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("name", "Entity 3")); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("status", "active")).
+		AddSortDesc("name").
+		PrepareDefaults()
+
+	expected, _, err := uow.FindAllWithPagination(ctx, params)
+	if err != nil {
+		t.Fatalf("Failed to find entities: %v", err)
+	}
+
+	// Act
+	var results []*testutil.TestEntity
+	err = uow.QueryBuilder(ctx, params).Find(&results).Error
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != len(expected) || len(results) != 2 {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for i := range expected {
+		if results[i].GetID() != expected[i].GetID() {
+			t.Errorf("Result %d: expected ID %d, got %d", i, expected[i].GetID(), results[i].GetID())
+		}
+	}
+}
+
+func TestPostgresUnitOfWork_QueryBuilder_SharesTransaction(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx := context.Background()
+
+	if err := uow.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Uncommitted"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	// Act
+	var count int64
+	err := uow.QueryBuilder(ctx, query.NewQueryParams[*testutil.TestEntity]()).Count(&count).Error
+	uow.RollbackTransaction(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected uncommitted row to be visible inside the transaction, got count %d", count)
+	}
+}
+
+func TestPostgresUnitOfWork_WithScopes(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	activeOnly := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("status = ?", "active")
+	}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithScopes(activeOnly))
+	entities := []*testutil.TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "inactive"},
+		{Name: "Entity 3", Status: "active"},
+	}
+	if _, err := uow.BulkInsert(ctx, entities); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+
+	// Act
+	all, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	_, err = uow.FindOneById(ctx, entities[1].GetID())
+	sql := dryRunSQL(uow.(*PostgresUnitOfWork[*testutil.TestEntity]).QueryBuilder(ctx, query.NewQueryParams[*testutil.TestEntity]()))
+
+	// Assert
+	if len(all) != 2 {
+		t.Errorf("Expected 2 scoped entities, got %d", len(all))
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected out-of-scope entity to be hidden, got: %v", err)
+	}
+	if !strings.Contains(sql, "status = ?") {
+		t.Errorf("Expected scope condition in generated SQL, got: %s", sql)
+	}
+}
+
+// countStatements registers callbacks that count every query, update and create statement
+func countStatements(t *testing.T, db *gorm.DB) *int {
+	t.Helper()
+
+	statements := 0
+	count := func(tx *gorm.DB) { statements++ }
+	if err := db.Callback().Query().Before("gorm:query").Register("test:count_query", count); err != nil {
+		t.Fatalf("Failed to register query callback: %v", err)
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("test:count_update", count); err != nil {
+		t.Fatalf("Failed to register update callback: %v", err)
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register("test:count_create", count); err != nil {
+		t.Fatalf("Failed to register create callback: %v", err)
+	}
+	return &statements
+}
+
+// TestPostgresUnitOfWork_Update_SkipPreCheck validates that the update is issued as a single statement
+func TestPostgresUnitOfWork_Update_SkipPreCheck(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithSkipUpdatePreCheck())
+	entity, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Original", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	statements := countStatements(t, db)
+	entity.Name = "Updated"
+
+	// Act
+	updated, err := uow.Update(ctx, identifier.NewIdentifier().Equal("id", entity.GetID()), entity)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if *statements != 1 {
+		t.Errorf("Expected 1 statement, got %d", *statements)
+	}
+	if updated.Name != "Updated" {
+		t.Errorf("Expected name 'Updated', got %s", updated.Name)
+	}
+	var stored testutil.TestEntity
+	if err := db.First(&stored, entity.GetID()).Error; err != nil {
+		t.Fatalf("Failed to load updated entity: %v", err)
+	}
+	if stored.Name != "Updated" || stored.Status != "active" {
+		t.Errorf("Expected stored entity to be updated, got %+v", stored)
+	}
+}
+
+// TestPostgresUnitOfWork_Update_SkipPreCheck_NotFound validates that a missing row is reported
+func TestPostgresUnitOfWork_Update_SkipPreCheck_NotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		trashed  bool
+		mismatch bool
+	}{
+		{"Unknown ID", false, false},
+		{"Soft-deleted row", true, false},
+		{"Identifier mismatch", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithSkipUpdatePreCheck())
+			entity, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Original", Status: "active"})
+			if err != nil {
+				t.Fatalf("Failed to insert test entity: %v", err)
+			}
+			id := identifier.NewIdentifier().Equal("id", entity.GetID())
+			if tt.trashed {
+				if _, err := uow.SoftDelete(ctx, id); err != nil {
+					t.Fatalf("Failed to soft delete entity: %v", err)
+				}
+			}
+			if tt.mismatch {
+				id = id.Equal("status", "inactive")
+			}
+			target := &testutil.TestEntity{Name: "Updated", Status: "active"}
+			target.ID = entity.ID
+			if !tt.trashed && !tt.mismatch {
+				target.ID = 99999
+			}
+			statements := countStatements(t, db)
+
+			// Act
+			_, err = uow.Update(ctx, id, target)
+
+			// Assert
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Fatalf("Expected gorm.ErrRecordNotFound, got: %v", err)
+			}
+			if *statements != 1 {
+				t.Errorf("Expected 1 statement, got %d", *statements)
+			}
+			var count int64
+			db.Unscoped().Model(&testutil.TestEntity{}).Where("name = ?", "Updated").Count(&count)
+			if count != 0 {
+				t.Errorf("Expected no row to be written, got %d", count)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_BulkInsert_BatchSize validates that large inserts are split into batches
+func TestPostgresUnitOfWork_BulkInsert_BatchSize(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithBulkInsertBatchSize(2))
+	entities := make([]*testutil.TestEntity, 5)
+	for i := range entities {
+		entities[i] = &testutil.TestEntity{Name: fmt.Sprintf("Entity %d", i), Status: "active"}
+	}
+	statements := countStatements(t, db)
+
+	// Act
+	result, err := uow.BulkInsert(ctx, entities)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if *statements != 3 {
+		t.Errorf("Expected 3 insert statements, got %d", *statements)
+	}
+	for i, entity := range result {
+		if entity.GetID() == 0 {
+			t.Errorf("Expected entity %d to have an ID", i)
+		}
+	}
+	count, err := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]())
+	if err != nil {
+		t.Fatalf("Failed to count entities: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 stored entities, got %d", count)
+	}
+}
+
+// TestPostgresUnitOfWork_FindChangedSince validates that only rows updated at or after the
+// cutoff are returned, oldest change first, with trashed rows only when requested
+func TestPostgresUnitOfWork_FindChangedSince(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+
+	since := time.Now().Add(-time.Hour)
+	entities := []*testutil.TestEntity{
+		{Name: "Untouched", Status: "active"},
+		{Name: "Updated later", Status: "active"},
+		{Name: "Updated first", Status: "active"},
+		{Name: "Trashed", Status: "active"},
+	}
+	if _, err := uow.BulkInsert(ctx, entities); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	for _, entity := range entities {
+		if err := db.Model(entity).UpdateColumn("updated_at", since.Add(-24*time.Hour)).Error; err != nil {
+			t.Fatalf("Failed to backdate entity: %v", err)
+		}
+	}
+	if err := db.Model(entities[2]).UpdateColumn("updated_at", since.Add(time.Minute)).Error; err != nil {
+		t.Fatalf("Failed to update entity: %v", err)
+	}
+	if err := db.Model(entities[1]).UpdateColumn("updated_at", since.Add(2*time.Minute)).Error; err != nil {
+		t.Fatalf("Failed to update entity: %v", err)
+	}
+	// Soft deletion leaves updated_at alone, so the row is only found through deleted_at
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[3].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   *query.QueryParams[*testutil.TestEntity]
+		expected []string
+	}{
+		{"Without params", nil, []string{"Updated first", "Updated later"}},
+		{"Live only", query.NewQueryParams[*testutil.TestEntity](), []string{"Updated first", "Updated later"}},
+		{
+			"With filters",
+			query.NewQueryParams[*testutil.TestEntity]().WithFilters(identifier.NewIdentifier().Equal("name", "Updated later")),
+			[]string{"Updated later"},
+		},
+		{
+			"Including trashed",
+			query.NewQueryParams[*testutil.TestEntity]().IncludeDeletedRecords(),
+			[]string{"Trashed", "Updated first", "Updated later"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := uow.FindChangedSince(ctx, since, tt.params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			names := make([]string, len(result))
+			for i, entity := range result {
+				names[i] = entity.Name
+				if entity.Name == "Trashed" && !entity.DeletedAt.Valid {
+					t.Errorf("Expected the trashed entity to carry its deleted_at")
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_FindTop validates that exactly n matching live entities are returned
+// in the requested order without a COUNT
+func TestPostgresUnitOfWork_FindTop(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{
+		{Name: "A", Status: "active", Age: 30},
+		{Name: "B", Status: "active", Age: 50},
+		{Name: "C", Status: "inactive", Age: 90},
+		{Name: "D", Status: "active", Age: 40},
+		{Name: "E", Status: "active", Age: 70},
+		{Name: "F", Status: "active", Age: 20},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[4].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+	byAge := []query.SortField{{Field: "age", Order: query.SortOrderDesc}}
+
+	tests := []struct {
+		name     string
+		n        int
+		sort     []query.SortField
+		ident    identifier.IIdentifier
+		expected []string
+	}{
+		{"Top by sort", 3, byAge, nil, []string{"C", "B", "D"}},
+		{"Filtered", 3, byAge, identifier.NewIdentifier().Equal("status", "active"), []string{"B", "D", "A"}},
+		{"Fewer matches than n", 10, byAge, identifier.NewIdentifier().Equal("status", "inactive"), []string{"C"}},
+		{"Default sort", 2, nil, nil, []string{"A", "B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := 0
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithSQLCapture(func(ctx context.Context, statement CapturedSQL) {
+				statements++
+			}))
+
+			// Act
+			results, err := uow.FindTop(ctx, tt.n, tt.sort, tt.ident)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if statements != 1 {
+				t.Errorf("Expected a single query without a count, got %d statements", statements)
+			}
+			names := make([]string, len(results))
+			for i, result := range results {
+				names[i] = result.Name
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_FindTop_InvalidLimit validates that a non-positive n is rejected
+func TestPostgresUnitOfWork_FindTop_InvalidLimit(t *testing.T) {
+	// Arrange
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t))
+
+	// Act
+	_, err := uow.FindTop(context.Background(), 0, nil, nil)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected ValidationError, got: %v", err)
+	}
+}
+
+// postgresDialector reports itself as postgres while delegating everything else
+type postgresDialector struct {
+	gorm.Dialector
+}
+
+// Name returns the postgres dialect name
+func (postgresDialector) Name() string {
+	return "postgres"
+}
+
+// TestPostgresUnitOfWork_Dialect validates that the dialect of the underlying connection is reported
+func TestPostgresUnitOfWork_Dialect(t *testing.T) {
+	// Arrange
+	sqliteDB := testutil.SetupTestDB(t)
+	postgresDB := sqliteDB.Session(&gorm.Session{})
+	postgresDB.Config = &gorm.Config{Dialector: postgresDialector{sqliteDB.Dialector}}
+
+	tests := []struct {
+		name     string
+		uow      unit_of_work.IUnitOfWork[*testutil.TestEntity]
+		expected string
+	}{
+		{"SQLite", NewPostgresUnitOfWork[*testutil.TestEntity](sqliteDB), "sqlite"},
+		{"PostgreSQL", NewPostgresUnitOfWork[*testutil.TestEntity](postgresDB), "postgres"},
+		{"Circuit breaker", NewCircuitBreakerUnitOfWork(NewPostgresUnitOfWork[*testutil.TestEntity](sqliteDB)), "sqlite"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			dialect := tt.uow.Dialect()
+
+			// Assert
+			if dialect != tt.expected {
+				t.Errorf("Expected dialect %q, got %q", tt.expected, dialect)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_IncludeDeleted_HydratesDeletedAt validates that a result set mixing live
+// and trashed rows carries the deletion time of the trashed ones only
+func TestPostgresUnitOfWork_IncludeDeleted_HydratesDeletedAt(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "Live"}, {Name: "Trashed"}, {Name: "Also live"}})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	// Act
+	result, total, err := uow.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]().IncludeDeletedRecords())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if total != 3 || len(result) != 3 {
+		t.Fatalf("Expected 3 entities, got %d (total %d)", len(result), total)
+	}
+	for _, entity := range result {
+		deletedAt := entity.GetDeletedAt()
+		if entity.Name == "Trashed" && deletedAt == nil {
+			t.Errorf("Expected the trashed entity to have DeletedAt set")
+		}
+		if entity.Name != "Trashed" && deletedAt != nil {
+			t.Errorf("Expected live entity %q to have no DeletedAt, got %v", entity.Name, *deletedAt)
+		}
+	}
+}
+
+// TestPostgresUnitOfWork_BulkHardDelete_Atomic validates that a failing identifier rolls back
+// the deletes of the identifiers before it
+func TestPostgresUnitOfWork_BulkHardDelete_Atomic(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	entities, err := uow.BulkInsert(ctx, []*testutil.TestEntity{{Name: "A"}, {Name: "B"}})
+	if err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	identifiers := []identifier.IIdentifier{
+		identifier.NewIdentifier().Equal("name", "A"),
+		nil, // unbounded, rejected with ErrUnboundedDelete
+	}
+
+	// Act
+	err = uow.BulkHardDelete(ctx, identifiers)
+
+	// Assert
+	if !errors.Is(err, unit_of_work.ErrUnboundedDelete) {
+		t.Fatalf("Expected ErrUnboundedDelete, got: %v", err)
+	}
+	if _, err := uow.FindOneById(ctx, entities[0].GetID()); err != nil {
+		t.Errorf("Expected the first entity to survive the failed bulk delete, got: %v", err)
+	}
+}
+
+// TestPostgresUnitOfWork_BulkDelete_CoalescesIDs validates that bulk deletes by plain ID
+// equalities run as a single IN statement
+func TestPostgresUnitOfWork_BulkDelete_CoalescesIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		delete   func(uow unit_of_work.IUnitOfWork[*testutil.TestEntity], identifiers []identifier.IIdentifier) error
+		unscoped bool
+	}{
+		{"Soft delete", func(uow unit_of_work.IUnitOfWork[*testutil.TestEntity], identifiers []identifier.IIdentifier) error {
+			return uow.BulkSoftDelete(context.Background(), identifiers)
+		}, false},
+		{"Hard delete", func(uow unit_of_work.IUnitOfWork[*testutil.TestEntity], identifiers []identifier.IIdentifier) error {
+			return uow.BulkHardDelete(context.Background(), identifiers)
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			entities := make([]*testutil.TestEntity, 100)
+			for i := range entities {
+				entities[i] = &testutil.TestEntity{Name: fmt.Sprintf("Entity %d", i), Status: "active"}
+			}
+			if _, err := uow.BulkInsert(context.Background(), entities); err != nil {
+				t.Fatalf("Failed to insert test entities: %v", err)
+			}
+			identifiers := make([]identifier.IIdentifier, len(entities))
+			for i, entity := range entities {
+				identifiers[i] = identifier.NewIdentifier().Equal("id", entity.GetID())
+			}
+
+			var statements []string
+			record := func(tx *gorm.DB) { statements = append(statements, tx.Statement.SQL.String()) }
+			if err := db.Callback().Delete().After("gorm:delete").Register("test:record_delete", record); err != nil {
+				t.Fatalf("Failed to register delete callback: %v", err)
+			}
+
+			// Act
+			err := tt.delete(uow, identifiers)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(statements) != 1 {
+				t.Fatalf("Expected 1 delete statement, got %d", len(statements))
+			}
+			if !strings.Contains(statements[0], "id IN (") {
+				t.Errorf("Expected an IN statement, got: %s", statements[0])
+			}
+			var remaining int64
+			scope := db.Model(&testutil.TestEntity{})
+			if tt.unscoped {
+				scope = scope.Unscoped()
+			}
+			if err := scope.Count(&remaining).Error; err != nil {
+				t.Fatalf("Failed to count remaining entities: %v", err)
+			}
+			if remaining != 0 {
+				t.Errorf("Expected no remaining entities, got %d", remaining)
+			}
+		})
+	}
+}
+
+// TestCoalesceIDIdentifiers validates that only lists of plain ID equalities are coalesced, in chunks
+func TestCoalesceIDIdentifiers(t *testing.T) {
+	manyIDs := make([]identifier.IIdentifier, bulkDeleteChunkSize+1)
+	for i := range manyIDs {
+		manyIDs[i] = identifier.NewIdentifier().Equal("id", i+1)
+	}
+
+	tests := []struct {
+		name        string
+		identifiers []identifier.IIdentifier
+		expected    int
+		coalesced   bool
+	}{
+		{"Plain IDs", []identifier.IIdentifier{identifier.NewIdentifier().Equal("id", 1), identifier.NewIdentifier().Equal("ID", 2)}, 1, true},
+		{"Chunked IDs", manyIDs, 2, true},
+		{"Other field", []identifier.IIdentifier{identifier.NewIdentifier().Equal("id", 1), identifier.NewIdentifier().Equal("email", "a@b.c")}, 2, false},
+		{"Compound identifier", []identifier.IIdentifier{identifier.NewIdentifier().Equal("id", 1).Equal("status", "active")}, 1, false},
+		{"Other operator", []identifier.IIdentifier{identifier.NewIdentifier().GreaterThan("id", 1)}, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := coalesceIDIdentifiers(tt.identifiers)
+
+			// Assert
+			if len(result) != tt.expected {
+				t.Fatalf("Expected %d identifiers, got %d", tt.expected, len(result))
+			}
+			isIn := result[0].ToFilterCriteria()[0].Operator == identifier.FilterOperatorIn
+			if isIn != tt.coalesced {
+				t.Errorf("Expected coalesced=%v, got %v", tt.coalesced, isIn)
+			}
 		})
 	}
 }
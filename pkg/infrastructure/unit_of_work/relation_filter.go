@@ -0,0 +1,91 @@
+package unit_of_work
+
+import (
+	"fmt"
+	"strings"
+
+	queryparams "github.com/ai-shiraz-teams/go-database/internal/shared/query"
+
+	"gorm.io/gorm"
+)
+
+// ApplyRelationFilters translates relation filters into correlated EXISTS subqueries,
+// or NOT EXISTS for negated filters.
+// Relations are resolved from the GORM schema of the query's model, so only associations
+// declared on the entity (has-one, has-many, belongs-to, including polymorphic ones) can be
+// referenced.
+func (fa *FilterApplier) ApplyRelationFilters(query *gorm.DB, relationFilters []queryparams.RelationFilter) *gorm.DB {
+	for _, relationFilter := range relationFilters {
+		subQuery, err := fa.buildRelationSubQuery(query, relationFilter)
+		if err != nil {
+			_ = query.AddError(err)
+			return query
+		}
+		if relationFilter.Negate {
+			query = query.Where("NOT EXISTS (?)", subQuery)
+		} else {
+			query = query.Where("EXISTS (?)", subQuery)
+		}
+	}
+	return query
+}
+
+// buildRelationSubQuery builds "SELECT 1 FROM related WHERE related.fk = parent.pk AND <filters>"
+func (fa *FilterApplier) buildRelationSubQuery(query *gorm.DB, relationFilter queryparams.RelationFilter) (*gorm.DB, error) {
+	stmt := query.Statement
+	if stmt.Schema == nil {
+		if err := stmt.Parse(stmt.Model); err != nil {
+			return nil, fmt.Errorf("failed to resolve schema for relation %q: %w", relationFilter.Relation, err)
+		}
+	}
+
+	relationship, ok := stmt.Schema.Relationships.Relations[relationFilter.Relation]
+	if !ok {
+		return nil, fmt.Errorf("unknown relation %q on %s", relationFilter.Relation, stmt.Schema.Name)
+	}
+	if relationship.JoinTable != nil || len(relationship.References) == 0 {
+		return nil, fmt.Errorf("relation %q of type %s is not supported in relation filters", relationFilter.Relation, relationship.Type)
+	}
+
+	parentTable := stmt.Table
+	if parentTable == "" {
+		parentTable = stmt.Schema.Table
+	}
+	relatedTable := relationship.FieldSchema.Table
+
+	conditions := make([]string, 0, len(relationship.References))
+	var args []interface{}
+	for _, reference := range relationship.References {
+		if reference.PrimaryKey == nil {
+			// Polymorphic type references compare the related type column with the owner's
+			// type value, so rows of other owner types with the same id do not match
+			conditions = append(conditions, fmt.Sprintf("%s.%s = ?", relatedTable, reference.ForeignKey.DBName))
+			args = append(args, reference.PrimaryValue)
+			continue
+		}
+		if reference.OwnPrimaryKey {
+			// has-one / has-many: the related table holds the foreign key
+			conditions = append(conditions, fmt.Sprintf("%s.%s = %s.%s",
+				relatedTable, reference.ForeignKey.DBName, parentTable, reference.PrimaryKey.DBName))
+		} else {
+			// belongs-to: the parent table holds the foreign key
+			conditions = append(conditions, fmt.Sprintf("%s.%s = %s.%s",
+				relatedTable, reference.PrimaryKey.DBName, parentTable, reference.ForeignKey.DBName))
+		}
+	}
+
+	subQuery := query.Session(&gorm.Session{NewDB: true}).
+		Table(relatedTable).
+		Select("1").
+		Where(strings.Join(conditions, " AND "), args...)
+
+	if _, ok := relationship.FieldSchema.FieldsByDBName[fa.softDeleteColumn()]; ok {
+		subQuery = subQuery.Where(fa.deletedCondition(relatedTable, false))
+	}
+
+	if len(relationFilter.Filters) > 0 {
+		subQuery = subQuery.Where(fa.ApplyFilters(query.Session(&gorm.Session{NewDB: true}), relationFilter.Filters))
+	}
+
+	return subQuery, nil
+}
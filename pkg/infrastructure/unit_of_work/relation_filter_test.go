@@ -0,0 +1,232 @@
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// seedEntitiesWithOrders creates entities with different order sets for relation filter tests
+func seedEntitiesWithOrders(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	entities := []*testutil.TestEntity{
+		{Name: "Paid Customer", Orders: []testutil.TestOrder{{Status: "paid"}, {Status: "pending"}}},
+		{Name: "Pending Customer", Orders: []testutil.TestOrder{{Status: "pending"}}},
+		{Name: "No Orders"},
+	}
+	for _, entity := range entities {
+		if err := db.Create(entity).Error; err != nil {
+			t.Fatalf("Failed to seed entity: %v", err)
+		}
+	}
+}
+
+// TestFilterApplier_ApplyRelationFilters validates EXISTS filtering on a has-many relation
+func TestFilterApplier_ApplyRelationFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected []string
+	}{
+		{
+			name:     "Has matching related row",
+			ident:    identifier.NewIdentifier().Equal("status", "paid"),
+			expected: []string{"Paid Customer"},
+		},
+		{
+			name:     "Has any related row",
+			ident:    nil,
+			expected: []string{"Paid Customer", "Pending Customer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			seedEntitiesWithOrders(t, db)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			params := query.NewQueryParams[*testutil.TestEntity]().WhereHas("Orders", tt.ident).PrepareDefaults()
+
+			// Act
+			results, total, err := uow.FindAllWithPagination(context.Background(), params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if int(total) != len(tt.expected) || len(results) != len(tt.expected) {
+				t.Fatalf("Expected %d entities, got %d (total %d)", len(tt.expected), len(results), total)
+			}
+			for i, name := range tt.expected {
+				if results[i].Name != name {
+					t.Errorf("Expected entity %q, got %q", name, results[i].Name)
+				}
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyRelationFilters_Negated validates NOT EXISTS filtering on a has-many relation
+func TestFilterApplier_ApplyRelationFilters_Negated(t *testing.T) {
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected []string
+	}{
+		{
+			name:     "No matching related row",
+			ident:    identifier.NewIdentifier().Equal("status", "paid"),
+			expected: []string{"Pending Customer", "No Orders"},
+		},
+		{
+			name:     "No related row at all",
+			ident:    nil,
+			expected: []string{"No Orders"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			seedEntitiesWithOrders(t, db)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+			params := query.NewQueryParams[*testutil.TestEntity]().WhereDoesntHave("Orders", tt.ident).PrepareDefaults()
+
+			// Act
+			results, total, err := uow.FindAllWithPagination(context.Background(), params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if int(total) != len(tt.expected) || len(results) != len(tt.expected) {
+				t.Fatalf("Expected %d entities, got %d (total %d)", len(tt.expected), len(results), total)
+			}
+			for i, name := range tt.expected {
+				if results[i].Name != name {
+					t.Errorf("Expected entity %q, got %q", name, results[i].Name)
+				}
+			}
+		})
+	}
+}
+
+// TestFilterApplier_ApplyRelationFilters_IgnoresSoftDeletedRelated validates that trashed related rows do not match
+func TestFilterApplier_ApplyRelationFilters_IgnoresSoftDeletedRelated(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	seedEntitiesWithOrders(t, db)
+	if err := db.Where("status = ?", "paid").Delete(&testutil.TestOrder{}).Error; err != nil {
+		t.Fatalf("Failed to soft delete orders: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	params := query.NewQueryParams[*testutil.TestEntity]().WhereHas("Orders", identifier.NewIdentifier().Equal("status", "paid"))
+
+	// Act
+	count, err := uow.Count(context.Background(), params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 entities, got %d", count)
+	}
+}
+
+// TestFilterApplier_ApplyRelationFilters_UnknownRelation validates that unknown relations surface an error
+func TestFilterApplier_ApplyRelationFilters_UnknownRelation(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	params := query.NewQueryParams[*testutil.TestEntity]().WhereHas("Invoices", nil)
+
+	// Act
+	_, err := uow.Count(context.Background(), params)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for unknown relation")
+	}
+}
+
+// polymorphicComment belongs to either a commentedPost or a commentedPhoto
+type polymorphicComment struct {
+	types.BaseEntity
+	Body      string `gorm:"column:body"`
+	OwnerID   int    `gorm:"column:owner_id"`
+	OwnerType string `gorm:"column:owner_type"`
+}
+
+// TableName returns the table name for GORM
+func (pc *polymorphicComment) TableName() string {
+	return "polymorphic_comments"
+}
+
+// commentedPost owns comments through a polymorphic relation
+type commentedPost struct {
+	types.BaseEntity
+	Title    string               `gorm:"column:title"`
+	Comments []polymorphicComment `gorm:"polymorphic:Owner;"`
+}
+
+// TableName returns the table name for GORM
+func (cp *commentedPost) TableName() string {
+	return "commented_posts"
+}
+
+// commentedPhoto owns comments through the same polymorphic relation as commentedPost
+type commentedPhoto struct {
+	types.BaseEntity
+	Caption  string               `gorm:"column:caption"`
+	Comments []polymorphicComment `gorm:"polymorphic:Owner;"`
+}
+
+// TableName returns the table name for GORM
+func (cp *commentedPhoto) TableName() string {
+	return "commented_photos"
+}
+
+// TestFilterApplier_ApplyRelationFilters_Polymorphic validates that a polymorphic relation only
+// matches related rows of the owner's type, not rows of other owners sharing the same id
+func TestFilterApplier_ApplyRelationFilters_Polymorphic(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	if err := db.AutoMigrate(&polymorphicComment{}, &commentedPost{}, &commentedPhoto{}); err != nil {
+		t.Fatalf("Failed to migrate polymorphic entities: %v", err)
+	}
+	posts := []*commentedPost{{Title: "Commented"}, {Title: "Quiet"}}
+	if err := db.Create(&posts).Error; err != nil {
+		t.Fatalf("Failed to seed posts: %v", err)
+	}
+	// The photo shares its id with the quiet post, so an unqualified join would match it
+	photos := []*commentedPhoto{{Caption: "First"}, {Caption: "Second", Comments: []polymorphicComment{{Body: "Nice photo"}}}}
+	if err := db.Create(&photos).Error; err != nil {
+		t.Fatalf("Failed to seed photos: %v", err)
+	}
+	if err := db.Model(posts[0]).Association("Comments").Append(&polymorphicComment{Body: "Nice post"}); err != nil {
+		t.Fatalf("Failed to seed post comment: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*commentedPost](db)
+	params := query.NewQueryParams[*commentedPost]().WhereHas("Comments", nil).PrepareDefaults()
+
+	// Act
+	results, _, err := uow.FindAllWithPagination(context.Background(), params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Commented" {
+		t.Errorf("Expected only the commented post, got %+v", results)
+	}
+}
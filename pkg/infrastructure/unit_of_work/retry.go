@@ -0,0 +1,110 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// connectionErrorMessages lists error fragments reported by drivers when the connection was lost
+var connectionErrorMessages = []string{
+	"connection reset",
+	"broken pipe",
+	"connection refused",
+	"bad connection",
+	"unexpected eof",
+}
+
+// transientTransactionStates are the SQLSTATE codes after which a transaction can be safely retried
+var transientTransactionStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// transientTransactionMessages lists error fragments reported for retryable transaction failures
+var transientTransactionMessages = []string{
+	"could not serialize access",
+	"deadlock detected",
+}
+
+// sqlStateError is implemented by driver errors exposing a SQLSTATE code (pgconn.PgError, pq.Error)
+type sqlStateError interface {
+	SQLState() string
+}
+
+// IsTransientTransactionError reports whether err signals a transaction failure that is expected
+// to succeed when the whole transaction is retried, such as a serialization failure or deadlock
+func IsTransientTransactionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		return transientTransactionStates[stateErr.SQLState()]
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range transientTransactionMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConnectionError reports whether err was caused by a lost or unusable database connection,
+// as opposed to an error produced by the query itself
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range connectionErrorMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// withReadRetry executes a read operation, retrying it once after the configured delay
+// when it fails with a connection error. Reads inside a transaction are never retried
+// because the transaction is bound to the lost connection.
+func (uow *PostgresUnitOfWork[T]) withReadRetry(ctx context.Context, read func() error) error {
+	err := read()
+	if err == nil || !uow.config.retryOnConnectionError || uow.tx != nil || !IsConnectionError(err) {
+		return err
+	}
+
+	timer := time.NewTimer(uow.config.retryDelay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return err
+	case <-timer.C:
+	}
+
+	return read()
+}
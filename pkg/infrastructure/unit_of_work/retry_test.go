@@ -0,0 +1,195 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// injectConnectionErrors registers a query callback that fails the first `failures` queries
+// with a connection reset error and returns a pointer to the number of observed queries
+func injectConnectionErrors(t *testing.T, db *gorm.DB, failures int) *int {
+	t.Helper()
+
+	calls := 0
+	err := db.Callback().Query().Before("gorm:query").Register("test:connection_error", func(tx *gorm.DB) {
+		calls++
+		if calls <= failures {
+			_ = tx.AddError(fmt.Errorf("read tcp: %w", syscall.ECONNRESET))
+		}
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	return &calls
+}
+
+// TestIsConnectionError validates connection error classification
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"connection reset", fmt.Errorf("read tcp: %w", syscall.ECONNRESET), true},
+		{"broken pipe", fmt.Errorf("write tcp: %w", syscall.EPIPE), true},
+		{"bad connection", driver.ErrBadConn, true},
+		{"message only", errors.New("write: connection reset by peer"), true},
+		{"record not found", gorm.ErrRecordNotFound, false},
+		{"syntax error", errors.New(`syntax error at or near "SELEC"`), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := IsConnectionError(tt.err)
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// sqlStateTestError mimics a driver error exposing a SQLSTATE code
+type sqlStateTestError struct {
+	state string
+}
+
+func (e *sqlStateTestError) Error() string    { return "pq: error with state " + e.state }
+func (e *sqlStateTestError) SQLState() string { return e.state }
+
+// TestIsTransientTransactionError validates transient transaction error classification
+func TestIsTransientTransactionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil error", nil, false},
+		{"serialization failure", &sqlStateTestError{state: "40001"}, true},
+		{"deadlock", fmt.Errorf("commit: %w", &sqlStateTestError{state: "40P01"}), true},
+		{"unique violation", &sqlStateTestError{state: "23505"}, false},
+		{"message only", errors.New("ERROR: could not serialize access due to concurrent update"), true},
+		{"record not found", gorm.ErrRecordNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := IsTransientTransactionError(tt.err)
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_ConnectionRetry validates that reads are retried once on connection loss
+func TestPostgresUnitOfWork_ConnectionRetry(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	seed := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if _, err := seed.Insert(ctx, &testutil.TestEntity{Name: "Entity 1", Status: "active"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+
+	calls := injectConnectionErrors(t, db, 1)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithConnectionRetry(time.Millisecond))
+
+	// Act
+	results, err := uow.FindAll(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 entity, got %d", len(results))
+	}
+	if *calls != 2 {
+		t.Errorf("Expected 2 query attempts, got %d", *calls)
+	}
+}
+
+// TestPostgresUnitOfWork_ConnectionRetry_Disabled validates that errors surface without the option
+func TestPostgresUnitOfWork_ConnectionRetry_Disabled(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	calls := injectConnectionErrors(t, db, 1)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+
+	// Act
+	_, err := uow.FindAll(context.Background())
+
+	// Assert
+	if !IsConnectionError(err) {
+		t.Errorf("Expected connection error, got: %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected 1 query attempt, got %d", *calls)
+	}
+}
+
+// TestPostgresUnitOfWork_ConnectionRetry_InTransaction validates that transactional reads are not retried
+func TestPostgresUnitOfWork_ConnectionRetry_InTransaction(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	calls := injectConnectionErrors(t, db, 1)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithConnectionRetry(time.Millisecond))
+
+	if err := uow.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer uow.RollbackTransaction(ctx)
+
+	// Act
+	_, err := uow.FindAll(ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected connection error inside transaction")
+	}
+	if *calls != 1 {
+		t.Errorf("Expected 1 query attempt, got %d", *calls)
+	}
+}
+
+// TestPostgresUnitOfWork_ConnectionRetry_QueryError validates that query errors are not retried
+func TestPostgresUnitOfWork_ConnectionRetry_QueryError(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	calls := 0
+	err := db.Callback().Query().Before("gorm:query").Register("test:count_calls", func(tx *gorm.DB) {
+		calls++
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithConnectionRetry(time.Millisecond))
+
+	// Act
+	_, err = uow.FindOneById(context.Background(), 99999)
+
+	// Assert
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected record not found, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 query attempt, got %d", calls)
+	}
+}
@@ -0,0 +1,95 @@
+package unit_of_work
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+)
+
+// IndexSpec describes an index created by EnsureIndexes
+type IndexSpec struct {
+	Name     string   // Index name; defaults to idx_<table>_<columns>
+	Columns  []string // Indexed columns, in order
+	Unique   bool     // Create a UNIQUE index
+	OnlyLive bool     // Partial index covering only rows that are not soft-deleted
+	Where    string   // Additional partial index condition; the caller owns its SQL safety
+}
+
+// LiveUniqueIndex returns a spec for a unique index enforced only among rows that are not
+// soft-deleted, so a trashed row does not block inserting a live row with the same values,
+// e.g. EnsureIndexes(ctx, []IndexSpec{LiveUniqueIndex("email")})
+func LiveUniqueIndex(columns ...string) IndexSpec {
+	return IndexSpec{Columns: columns, Unique: true, OnlyLive: true}
+}
+
+// EnsureSchema creates or upgrades the table for T with GORM AutoMigrate, adding missing
+// columns and indexes declared on the model. It is safe to call on every startup and is
+// intended as the single migration step for services and tests alike.
+func (uow *PostgresUnitOfWork[T]) EnsureSchema(ctx context.Context) error {
+	return uow.db.WithContext(ctx).AutoMigrate(new(T))
+}
+
+// EnsureIndexes creates the given indexes on the table for T with CREATE INDEX IF NOT EXISTS,
+// so it is safe to call on every startup. OnlyLive specs become partial indexes matching the
+// configured soft-delete strategy, e.g. "WHERE deleted_at IS NULL", which keeps indexes used
+// by default queries small.
+func (uow *PostgresUnitOfWork[T]) EnsureIndexes(ctx context.Context, specs []IndexSpec) error {
+	stmt := uow.db.Model(new(T)).Statement
+	if err := stmt.Parse(stmt.Model); err != nil {
+		return fmt.Errorf("failed to resolve schema for indexes: %w", err)
+	}
+	table := stmt.Schema.Table
+
+	for _, spec := range specs {
+		sql, err := uow.indexSQL(table, spec)
+		if err != nil {
+			return err
+		}
+		if err := uow.db.WithContext(ctx).Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to create index on %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// indexSQL renders the CREATE INDEX statement for spec on table
+func (uow *PostgresUnitOfWork[T]) indexSQL(table string, spec IndexSpec) (string, error) {
+	if len(spec.Columns) == 0 {
+		return "", domainerrors.NewValidationError("columns", "at least one index column is required")
+	}
+	for _, column := range spec.Columns {
+		if err := ValidateFieldName(column); err != nil {
+			return "", err
+		}
+	}
+
+	name := spec.Name
+	if name == "" {
+		name = fmt.Sprintf("idx_%s_%s", table, strings.Join(spec.Columns, "_"))
+	}
+	if err := ValidateFieldName(name); err != nil {
+		return "", err
+	}
+
+	var sql strings.Builder
+	sql.WriteString("CREATE ")
+	if spec.Unique {
+		sql.WriteString("UNIQUE ")
+	}
+	fmt.Fprintf(&sql, "INDEX IF NOT EXISTS %s ON %s (%s)", name, table, strings.Join(spec.Columns, ", "))
+
+	var conditions []string
+	if spec.OnlyLive {
+		conditions = append(conditions, uow.filterApplier.deletedCondition("", false))
+	}
+	if spec.Where != "" {
+		conditions = append(conditions, "("+spec.Where+")")
+	}
+	if len(conditions) > 0 {
+		sql.WriteString(" WHERE ")
+		sql.WriteString(strings.Join(conditions, " AND "))
+	}
+	return sql.String(), nil
+}
@@ -0,0 +1,152 @@
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openEmptyDB opens an in-memory database without any migrated tables
+func openEmptyDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	return db
+}
+
+// TestPostgresUnitOfWork_EnsureSchema validates that the table and its columns are created on a fresh database
+func TestPostgresUnitOfWork_EnsureSchema(t *testing.T) {
+	// Arrange
+	db := openEmptyDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+	// Act
+	err := uow.EnsureSchema(context.Background())
+	again := uow.EnsureSchema(context.Background())
+
+	// Assert
+	if err != nil || again != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, again)
+	}
+	migrator := db.Migrator()
+	if !migrator.HasTable(&testutil.TestEntity{}) {
+		t.Fatal("Expected test_entities table to exist")
+	}
+	for _, column := range []string{"name", "email", "deleted_at"} {
+		if !migrator.HasColumn(&testutil.TestEntity{}, column) {
+			t.Errorf("Expected column %q to exist", column)
+		}
+	}
+	if _, err := uow.FindAll(context.Background()); err != nil {
+		t.Errorf("Expected queries to succeed after EnsureSchema, got: %v", err)
+	}
+}
+
+// TestPostgresUnitOfWork_EnsureIndexes validates that regular and partial indexes are created
+func TestPostgresUnitOfWork_EnsureIndexes(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        IndexSpec
+		indexName   string
+		expectedSQL string
+	}{
+		{
+			name:        "Default name",
+			spec:        IndexSpec{Columns: []string{"name", "age"}},
+			indexName:   "idx_test_entities_name_age",
+			expectedSQL: "CREATE INDEX idx_test_entities_name_age ON test_entities (name, age)",
+		},
+		{
+			name:        "Partial unique index on live rows",
+			spec:        IndexSpec{Name: "idx_live_email", Columns: []string{"email"}, Unique: true, OnlyLive: true},
+			indexName:   "idx_live_email",
+			expectedSQL: "CREATE UNIQUE INDEX idx_live_email ON test_entities (email) WHERE deleted_at IS NULL",
+		},
+		{
+			name:        "Custom condition",
+			spec:        IndexSpec{Name: "idx_active_age", Columns: []string{"age"}, OnlyLive: true, Where: "is_active = 1"},
+			indexName:   "idx_active_age",
+			expectedSQL: "CREATE INDEX idx_active_age ON test_entities (age) WHERE deleted_at IS NULL AND (is_active = 1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+			// Act
+			err := uow.EnsureIndexes(context.Background(), []IndexSpec{tt.spec})
+			again := uow.EnsureIndexes(context.Background(), []IndexSpec{tt.spec})
+
+			// Assert
+			if err != nil || again != nil {
+				t.Fatalf("Expected no error, got: %v, %v", err, again)
+			}
+			var sql string
+			if err := db.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", tt.indexName).Scan(&sql).Error; err != nil {
+				t.Fatalf("Failed to read index: %v", err)
+			}
+			if sql != tt.expectedSQL {
+				t.Errorf("Expected index %q, got %q", tt.expectedSQL, sql)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_EnsureIndexes_LiveUnique validates that a live unique index lets a
+// trashed row's values be reused while still rejecting live duplicates
+func TestPostgresUnitOfWork_EnsureIndexes_LiveUnique(t *testing.T) {
+	// Arrange
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t))
+	ctx := context.Background()
+	if err := uow.(*PostgresUnitOfWork[*testutil.TestEntity]).EnsureIndexes(ctx, []IndexSpec{LiveUniqueIndex("email")}); err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	original, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Original", Email: "john@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", original.GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	// Act
+	_, recreateErr := uow.Insert(ctx, &testutil.TestEntity{Name: "Recreated", Email: "john@example.com"})
+	_, duplicateErr := uow.Insert(ctx, &testutil.TestEntity{Name: "Duplicate", Email: "john@example.com"})
+
+	// Assert
+	if recreateErr != nil {
+		t.Errorf("Expected the trashed row not to block the insert, got: %v", recreateErr)
+	}
+	if duplicateErr == nil {
+		t.Errorf("Expected a live duplicate to be rejected")
+	}
+}
+
+// TestPostgresUnitOfWork_EnsureIndexes_InvalidColumn validates that unsafe column names are rejected
+func TestPostgresUnitOfWork_EnsureIndexes_InvalidColumn(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+	// Act
+	err := uow.EnsureIndexes(context.Background(), []IndexSpec{{Columns: []string{"name; DROP TABLE x"}}})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected validation error for invalid column")
+	}
+}
@@ -0,0 +1,122 @@
+package unit_of_work
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// SoftDeleteStrategy selects how soft-deleted rows are marked and recognized
+type SoftDeleteStrategy int
+
+const (
+	// SoftDeleteTimestamp marks rows by setting the deleted_at timestamp (default)
+	SoftDeleteTimestamp SoftDeleteStrategy = iota
+
+	// SoftDeleteBoolean marks rows by setting the is_deleted boolean column to true
+	SoftDeleteBoolean
+)
+
+const (
+	// deletedAtColumn is the soft-delete column used by SoftDeleteTimestamp
+	deletedAtColumn = "deleted_at"
+
+	// isDeletedColumn is the soft-delete column used by SoftDeleteBoolean
+	isDeletedColumn = "is_deleted"
+
+	// auditNoteColumn is the column backing types.AuditableEntity.AuditNote
+	auditNoteColumn = "audit_note"
+)
+
+// auditNoteSetter is implemented by entities that record an audit note, such as types.AuditableEntity
+type auditNoteSetter interface {
+	SetAuditNote(note string)
+}
+
+// WithSoftDeleteStrategy sets how soft-deleted rows are recognized when applying visibility
+func (fa *FilterApplier) WithSoftDeleteStrategy(strategy SoftDeleteStrategy) *FilterApplier {
+	fa.softDeleteStrategy = strategy
+	return fa
+}
+
+// softDeleteColumn returns the column holding the soft-delete marker for the configured strategy
+func (fa *FilterApplier) softDeleteColumn() string {
+	if fa.softDeleteStrategy == SoftDeleteBoolean {
+		return isDeletedColumn
+	}
+	return deletedAtColumn
+}
+
+// deletedCondition returns the SQL condition matching live rows, or soft-deleted rows when
+// deleted is true, qualified with table when it is not empty
+func (fa *FilterApplier) deletedCondition(table string, deleted bool) string {
+	column := fa.softDeleteColumn()
+	if table != "" {
+		column = fmt.Sprintf("%s.%s", table, column)
+	}
+
+	if fa.softDeleteStrategy == SoftDeleteBoolean {
+		if deleted {
+			return fmt.Sprintf("%s = TRUE", column)
+		}
+		return fmt.Sprintf("%s = FALSE", column)
+	}
+	if deleted {
+		return fmt.Sprintf("%s IS NOT NULL", column)
+	}
+	return fmt.Sprintf("%s IS NULL", column)
+}
+
+// excludeDeleted hides soft-deleted rows from queries that otherwise rely on GORM's
+// automatic deleted_at scoping, which does not know about the boolean strategy
+func (uow *PostgresUnitOfWork[T]) excludeDeleted(query *gorm.DB) *gorm.DB {
+	if uow.config.softDeleteStrategy == SoftDeleteBoolean {
+		return query.Where(uow.filterApplier.deletedCondition("", false))
+	}
+	return query
+}
+
+// markDeleted soft-deletes the rows matched by query according to the configured strategy
+// and returns the number of rows affected
+func (uow *PostgresUnitOfWork[T]) markDeleted(query *gorm.DB) (int64, error) {
+	var result *gorm.DB
+	if uow.config.softDeleteStrategy == SoftDeleteBoolean {
+		result = query.Update(isDeletedColumn, true)
+	} else {
+		result = query.Delete(new(T))
+	}
+	return result.RowsAffected, result.Error
+}
+
+// markDeletedWithColumns soft-deletes the rows matched by query and sets the extra columns
+// in the same UPDATE statement. Like markDeleted, it bumps updated_at only under the boolean
+// strategy, where updated_at doubles as the deletion time.
+func (uow *PostgresUnitOfWork[T]) markDeletedWithColumns(query *gorm.DB, columns map[string]interface{}) error {
+	if uow.config.softDeleteStrategy == SoftDeleteBoolean {
+		columns[isDeletedColumn] = true
+		return query.Updates(columns).Error
+	}
+	columns[deletedAtColumn] = query.NowFunc()
+	return query.UpdateColumns(columns).Error
+}
+
+// markRestored clears the soft-delete marker of the rows matched by query and returns how
+// many rows were restored
+func (uow *PostgresUnitOfWork[T]) markRestored(query *gorm.DB) (int64, error) {
+	var result *gorm.DB
+	if uow.config.softDeleteStrategy == SoftDeleteBoolean {
+		result = query.Update(isDeletedColumn, false)
+	} else {
+		result = query.Update(deletedAtColumn, nil)
+	}
+	return result.RowsAffected, result.Error
+}
+
+// trashOrder returns the ordering that lists the most recently deleted rows first.
+// The boolean strategy has no deletion timestamp, so the last update time is used instead.
+func (uow *PostgresUnitOfWork[T]) trashOrder() string {
+	if uow.config.softDeleteStrategy == SoftDeleteBoolean {
+		return "updated_at DESC"
+	}
+	return "deleted_at DESC"
+}
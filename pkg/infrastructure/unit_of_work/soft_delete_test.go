@@ -0,0 +1,291 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// flaggedEntity marks soft deletion with an is_deleted boolean column
+type flaggedEntity struct {
+	types.BaseEntity
+	Name      string `gorm:"column:name"`
+	IsDeleted bool   `gorm:"column:is_deleted;not null;default:false"`
+}
+
+// TableName returns the table name for GORM
+func (fe *flaggedEntity) TableName() string {
+	return "flagged_entities"
+}
+
+// setupBooleanSoftDelete creates a unit of work using the boolean strategy with two seeded rows
+func setupBooleanSoftDelete(t *testing.T) (*gorm.DB, *PostgresUnitOfWork[*flaggedEntity], []*flaggedEntity) {
+	t.Helper()
+
+	db := testutil.SetupTestDB(t)
+	if err := db.AutoMigrate(&flaggedEntity{}); err != nil {
+		t.Fatalf("Failed to migrate flagged entity: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*flaggedEntity](db, WithSoftDeleteStrategy(SoftDeleteBoolean)).(*PostgresUnitOfWork[*flaggedEntity])
+
+	entities, err := uow.BulkInsert(context.Background(), []*flaggedEntity{{Name: "Kept"}, {Name: "Trashed"}})
+	if err != nil {
+		t.Fatalf("Failed to insert flagged entities: %v", err)
+	}
+	return db, uow, entities
+}
+
+// TestSoftDeleteBoolean_SoftDelete validates that soft delete sets the flag and hides the row
+func TestSoftDeleteBoolean_SoftDelete(t *testing.T) {
+	// Arrange
+	db, uow, entities := setupBooleanSoftDelete(t)
+	ctx := context.Background()
+	trashedID := identifier.NewIdentifier().Equal("id", entities[1].GetID())
+
+	// Act
+	_, err := uow.SoftDelete(ctx, trashedID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var stored flaggedEntity
+	if err := db.Unscoped().First(&stored, entities[1].GetID()).Error; err != nil {
+		t.Fatalf("Failed to load trashed row: %v", err)
+	}
+	if !stored.IsDeleted {
+		t.Error("Expected is_deleted to be true")
+	}
+	if stored.DeletedAt.Valid {
+		t.Error("Expected deleted_at to be left untouched")
+	}
+
+	if _, err := uow.FindOneByIdentifier(ctx, trashedID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected trashed row to be hidden, got: %v", err)
+	}
+	all, err := uow.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list entities: %v", err)
+	}
+	if len(all) != 1 || all[0].Name != "Kept" {
+		t.Errorf("Expected only the kept row, got %+v", all)
+	}
+	count, err := uow.Count(ctx, query.NewQueryParams[*flaggedEntity]())
+	if err != nil {
+		t.Fatalf("Failed to count entities: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected count 1, got %d", count)
+	}
+}
+
+// TestSoftDeleteBoolean_TrashListing validates that trash listings select flagged rows
+func TestSoftDeleteBoolean_TrashListing(t *testing.T) {
+	// Arrange
+	_, uow, entities := setupBooleanSoftDelete(t)
+	ctx := context.Background()
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	// Act
+	trashed, err := uow.GetTrashed(ctx)
+	filtered, filteredErr := uow.GetTrashedByIdentifier(ctx, identifier.NewIdentifier().Equal("name", "Trashed"))
+	paged, total, pagedErr := uow.GetTrashedWithPagination(ctx, query.NewQueryParams[*flaggedEntity]())
+
+	// Assert
+	for _, err := range []error{err, filteredErr, pagedErr} {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	if len(trashed) != 1 || trashed[0].Name != "Trashed" {
+		t.Errorf("Expected the trashed row from GetTrashed, got %+v", trashed)
+	}
+	if len(filtered) != 1 || filtered[0].Name != "Trashed" {
+		t.Errorf("Expected the trashed row from GetTrashedByIdentifier, got %+v", filtered)
+	}
+	if total != 1 || len(paged) != 1 {
+		t.Errorf("Expected 1 trashed row from pagination, got %d (total %d)", len(paged), total)
+	}
+}
+
+// TestSoftDeleteBoolean_Restore validates that restore clears the flag
+func TestSoftDeleteBoolean_Restore(t *testing.T) {
+	// Arrange
+	_, uow, entities := setupBooleanSoftDelete(t)
+	ctx := context.Background()
+	trashedID := identifier.NewIdentifier().Equal("id", entities[1].GetID())
+	if _, err := uow.SoftDelete(ctx, trashedID); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	// Act
+	restored, err := uow.Restore(ctx, trashedID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if restored.IsDeleted {
+		t.Error("Expected is_deleted to be false after restore")
+	}
+	if _, err := uow.FindOneByIdentifier(ctx, trashedID); err != nil {
+		t.Errorf("Expected restored row to be visible, got: %v", err)
+	}
+	trashed, err := uow.GetTrashed(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list trash: %v", err)
+	}
+	if len(trashed) != 0 {
+		t.Errorf("Expected empty trash, got %d rows", len(trashed))
+	}
+}
+
+// TestFilterApplier_ApplyDeletedVisibility_Boolean validates the boolean visibility conditions
+func TestFilterApplier_ApplyDeletedVisibility_Boolean(t *testing.T) {
+	tests := []struct {
+		name           string
+		includeDeleted bool
+		onlyDeleted    bool
+		expected       string
+	}{
+		{"Live rows", false, false, "is_deleted = FALSE"},
+		{"Only deleted rows", false, true, "is_deleted = TRUE"},
+		{"All rows", true, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier().WithSoftDeleteStrategy(SoftDeleteBoolean)
+
+			// Act
+			sql := dryRunSQL(fa.ApplyDeletedVisibility(db.Model(&testutil.TestEntity{}), tt.includeDeleted, tt.onlyDeleted))
+
+			// Assert
+			if tt.expected == "" {
+				if strings.Contains(sql, "is_deleted") {
+					t.Errorf("Expected no soft-delete condition, got: %s", sql)
+				}
+				return
+			}
+			if !strings.Contains(sql, tt.expected) {
+				t.Errorf("Expected SQL to contain %q, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// auditedEntity records audit notes through the embedded AuditableEntity
+type auditedEntity struct {
+	types.AuditableEntity
+	Name string `gorm:"column:name"`
+}
+
+// TableName returns the table name for GORM
+func (ae *auditedEntity) TableName() string {
+	return "audited_entities"
+}
+
+// TestPostgresUnitOfWork_SoftDeleteWithNote validates that the deletion and the note are stored in one statement
+func TestPostgresUnitOfWork_SoftDeleteWithNote(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	if err := db.AutoMigrate(&auditedEntity{}); err != nil {
+		t.Fatalf("Failed to migrate audited entity: %v", err)
+	}
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*auditedEntity](db)
+	entity, err := uow.Insert(ctx, &auditedEntity{Name: "Audited"})
+	if err != nil {
+		t.Fatalf("Failed to insert audited entity: %v", err)
+	}
+	var inserted auditedEntity
+	if err := db.First(&inserted, entity.GetID()).Error; err != nil {
+		t.Fatalf("Failed to load inserted row: %v", err)
+	}
+	updates := 0
+	if err := db.Callback().Update().Before("gorm:update").Register("test:count_update", func(tx *gorm.DB) { updates++ }); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	// Act
+	deleted, err := uow.SoftDeleteWithNote(ctx, identifier.NewIdentifier().Equal("id", entity.GetID()), "duplicate account")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("Expected 1 update statement, got %d", updates)
+	}
+	if deleted.AuditNote != "duplicate account" {
+		t.Errorf("Expected returned note to be set, got %q", deleted.AuditNote)
+	}
+	var stored auditedEntity
+	if err := db.Unscoped().First(&stored, entity.GetID()).Error; err != nil {
+		t.Fatalf("Failed to load deleted row: %v", err)
+	}
+	if !stored.DeletedAt.Valid {
+		t.Error("Expected deleted_at to be set")
+	}
+	if stored.AuditNote != "duplicate account" {
+		t.Errorf("Expected audit_note 'duplicate account', got %q", stored.AuditNote)
+	}
+	if !stored.UpdatedAt.Equal(inserted.UpdatedAt) {
+		t.Errorf("Expected updated_at to be left alone like SoftDelete, got %v instead of %v", stored.UpdatedAt, inserted.UpdatedAt)
+	}
+}
+
+// TestPostgresUnitOfWork_SoftDeleteWithNote_Unsupported validates that entities without audit notes are still soft-deleted
+func TestPostgresUnitOfWork_SoftDeleteWithNote_Unsupported(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	entity, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Plain", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	id := identifier.NewIdentifier().Equal("id", entity.GetID())
+
+	// Act
+	_, err = uow.SoftDeleteWithNote(ctx, id, "ignored")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := uow.FindOneByIdentifier(ctx, id); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected entity to be soft-deleted, got: %v", err)
+	}
+}
+
+// TestSoftDeleteBoolean_FindOne validates that FindOne hides rows flagged as deleted
+func TestSoftDeleteBoolean_FindOne(t *testing.T) {
+	// Arrange
+	_, uow, entities := setupBooleanSoftDelete(t)
+	ctx := context.Background()
+	if _, err := uow.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", entities[1].GetID())); err != nil {
+		t.Fatalf("Failed to soft delete: %v", err)
+	}
+
+	// Act
+	_, err := uow.FindOne(ctx, &flaggedEntity{Name: "Trashed"})
+
+	// Assert
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Expected record not found for a flagged row, got: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package unit_of_work
+
+import (
+	"fmt"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	queryparams "github.com/ai-shiraz-teams/go-database/internal/shared/query"
+
+	"gorm.io/gorm"
+)
+
+// WithSortExpressions sets the sort field expressions; see the WithSortExpressions option
+func (fa *FilterApplier) WithSortExpressions(expressions map[string]string) *FilterApplier {
+	fa.sortExpressions = make(map[string]string, len(expressions))
+	for field, expression := range expressions {
+		fa.sortExpressions[field] = expression
+	}
+	return fa
+}
+
+// WithDefaultSort sets the ordering applied when query params specify no sort, in place of
+// the id ASC fallback, e.g. created_at DESC for entities listed newest first
+func (fa *FilterApplier) WithDefaultSort(sorts ...queryparams.SortField) *FilterApplier {
+	fa.defaultSort = append([]queryparams.SortField(nil), sorts...)
+	return fa
+}
+
+// applySorts orders the query by each sort field in turn, recording invalid fields and
+// orders as query errors
+func (fa *FilterApplier) applySorts(query *gorm.DB, sorts []queryparams.SortField) *gorm.DB {
+	for _, sort := range sorts {
+		order := sort.Order.Normalize()
+		if !order.IsValid() {
+			_ = query.AddError(domainerrors.NewValidationError(sort.Field, fmt.Sprintf("invalid sort order %q", sort.Order)))
+			continue
+		}
+		expression, err := fa.sortExpression(sort.Field)
+		if err != nil {
+			_ = query.AddError(err)
+			continue
+		}
+		query = query.Order(fmt.Sprintf("%s %s", expression, order))
+	}
+	return query
+}
+
+// applySortsOrDefault orders query by sorts, falling back to the default sort and then to
+// id ASC when sorts is empty
+func (fa *FilterApplier) applySortsOrDefault(query *gorm.DB, sorts []queryparams.SortField) *gorm.DB {
+	if len(sorts) > 0 {
+		return fa.applySorts(query, sorts)
+	}
+	if len(fa.defaultSort) > 0 {
+		return fa.applySorts(query, fa.defaultSort)
+	}
+	return query.Order("id ASC")
+}
+
+// sortExpression resolves a sort field to its mapped expression or validated column name
+func (fa *FilterApplier) sortExpression(field string) (string, error) {
+	if expression, ok := fa.sortExpressions[field]; ok {
+		return expression, nil
+	}
+	if err := ValidateFieldName(field); err != nil {
+		return "", err
+	}
+	return fa.columnName(field), nil
+}
@@ -0,0 +1,164 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestFilterApplier_SortExpressions validates that mapped fields order by their expression and others by column
+func TestFilterApplier_SortExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     query.SortField
+		expected string
+	}{
+		{"Mapped field", query.SortField{Field: "name", Order: query.SortOrderDesc}, "ORDER BY lower(name) desc"},
+		{"Unmapped field", query.SortField{Field: "createdAt", Order: query.SortOrderAsc}, "ORDER BY created_at asc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier().WithSortExpressions(map[string]string{"name": "lower(name)"})
+			params := query.NewQueryParams[*testutil.TestEntity]()
+			params.Sort = []query.SortField{tt.sort}
+
+			// Act
+			result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+			// Assert
+			if result.Error != nil {
+				t.Fatalf("Expected no error, got: %v", result.Error)
+			}
+			if sql := dryRunSQL(result); !strings.Contains(sql, tt.expected) {
+				t.Errorf("Expected SQL to contain %q, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestFilterApplier_SortExpressions_RejectsUnmappedExpression validates that unmapped sort fields must be plain columns
+func TestFilterApplier_SortExpressions_RejectsUnmappedExpression(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	fa := NewFilterApplier().WithSortExpressions(map[string]string{"name": "lower(name)"})
+	params := query.NewQueryParams[*testutil.TestEntity]()
+	params.Sort = []query.SortField{{Field: "lower(email)", Order: query.SortOrderAsc}}
+
+	// Act
+	result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(result.Error, &validationErr) {
+		t.Fatalf("Expected validation error, got: %v", result.Error)
+	}
+}
+
+// TestWithSortExpressions validates that paginated results are ordered by the mapped expression
+func TestWithSortExpressions(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithSortExpressions(map[string]string{"name": "lower(name)"}))
+	ctx := context.Background()
+	for _, name := range []string{"carol", "Bob", "alice"} {
+		if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: name}); err != nil {
+			t.Fatalf("Failed to insert test entity: %v", err)
+		}
+	}
+	params := query.NewQueryParams[*testutil.TestEntity]()
+	params.Sort = []query.SortField{{Field: "name", Order: query.SortOrderAsc}}
+
+	// Act
+	result, _, err := uow.FindAllWithPagination(ctx, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := []string{"alice", "Bob", "carol"}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d entities, got %d", len(expected), len(result))
+	}
+	for i, name := range expected {
+		if result[i].Name != name {
+			t.Errorf("Expected entity %d to be %s, got %s", i, name, result[i].Name)
+		}
+	}
+}
+
+// TestFilterApplier_DefaultSort validates that the default sort replaces the id fallback only
+// when params carry no sort of their own
+func TestFilterApplier_DefaultSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     []query.SortField
+		expected string
+	}{
+		{"No sort uses the default", nil, "ORDER BY created_at desc"},
+		{"Explicit sort wins", []query.SortField{{Field: "name", Order: query.SortOrderAsc}}, "ORDER BY name asc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+			fa := NewFilterApplier().WithDefaultSort(query.SortField{Field: "createdAt", Order: query.SortOrderDesc})
+			params := query.NewQueryParams[*testutil.TestEntity]()
+			params.Sort = tt.sort
+
+			// Act
+			result := fa.ApplyQueryParams(db.Model(&testutil.TestEntity{}), params)
+
+			// Assert
+			if result.Error != nil {
+				t.Fatalf("Expected no error, got: %v", result.Error)
+			}
+			sql := dryRunSQL(result)
+			if !strings.HasSuffix(sql, tt.expected) {
+				t.Errorf("Expected SQL to end with %q, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestWithDefaultSort validates that an unsorted page is listed newest first
+func TestWithDefaultSort(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithDefaultSort(query.SortField{Field: "created_at", Order: query.SortOrderDesc}))
+	ctx := context.Background()
+	created := time.Now().Add(-time.Hour)
+	for i, name := range []string{"Oldest", "Middle", "Newest"} {
+		entity := &testutil.TestEntity{Name: name}
+		entity.CreatedAt = created.Add(time.Duration(i) * time.Minute)
+		if _, err := uow.Insert(ctx, entity); err != nil {
+			t.Fatalf("Failed to insert test entity: %v", err)
+		}
+	}
+
+	// Act
+	result, _, err := uow.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := []string{"Newest", "Middle", "Oldest"}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d entities, got %d", len(expected), len(result))
+	}
+	for i, name := range expected {
+		if result[i].Name != name {
+			t.Errorf("Expected entity %d to be %s, got %s", i, name, result[i].Name)
+		}
+	}
+}
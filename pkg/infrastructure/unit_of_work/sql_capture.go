@@ -0,0 +1,72 @@
+package unit_of_work
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// CapturedSQL describes one statement executed by the unit of work
+type CapturedSQL struct {
+	SQL          string        // Parameterized SQL as sent to the database
+	Vars         []interface{} // Bound arguments, in placeholder order
+	RowsAffected int64         // Rows returned or affected, -1 when unknown
+	Err          error         // Error returned by the statement, if any
+}
+
+// SQLCaptureFunc receives every statement executed by a unit of work configured with
+// WithSQLCapture, together with the context of the call that issued it
+type SQLCaptureFunc func(ctx context.Context, statement CapturedSQL)
+
+// sqlCaptureLogger wraps a GORM logger to report every traced statement to a SQLCaptureFunc
+// before handing it on to the wrapped logger
+type sqlCaptureLogger struct {
+	logger.Interface
+	capture SQLCaptureFunc
+
+	// mu serializes statement rendering so ParamsFilter reports to the Trace that triggered it
+	mu      *sync.Mutex
+	sql     string
+	vars    []interface{}
+	hasVars bool
+}
+
+// newSQLCaptureLogger wraps inner so that statements are reported to capture
+func newSQLCaptureLogger(inner logger.Interface, capture SQLCaptureFunc) *sqlCaptureLogger {
+	return &sqlCaptureLogger{Interface: inner, capture: capture, mu: &sync.Mutex{}}
+}
+
+// LogMode changes the level of the wrapped logger and keeps capturing
+func (l *sqlCaptureLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return newSQLCaptureLogger(l.Interface.LogMode(level), l.capture)
+}
+
+// ParamsFilter records the parameterized SQL and its arguments while the statement is rendered,
+// then applies the wrapped logger's own filter if it has one
+func (l *sqlCaptureLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	l.sql, l.vars, l.hasVars = sql, params, true
+	if filter, ok := l.Interface.(gorm.ParamsFilter); ok {
+		return filter.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+// Trace renders the statement once, reports it to the capture callback regardless of the log
+// level and passes the rendered statement to the wrapped logger
+func (l *sqlCaptureLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.mu.Lock()
+	l.hasVars = false
+	explained, rows := fc()
+	statement := CapturedSQL{SQL: explained, RowsAffected: rows, Err: err}
+	if l.hasVars {
+		statement.SQL, statement.Vars = l.sql, l.vars
+	}
+	l.sql, l.vars = "", nil
+	l.mu.Unlock()
+
+	l.capture(ctx, statement)
+	l.Interface.Trace(ctx, begin, func() (string, int64) { return explained, rows }, err)
+}
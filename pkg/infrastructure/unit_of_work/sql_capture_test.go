@@ -0,0 +1,72 @@
+package unit_of_work
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestWithSQLCapture validates that the parameterized SQL and arguments of a filtered query are
+// reported to the capture callback
+func TestWithSQLCapture(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	var captured []CapturedSQL
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithSQLCapture(func(_ context.Context, statement CapturedSQL) {
+		captured = append(captured, statement)
+	}))
+	if _, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Active", Status: "active"}); err != nil {
+		t.Fatalf("Failed to insert test entity: %v", err)
+	}
+	captured = nil
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("status", "active"))
+
+	// Act
+	_, _, err := uow.FindAllWithPagination(ctx, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(captured) != 2 {
+		t.Fatalf("Expected the count and select statements, got %+v", captured)
+	}
+	expected := "SELECT * FROM `test_entities` WHERE status = ? AND deleted_at IS NULL AND `test_entities`.`deleted_at` IS NULL ORDER BY id ASC LIMIT 50"
+	if captured[1].SQL != expected {
+		t.Errorf("Expected %s, got %s", expected, captured[1].SQL)
+	}
+	if !reflect.DeepEqual(captured[1].Vars, []interface{}{"active"}) {
+		t.Errorf("Expected the filter value to be bound, got %v", captured[1].Vars)
+	}
+	if captured[1].RowsAffected != 1 || captured[1].Err != nil {
+		t.Errorf("Expected one row and no error, got %d and %v", captured[1].RowsAffected, captured[1].Err)
+	}
+}
+
+// TestWithSQLCapture_WithLogger validates that capturing keeps forwarding statements to the
+// configured logger
+func TestWithSQLCapture_WithLogger(t *testing.T) {
+	// Arrange
+	logged := &capturingLogger{}
+	captures := 0
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t),
+		WithLogger(logged),
+		WithSQLCapture(func(context.Context, CapturedSQL) { captures++ }))
+
+	// Act
+	_, err := uow.FindAll(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if captures != 1 || len(logged.statements) != 1 {
+		t.Errorf("Expected the statement to be captured and logged once, got %d and %d", captures, len(logged.statements))
+	}
+}
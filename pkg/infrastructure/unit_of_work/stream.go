@@ -0,0 +1,54 @@
+package unit_of_work
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+)
+
+// StreamJSON writes every entity matching params to w as a single JSON array, encoding
+// rows one at a time as they are read from the database so memory stays flat for large
+// exports. Filters, search, soft-delete visibility and sorting are honored; pagination
+// and preloads are not, since rows are scanned individually from a cursor.
+func (uow *PostgresUnitOfWork[T]) StreamJSON(ctx context.Context, params *query.QueryParams[T], w io.Writer) error {
+	db := uow.QueryBuilder(ctx, params)
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	for rows.Next() {
+		var entity T
+		if err := db.ScanRows(rows, &entity); err != nil {
+			return err
+		}
+
+		encoded, err := json.Marshal(entity)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(encoded); err != nil {
+			return err
+		}
+		first = false
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+	return err
+}
@@ -0,0 +1,69 @@
+package unit_of_work
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestPostgresUnitOfWork_StreamJSON validates that matching rows are streamed as a JSON array
+func TestPostgresUnitOfWork_StreamJSON(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	entities := []*testutil.TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "inactive"},
+		{Name: "Entity 3", Status: "active"},
+	}
+	if _, err := uow.BulkInsert(ctx, entities); err != nil {
+		t.Fatalf("Failed to insert test entities: %v", err)
+	}
+	params := query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().Equal("status", "active")).
+		AddSortDesc("id")
+
+	// Act
+	var buf bytes.Buffer
+	err := uow.StreamJSON(ctx, params, &buf)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var decoded []testutil.TestEntity
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", buf.String(), err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("Expected 2 entities, got %d", len(decoded))
+	}
+	if decoded[0].Name != "Entity 3" || decoded[1].Name != "Entity 1" {
+		t.Errorf("Expected entities in sort order, got %q and %q", decoded[0].Name, decoded[1].Name)
+	}
+}
+
+// TestPostgresUnitOfWork_StreamJSON_Empty validates that no matches produce an empty array
+func TestPostgresUnitOfWork_StreamJSON_Empty(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+	// Act
+	var buf bytes.Buffer
+	err := uow.StreamJSON(context.Background(), query.NewQueryParams[*testutil.TestEntity](), &buf)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if buf.String() != "[]" {
+		t.Errorf("Expected empty array, got %q", buf.String())
+	}
+}
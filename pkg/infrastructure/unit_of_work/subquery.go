@@ -0,0 +1,44 @@
+package unit_of_work
+
+import (
+	"fmt"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+
+	"gorm.io/gorm"
+)
+
+// buildSubquery builds "SELECT column FROM table WHERE <filters>" for the subquery operators.
+// For NOT IN (excludeNulls) it also requires the column to be non-NULL: a single NULL in the
+// subquery makes "x NOT IN (...)" unknown for every row, silently returning nothing.
+// The SQL is standard and targets PostgreSQL first; other dialects supporting IN subqueries work too.
+func (fa *FilterApplier) buildSubquery(query *gorm.DB, sub *identifier.Subquery, excludeNulls bool) (*gorm.DB, error) {
+	if sub == nil {
+		return nil, domainerrors.NewValidationError("subquery", "subquery filter requires a subquery")
+	}
+	column := fa.columnName(sub.Column)
+	for _, name := range []string{sub.Table, column} {
+		if err := ValidateFieldName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	subQuery := query.Session(&gorm.Session{NewDB: true}).
+		Table(sub.Table).
+		Select(column)
+
+	if excludeNulls {
+		subQuery = subQuery.Where(fmt.Sprintf("%s IS NOT NULL", column))
+	}
+
+	if len(sub.Filters) > 0 {
+		filtered := fa.ApplyFilters(query.Session(&gorm.Session{NewDB: true}), sub.Filters)
+		if filtered.Error != nil {
+			return nil, filtered.Error
+		}
+		subQuery = subQuery.Where(filtered)
+	}
+
+	return subQuery, nil
+}
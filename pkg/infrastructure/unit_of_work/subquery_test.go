@@ -0,0 +1,108 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+)
+
+// TestFilterApplier_Subquery validates the SQL generated for subquery membership filters
+func TestFilterApplier_Subquery(t *testing.T) {
+	paidOrders := identifier.NewSubquery("test_orders", "test_entity_id", identifier.NewIdentifier().Equal("status", "paid"))
+
+	tests := []struct {
+		name     string
+		ident    identifier.IIdentifier
+		expected string
+	}{
+		{
+			name:     "IN subquery",
+			ident:    identifier.NewIdentifier().InSubquery("id", paidOrders),
+			expected: "WHERE id IN (SELECT test_entity_id FROM `test_orders` WHERE status = ?)",
+		},
+		{
+			name:     "NOT IN subquery skips NULLs",
+			ident:    identifier.NewIdentifier().NotInSubquery("id", paidOrders),
+			expected: "WHERE id NOT IN (SELECT test_entity_id FROM `test_orders` WHERE test_entity_id IS NOT NULL AND status = ?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := testutil.SetupTestDB(t)
+
+			// Act
+			sql := dryRunSQL(NewFilterApplier().ApplyIdentifier(db.Model(&testutil.TestEntity{}), tt.ident))
+
+			// Assert
+			if !strings.Contains(sql, tt.expected) {
+				t.Errorf("Expected SQL to contain %q, got %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_NotInSubquery validates that NOT IN returns the complement of IN,
+// including when the subquery selects NULLs
+func TestPostgresUnitOfWork_NotInSubquery(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	seedEntitiesWithOrders(t, db)
+	if err := db.Create(&testutil.TestOrder{Status: "paid"}).Error; err != nil {
+		t.Fatalf("Failed to seed order: %v", err)
+	}
+	if err := db.Exec("UPDATE test_orders SET test_entity_id = NULL WHERE test_entity_id = 0").Error; err != nil {
+		t.Fatalf("Failed to clear order owner: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	ctx := context.Background()
+	paidOrders := identifier.NewSubquery("test_orders", "test_entity_id", identifier.NewIdentifier().Equal("status", "paid"))
+
+	// Act
+	included, _, includedErr := uow.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().InSubquery("id", paidOrders)))
+	excluded, _, excludedErr := uow.FindAllWithPagination(ctx, query.NewQueryParams[*testutil.TestEntity]().
+		WithFilters(identifier.NewIdentifier().NotInSubquery("id", paidOrders)))
+
+	// Assert
+	if includedErr != nil || excludedErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", includedErr, excludedErr)
+	}
+	var includedNames, excludedNames []string
+	for _, entity := range included {
+		includedNames = append(includedNames, entity.Name)
+	}
+	for _, entity := range excluded {
+		excludedNames = append(excludedNames, entity.Name)
+	}
+	if !reflect.DeepEqual(includedNames, []string{"Paid Customer"}) {
+		t.Errorf("Expected the paid customer, got %v", includedNames)
+	}
+	if !reflect.DeepEqual(excludedNames, []string{"Pending Customer", "No Orders"}) {
+		t.Errorf("Expected every other customer, got %v", excludedNames)
+	}
+}
+
+// TestFilterApplier_Subquery_InvalidColumn validates that subquery identifiers are validated
+func TestFilterApplier_Subquery_InvalidColumn(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t)
+	ident := identifier.NewIdentifier().NotInSubquery("id", identifier.NewSubquery("test_orders", "1; DROP TABLE test_orders", nil))
+
+	// Act
+	_, err := NewFilterApplier().ApplyFiltersE(db.Model(&testutil.TestEntity{}), ident.ToFilterCriteria())
+
+	// Assert
+	var validationErr *domainerrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected validation error, got: %v", err)
+	}
+}
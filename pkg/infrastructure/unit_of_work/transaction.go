@@ -0,0 +1,71 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+)
+
+// dataOperations is embedded by postgresTransaction so that only the data methods of its unit
+// of work are promoted, not transaction management or advisory locks
+type dataOperations[T types.IBaseModel] interface {
+	unit_of_work.IDataOperations[T]
+}
+
+// postgresTransaction is the ITransaction returned by Begin. It forwards the data methods to a
+// PostgresUnitOfWork whose transaction is fixed for its whole lifetime.
+type postgresTransaction[T types.IBaseModel] struct {
+	dataOperations[T]
+	uow  *PostgresUnitOfWork[T]
+	done bool
+}
+
+// Begin starts a transaction and returns a handle bound to it. Unlike BeginTransaction, the
+// unit of work itself is left untouched, so it can keep serving other goroutines while any
+// number of transactions are in progress. The handle shares the configuration of the unit
+// of work; once it is committed or rolled back its methods fail with sql.ErrTxDone rather
+// than silently running outside the transaction.
+func (uow *PostgresUnitOfWork[T]) Begin(ctx context.Context) (unit_of_work.ITransaction[T], error) {
+	tx := uow.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	bound := &PostgresUnitOfWork[T]{
+		db:            uow.db,
+		filterApplier: uow.filterApplier,
+		tx:            tx,
+		config:        uow.config,
+	}
+	return &postgresTransaction[T]{dataOperations: bound, uow: bound}, nil
+}
+
+// Commit commits the transaction. database/sql finishes the transaction whether or not the
+// commit succeeds, so the handle is done after any attempt and a failed commit cannot be
+// rolled back.
+func (t *postgresTransaction[T]) Commit(ctx context.Context) error {
+	t.done = true
+	return t.uow.tx.Commit().Error
+}
+
+// Rollback rolls back the transaction. A transaction that database/sql already finished, such
+// as one whose context was cancelled, is reported through sql.ErrTxDone and leaves the handle
+// done.
+func (t *postgresTransaction[T]) Rollback(ctx context.Context) error {
+	err := t.uow.tx.Rollback().Error
+	if err == nil || errors.Is(err, sql.ErrTxDone) {
+		t.done = true
+	}
+	return err
+}
+
+// InTransaction reports whether the transaction has not been committed or rolled back yet
+func (t *postgresTransaction[T]) InTransaction() bool {
+	return !t.done
+}
+
+// Compile-time check to ensure postgresTransaction implements ITransaction
+var _ unit_of_work.ITransaction[types.IBaseModel] = (*postgresTransaction[types.IBaseModel])(nil)
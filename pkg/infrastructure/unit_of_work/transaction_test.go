@@ -0,0 +1,447 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/unit_of_work"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupFileDB opens a file-backed database so separate connections only share committed data
+func setupFileDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tx.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	if err := db.AutoMigrate(&testutil.TestEntity{}, &testutil.TestOrder{}); err != nil {
+		t.Fatalf("Failed to migrate test entity: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// TestPostgresUnitOfWork_ReadYourWritesInTransaction validates that every read path sees rows
+// written earlier in the same, still uncommitted, transaction
+func TestPostgresUnitOfWork_ReadYourWritesInTransaction(t *testing.T) {
+	// Arrange
+	db := setupFileDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	outside := NewPostgresUnitOfWork[*testutil.TestEntity](db)
+	if err := uow.BeginTransaction(ctx); err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer uow.RollbackTransaction(ctx)
+
+	inserted, err := uow.Insert(ctx, &testutil.TestEntity{Name: "Uncommitted", Status: "active"})
+	if err != nil {
+		t.Fatalf("Failed to insert entity: %v", err)
+	}
+	id := identifier.NewIdentifier().Equal("id", inserted.GetID())
+	params := query.NewQueryParams[*testutil.TestEntity]().WithFilters(id).PrepareDefaults()
+
+	// Act & Assert
+	if _, err := outside.FindOneById(ctx, inserted.GetID()); err == nil {
+		t.Fatal("Expected the uncommitted row to be invisible outside the transaction")
+	}
+
+	reads := map[string]func() (int, error){
+		"FindOneById": func() (int, error) {
+			e, err := uow.FindOneById(ctx, inserted.GetID())
+			return boolToCount(err == nil && e.GetID() == inserted.GetID()), err
+		},
+		"FindOneByIdentifier": func() (int, error) {
+			_, err := uow.FindOneByIdentifier(ctx, id)
+			return boolToCount(err == nil), err
+		},
+		"FindOne": func() (int, error) {
+			_, err := uow.FindOne(ctx, &testutil.TestEntity{Name: "Uncommitted"})
+			return boolToCount(err == nil), err
+		},
+		"FindAll": func() (int, error) {
+			all, err := uow.FindAll(ctx)
+			return len(all), err
+		},
+		"FindByIDs": func() (int, error) {
+			found, err := uow.FindByIDs(ctx, []int{inserted.GetID()})
+			return len(found), err
+		},
+		"FindAllWithPagination": func() (int, error) {
+			_, total, err := uow.FindAllWithPagination(ctx, params)
+			return int(total), err
+		},
+		"Count": func() (int, error) {
+			count, err := uow.Count(ctx, params)
+			return int(count), err
+		},
+		"Exists": func() (int, error) {
+			exists, err := uow.Exists(ctx, id)
+			return boolToCount(exists), err
+		},
+		"ResolveIDByUniqueField": func() (int, error) {
+			resolved, err := uow.ResolveIDByUniqueField(ctx, inserted, "name", "Uncommitted")
+			return boolToCount(resolved == inserted.GetID()), err
+		},
+	}
+	for name, read := range reads {
+		t.Run(name, func(t *testing.T) {
+			count, err := read()
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("Expected the uncommitted row to be visible, got %d", count)
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_Begin validates that a transaction handle commits or discards its writes
+// without putting the unit of work itself in a transaction
+func TestPostgresUnitOfWork_Begin(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   bool
+		expected int
+	}{
+		{"Commit", true, 1},
+		{"Rollback", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			db := setupFileDB(t)
+			ctx := context.Background()
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+			tx, err := uow.Begin(ctx)
+			if err != nil {
+				t.Fatalf("Failed to begin transaction: %v", err)
+			}
+			if _, err := tx.Insert(ctx, &testutil.TestEntity{Name: "Pending"}); err != nil {
+				t.Fatalf("Failed to insert entity: %v", err)
+			}
+			before, err := uow.FindAll(ctx)
+			if err != nil {
+				t.Fatalf("Failed to read outside the transaction: %v", err)
+			}
+
+			// Act
+			if tt.commit {
+				err = tx.Commit(ctx)
+			} else {
+				err = tx.Rollback(ctx)
+			}
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if uow.tx != nil {
+				t.Error("Expected the unit of work to stay outside the transaction")
+			}
+			if len(before) != 0 {
+				t.Errorf("Expected the uncommitted row to be invisible to the unit of work, got %d rows", len(before))
+			}
+			after, err := uow.FindAll(ctx)
+			if err != nil {
+				t.Fatalf("Failed to read after the transaction: %v", err)
+			}
+			if len(after) != tt.expected {
+				t.Errorf("Expected %d rows after the transaction, got %d", tt.expected, len(after))
+			}
+		})
+	}
+}
+
+// TestPostgresUnitOfWork_Begin_InTransaction validates that a handle reports its transaction until it is finished
+func TestPostgresUnitOfWork_Begin_InTransaction(t *testing.T) {
+	// Arrange
+	db := setupFileDB(t)
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx := context.Background()
+
+	// Act
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	during := tx.InTransaction()
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+
+	// Assert
+	if !during {
+		t.Error("Expected the handle to be in a transaction before rollback")
+	}
+	if tx.InTransaction() {
+		t.Error("Expected the handle not to be in a transaction after rollback")
+	}
+	if uow.InTransaction() {
+		t.Error("Expected the unit of work itself to be unaffected by Begin")
+	}
+}
+
+// TestPostgresUnitOfWork_Begin_UseAfterCommit validates that a finished handle does not run outside its transaction
+func TestPostgresUnitOfWork_Begin_UseAfterCommit(t *testing.T) {
+	// Arrange
+	db := setupFileDB(t)
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Failed to commit transaction: %v", err)
+	}
+
+	// Act
+	_, insertErr := tx.Insert(ctx, &testutil.TestEntity{Name: "Late"})
+	rollbackErr := tx.Rollback(ctx)
+
+	// Assert
+	if !errors.Is(insertErr, sql.ErrTxDone) {
+		t.Errorf("Expected sql.ErrTxDone from Insert, got: %v", insertErr)
+	}
+	if !errors.Is(rollbackErr, sql.ErrTxDone) {
+		t.Errorf("Expected sql.ErrTxDone from Rollback, got: %v", rollbackErr)
+	}
+}
+
+// TestPostgresUnitOfWork_Begin_HandleScope validates that a handle exposes only the data methods
+// of its unit of work, not transaction management or advisory locks
+func TestPostgresUnitOfWork_Begin_HandleScope(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](setupFileDB(t)).(*PostgresUnitOfWork[*testutil.TestEntity])
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Act
+	var handle interface{} = tx
+	_, beginTransaction := handle.(interface{ BeginTransaction(context.Context) error })
+	_, runInTransaction := handle.(interface {
+		RunInTransaction(context.Context, func(context.Context) error) error
+	})
+	_, begin := handle.(interface {
+		Begin(context.Context) (unit_of_work.ITransaction[*testutil.TestEntity], error)
+	})
+	_, advisoryLock := handle.(interface {
+		TryAdvisoryLock(context.Context, int64) (bool, error)
+	})
+
+	// Assert
+	if beginTransaction || runInTransaction || begin || advisoryLock {
+		t.Errorf("Expected only data methods, got BeginTransaction %v, RunInTransaction %v, Begin %v, TryAdvisoryLock %v",
+			beginTransaction, runInTransaction, begin, advisoryLock)
+	}
+}
+
+// TestPostgresUnitOfWork_Begin_FailedCommit validates that a handle whose commit fails is done,
+// since database/sql has already finished its transaction
+func TestPostgresUnitOfWork_Begin_FailedCommit(t *testing.T) {
+	// Arrange
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](setupFileDB(t)).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx, cancel := context.WithCancel(context.Background())
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	cancel()
+
+	// Act
+	commitErr := tx.Commit(context.Background())
+
+	// Assert
+	if commitErr == nil {
+		t.Fatal("Expected the commit of a cancelled transaction to fail")
+	}
+	if tx.InTransaction() {
+		t.Error("Expected the handle to be done after a failed commit")
+	}
+}
+
+// TestPostgresUnitOfWork_Begin_RollbackCancelled validates that rolling back a transaction whose
+// context was cancelled leaves the handle done
+func TestPostgresUnitOfWork_Begin_RollbackCancelled(t *testing.T) {
+	// Arrange
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](setupFileDB(t)).(*PostgresUnitOfWork[*testutil.TestEntity])
+	ctx, cancel := context.WithCancel(context.Background())
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	cancel()
+
+	// Act
+	err = tx.Rollback(context.Background())
+
+	// Assert
+	if err != nil && !errors.Is(err, sql.ErrTxDone) {
+		t.Errorf("Expected sql.ErrTxDone or no error, got: %v", err)
+	}
+	if tx.InTransaction() {
+		t.Error("Expected the handle to be done after rolling back a cancelled transaction")
+	}
+}
+
+// TestPostgresUnitOfWork_Begin_Concurrent validates that concurrent transactions on a shared unit of work do not race
+func TestPostgresUnitOfWork_Begin_Concurrent(t *testing.T) {
+	// Arrange
+	db := setupFileDB(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get connection pool: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1) // SQLite allows a single writer; transactions queue for the connection
+	ctx := context.Background()
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db).(*PostgresUnitOfWork[*testutil.TestEntity])
+	const workers = 20
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := uow.Begin(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if _, err := tx.Insert(ctx, &testutil.TestEntity{Name: "Concurrent"}); err != nil {
+				_ = tx.Rollback(ctx)
+				errs <- err
+				return
+			}
+			errs <- tx.Commit(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Assert
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+	}
+	count, err := uow.Count(ctx, query.NewQueryParams[*testutil.TestEntity]())
+	if err != nil {
+		t.Fatalf("Failed to count entities: %v", err)
+	}
+	if count != workers {
+		t.Errorf("Expected %d committed rows, got %d", workers, count)
+	}
+}
+
+// boolToCount converts a visibility check into a row count
+func boolToCount(visible bool) int {
+	if visible {
+		return 1
+	}
+	return 0
+}
+
+// TestWithReadReplica validates that paginated reads and counts use the replica unless the primary is forced
+func TestWithReadReplica(t *testing.T) {
+	tests := []struct {
+		name          string
+		forcePrimary  bool
+		inTransaction bool
+		expected      int64
+	}{
+		{"Replica by default", false, false, 1},
+		{"ForcePrimary", true, false, 2},
+		{"Inside a transaction", false, true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			primary := testutil.SetupTestDB(t)
+			replica := testutil.SetupTestDB(t)
+			ctx := context.Background()
+			if err := primary.Create(&[]*testutil.TestEntity{{Name: "Old"}, {Name: "Just written"}}).Error; err != nil {
+				t.Fatalf("Failed to seed primary: %v", err)
+			}
+			if err := replica.Create(&testutil.TestEntity{Name: "Old"}).Error; err != nil {
+				t.Fatalf("Failed to seed replica: %v", err)
+			}
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](primary, WithReadReplica(replica))
+			if tt.inTransaction {
+				if err := uow.BeginTransaction(ctx); err != nil {
+					t.Fatalf("Failed to begin transaction: %v", err)
+				}
+				defer uow.RollbackTransaction(ctx)
+			}
+			params := query.NewQueryParams[*testutil.TestEntity]().PrepareDefaults()
+			if tt.forcePrimary {
+				params.WithForcePrimary()
+			}
+
+			// Act
+			count, countErr := uow.Count(ctx, params)
+			_, total, pageErr := uow.FindAllWithPagination(ctx, params)
+
+			// Assert
+			if countErr != nil || pageErr != nil {
+				t.Fatalf("Expected no error, got: %v, %v", countErr, pageErr)
+			}
+			if count != tt.expected || total != tt.expected {
+				t.Errorf("Expected %d rows, got count %d and total %d", tt.expected, count, total)
+			}
+		})
+	}
+}
+
+// TestWithReadReplica_FindTop validates that FindTop reads from the primary
+func TestWithReadReplica_FindTop(t *testing.T) {
+	// Arrange
+	primary := testutil.SetupTestDB(t)
+	replica := testutil.SetupTestDB(t)
+	ctx := context.Background()
+	if err := primary.Create(&[]*testutil.TestEntity{{Name: "Old"}, {Name: "Just written"}}).Error; err != nil {
+		t.Fatalf("Failed to seed primary: %v", err)
+	}
+	if err := replica.Create(&testutil.TestEntity{Name: "Old"}).Error; err != nil {
+		t.Fatalf("Failed to seed replica: %v", err)
+	}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](primary, WithReadReplica(replica))
+
+	// Act
+	top, err := uow.FindTop(ctx, 10, nil, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(top) != 2 {
+		t.Errorf("Expected the 2 primary rows, got %d", len(top))
+	}
+}
@@ -0,0 +1,104 @@
+package unit_of_work
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+
+	"gorm.io/gorm/clause"
+)
+
+// Vector is an embedding bound as a pgvector literal such as '[1,2.5,3]'
+type Vector []float32
+
+// Value renders the vector in the pgvector text format
+func (v Vector) Value() (driver.Value, error) {
+	var literal strings.Builder
+	literal.WriteByte('[')
+	for i, component := range v {
+		if i > 0 {
+			literal.WriteByte(',')
+		}
+		literal.WriteString(strconv.FormatFloat(float64(component), 'f', -1, 32))
+	}
+	literal.WriteByte(']')
+	return literal.String(), nil
+}
+
+// Scan parses a pgvector text value back into the vector
+func (v *Vector) Scan(value interface{}) error {
+	var literal string
+	switch raw := value.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		literal = raw
+	case []byte:
+		literal = string(raw)
+	default:
+		return fmt.Errorf("cannot scan %T into Vector", value)
+	}
+
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "[") || !strings.HasSuffix(literal, "]") {
+		return fmt.Errorf("invalid vector literal %q", literal)
+	}
+	body := strings.TrimSpace(literal[1 : len(literal)-1])
+	if body == "" {
+		*v = Vector{}
+		return nil
+	}
+
+	components := strings.Split(body, ",")
+	vector := make(Vector, len(components))
+	for i, component := range components {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(component), 32)
+		if err != nil {
+			return fmt.Errorf("invalid vector component %q: %w", component, err)
+		}
+		vector[i] = float32(parsed)
+	}
+	*v = vector
+	return nil
+}
+
+// NearestNeighbors returns the k rows whose pgvector column is closest to vector by
+// Euclidean distance (ORDER BY column <-> vector), nearest first. Filters, search and
+// soft-delete visibility from params narrow the candidates; its sorting and pagination
+// are ignored since the distance decides both order and size. It reads from the replica
+// configured with WithReadReplica unless params force the primary.
+func (uow *PostgresUnitOfWork[T]) NearestNeighbors(ctx context.Context, column string, vector []float32, k int, params *query.QueryParams[T]) ([]T, error) {
+	if err := ValidateFieldName(column); err != nil {
+		return nil, err
+	}
+	if len(vector) == 0 {
+		return nil, domainerrors.NewValidationError("vector", "vector must not be empty")
+	}
+	if k <= 0 {
+		return nil, domainerrors.NewValidationError("k", "k must be positive")
+	}
+
+	distance := clause.OrderBy{Expression: clause.Expr{
+		SQL:                uow.filterApplier.columnName(column) + " <-> ?",
+		Vars:               []interface{}{Vector(vector)},
+		WithoutParentheses: true,
+	}}
+
+	var entities []T
+	db := uow.readDB(params != nil && params.ForcePrimary)
+	err := uow.withReadRetry(ctx, func() error {
+		entities = nil
+		filtered := uow.filterApplier.ApplyQueryConditions(db.WithContext(ctx).Model(new(T)), params)
+		return filtered.Clauses(distance).Limit(k).Find(&entities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
@@ -0,0 +1,77 @@
+//go:build pgvector
+
+package unit_of_work
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
+
+	"gorm.io/gorm"
+)
+
+// embeddedDocument stores a three-dimensional pgvector embedding
+type embeddedDocument struct {
+	types.BaseEntity
+	Name      string `gorm:"column:name"`
+	Embedding Vector `gorm:"column:embedding;type:vector(3)"`
+}
+
+// TableName returns the table name for GORM
+func (ed *embeddedDocument) TableName() string {
+	return "embedded_documents"
+}
+
+// setupPgvectorDB connects to the Postgres database in PGVECTOR_DSN, which must have the
+// vector extension available, and skips the test when it is not set
+func setupPgvectorDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := openPostgresDB(t, "PGVECTOR_DSN")
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS vector").Error; err != nil {
+		t.Fatalf("Failed to enable pgvector: %v", err)
+	}
+	if err := db.Migrator().DropTable(&embeddedDocument{}); err != nil {
+		t.Fatalf("Failed to drop embedded documents: %v", err)
+	}
+	if err := db.AutoMigrate(&embeddedDocument{}); err != nil {
+		t.Fatalf("Failed to migrate embedded documents: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Migrator().DropTable(&embeddedDocument{}) })
+	return db
+}
+
+// TestNearestNeighbors_OrderedByDistance validates that results come back nearest first
+func TestNearestNeighbors_OrderedByDistance(t *testing.T) {
+	// Arrange
+	db := setupPgvectorDB(t)
+	uow := NewPostgresUnitOfWork[*embeddedDocument](db).(*PostgresUnitOfWork[*embeddedDocument])
+	ctx := context.Background()
+	documents := []*embeddedDocument{
+		{Name: "Far", Embedding: Vector{10, 10, 10}},
+		{Name: "Near", Embedding: Vector{1, 1, 1}},
+		{Name: "Exact", Embedding: Vector{0, 0, 0}},
+		{Name: "Middle", Embedding: Vector{3, 3, 3}},
+	}
+	if _, err := uow.BulkInsert(ctx, documents); err != nil {
+		t.Fatalf("Failed to insert documents: %v", err)
+	}
+
+	// Act
+	result, err := uow.NearestNeighbors(ctx, "embedding", []float32{0, 0, 0}, 3, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := []string{"Exact", "Near", "Middle"}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(result))
+	}
+	for i, name := range expected {
+		if result[i].Name != name {
+			t.Errorf("Expected result %d to be %s, got %s", i, name, result[i].Name)
+		}
+	}
+}
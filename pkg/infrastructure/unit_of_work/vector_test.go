@@ -0,0 +1,102 @@
+package unit_of_work
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/ai-shiraz-teams/go-database/internal/shared/errors"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+	"github.com/ai-shiraz-teams/go-database/internal/shared/query"
+	"github.com/ai-shiraz-teams/go-database/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// TestNearestNeighbors_SQL validates that the distance ordering binds the vector and combines with filters
+func TestNearestNeighbors_SQL(t *testing.T) {
+	// Arrange
+	db := testutil.SetupTestDB(t).Session(&gorm.Session{DryRun: true})
+	capture := &capturingLogger{}
+	uow := NewPostgresUnitOfWork[*testutil.TestEntity](db, WithLogger(capture)).(*PostgresUnitOfWork[*testutil.TestEntity])
+	params := query.NewQueryParams[*testutil.TestEntity]()
+	params.Filters = identifier.NewIdentifier().Equal("status", "active").ToFilterCriteria()
+
+	// Act
+	_, err := uow.NearestNeighbors(context.Background(), "embedding", []float32{1, 0.5, -2}, 3, params)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(capture.statements) != 1 {
+		t.Fatalf("Expected one statement, got %v", capture.statements)
+	}
+	sql := capture.statements[0]
+	for _, expected := range []string{"status = \"active\"", `ORDER BY embedding <-> "[1,0.5,-2]" LIMIT 3`} {
+		if !strings.Contains(sql, expected) {
+			t.Errorf("Expected SQL to contain %q, got: %s", expected, sql)
+		}
+	}
+}
+
+// TestNearestNeighbors_Validation validates that invalid arguments are rejected before querying
+func TestNearestNeighbors_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		vector []float32
+		k      int
+	}{
+		{"Invalid column", "embedding; DROP TABLE x", []float32{1}, 1},
+		{"Empty vector", "embedding", nil, 1},
+		{"Non-positive k", "embedding", []float32{1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			uow := NewPostgresUnitOfWork[*testutil.TestEntity](testutil.SetupTestDB(t)).(*PostgresUnitOfWork[*testutil.TestEntity])
+
+			// Act
+			_, err := uow.NearestNeighbors(context.Background(), tt.column, tt.vector, tt.k, nil)
+
+			// Assert
+			var validationErr *domainerrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected validation error, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestVector_RoundTrip validates that a vector survives conversion to and from the pgvector text format
+func TestVector_RoundTrip(t *testing.T) {
+	// Arrange
+	original := Vector{1, -0.25, 3e-3}
+
+	// Act
+	literal, err := original.Value()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var scanned Vector
+	scanErr := scanned.Scan([]byte(literal.(string)))
+
+	// Assert
+	if literal != "[1,-0.25,0.003]" {
+		t.Errorf("Expected literal [1,-0.25,0.003], got %v", literal)
+	}
+	if scanErr != nil {
+		t.Fatalf("Expected no scan error, got: %v", scanErr)
+	}
+	if len(scanned) != len(original) {
+		t.Fatalf("Expected %d components, got %d", len(original), len(scanned))
+	}
+	for i := range original {
+		if scanned[i] != original[i] {
+			t.Errorf("Expected component %d to be %v, got %v", i, original[i], scanned[i])
+		}
+	}
+}
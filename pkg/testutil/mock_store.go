@@ -0,0 +1,123 @@
+package testutil
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+
+	"gorm.io/gorm"
+)
+
+// store appends an entity to the mock's state, assigning the next ID when it has none
+func (m *MockUnitOfWork) store(entity *TestEntity) {
+	if entity.ID == 0 {
+		entity.ID = len(m.Entities) + 1
+	}
+	m.Entities = append(m.Entities, entity)
+}
+
+// softDelete marks the first live stored entity matching the identifier as deleted
+func (m *MockUnitOfWork) softDelete(id identifier.IIdentifier) (*TestEntity, error) {
+	filters := id.ToFilterCriteria()
+	for _, entity := range m.Entities {
+		if !entity.DeletedAt.Valid && matchesFilters(entity, filters) {
+			entity.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			return entity, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// count returns the number of stored entities matching the filters and soft-delete visibility
+// of params, which is read by field name because the query package cannot be imported here.
+// Fields missing from params keep their zero value.
+func (m *MockUnitOfWork) count(params interface{}) int64 {
+	var filters []identifier.FilterCriteria
+	var includeDeleted, onlyDeleted bool
+
+	val := reflect.Indirect(reflect.ValueOf(params))
+	if val.Kind() == reflect.Struct {
+		if field := val.FieldByName("Filters"); field.IsValid() {
+			filters, _ = field.Interface().([]identifier.FilterCriteria)
+		}
+		if field := val.FieldByName("IncludeDeleted"); field.IsValid() {
+			includeDeleted, _ = field.Interface().(bool)
+		}
+		if field := val.FieldByName("OnlyDeleted"); field.IsValid() {
+			onlyDeleted, _ = field.Interface().(bool)
+		}
+	}
+
+	var count int64
+	for _, entity := range m.Entities {
+		deleted := entity.DeletedAt.Valid
+		if (onlyDeleted && !deleted) || (!onlyDeleted && !includeDeleted && deleted) {
+			continue
+		}
+		if matchesFilters(entity, filters) {
+			count++
+		}
+	}
+	return count
+}
+
+// matchesFilters reports whether an entity satisfies all filters. Only the equality and
+// membership operators are supported, which is enough for asserting counts in tests.
+func matchesFilters(entity *TestEntity, filters []identifier.FilterCriteria) bool {
+	for _, filter := range filters {
+		value, ok := entityColumn(entity, filter.Field)
+		if !ok {
+			return false
+		}
+
+		switch filter.Operator {
+		case identifier.FilterOperatorEqual:
+			if !sameValue(value, filter.Value) {
+				return false
+			}
+		case identifier.FilterOperatorNotEqual:
+			if sameValue(value, filter.Value) {
+				return false
+			}
+		case identifier.FilterOperatorIn:
+			found := false
+			for _, candidate := range filter.Values {
+				found = found || sameValue(value, candidate)
+			}
+			if !found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// entityColumn returns the value of a TestEntity column by its database name
+func entityColumn(entity *TestEntity, column string) (interface{}, bool) {
+	switch column {
+	case "id":
+		return entity.ID, true
+	case "name":
+		return entity.Name, true
+	case "email":
+		return entity.Email, true
+	case "age":
+		return entity.Age, true
+	case "is_active":
+		return entity.IsActive, true
+	case "description":
+		return entity.Description, true
+	case "status":
+		return entity.Status, true
+	}
+	return nil, false
+}
+
+// sameValue compares values by their formatted representation so 1 and int64(1) are equal
+func sameValue(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
@@ -0,0 +1,76 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
+)
+
+// countParams mirrors the QueryParams fields read by the stateful mock
+type countParams struct {
+	Filters        []identifier.FilterCriteria
+	IncludeDeleted bool
+	OnlyDeleted    bool
+}
+
+// TestMockUnitOfWork_StatefulCount validates that Count reflects inserts and soft deletes
+func TestMockUnitOfWork_StatefulCount(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mock := &MockUnitOfWork{Stateful: true}
+	for _, entity := range []*TestEntity{
+		{Name: "Entity 1", Status: "active"},
+		{Name: "Entity 2", Status: "active"},
+		{Name: "Entity 3", Status: "inactive"},
+	} {
+		if _, err := mock.Insert(ctx, entity); err != nil {
+			t.Fatalf("Failed to insert entity: %v", err)
+		}
+	}
+	if _, err := mock.SoftDelete(ctx, identifier.NewIdentifier().Equal("id", 1)); err != nil {
+		t.Fatalf("Failed to soft delete entity: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   interface{}
+		expected int64
+	}{
+		{"Nil params count live rows", nil, 2},
+		{"Filters are applied", &countParams{Filters: identifier.NewIdentifier().Equal("status", "active").ToFilterCriteria()}, 1},
+		{"Deleted rows can be included", &countParams{IncludeDeleted: true}, 3},
+		{"Only deleted rows", &countParams{OnlyDeleted: true}, 1},
+		{"Missing fields are ignored", &struct{ Filters []identifier.FilterCriteria }{}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			count, err := mock.Count(ctx, tt.params)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if count != tt.expected {
+				t.Errorf("Expected count %d, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+// TestMockUnitOfWork_StaticCount validates that the configured result is kept without Stateful
+func TestMockUnitOfWork_StaticCount(t *testing.T) {
+	// Arrange
+	mock := &MockUnitOfWork{CountResult: 42}
+	_, _ = mock.Insert(context.Background(), &TestEntity{Name: "Entity 1"})
+
+	// Act
+	count, _ := mock.Count(context.Background(), nil)
+
+	// Assert
+	if count != 42 {
+		t.Errorf("Expected configured count 42, got %d", count)
+	}
+}
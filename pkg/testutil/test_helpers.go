@@ -3,6 +3,7 @@ package testutil
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ai-shiraz-teams/go-database/internal/shared/identifier"
 	"github.com/ai-shiraz-teams/go-database/internal/shared/types"
@@ -22,6 +23,9 @@ type TestEntity struct {
 	IsActive    bool   `gorm:"column:is_active" json:"is_active"`
 	Description string `gorm:"column:description" json:"description"`
 	Status      string `gorm:"column:status" json:"status"`
+
+	// Orders is a has-many relation used to exercise preloads and relation filters
+	Orders []TestOrder `gorm:"foreignKey:TestEntityID" json:"orders,omitempty"`
 }
 
 // TableName returns the table name for GORM
@@ -29,9 +33,22 @@ func (te *TestEntity) TableName() string {
 	return "test_entities"
 }
 
+// TestOrder is a related test entity owned by a TestEntity
+type TestOrder struct {
+	types.BaseEntity
+	TestEntityID int    `gorm:"column:test_entity_id;index" json:"test_entity_id"`
+	Status       string `gorm:"column:status" json:"status"`
+	Amount       int    `gorm:"column:amount" json:"amount"`
+}
+
+// TableName returns the table name for GORM
+func (to *TestOrder) TableName() string {
+	return "test_orders"
+}
+
 // SetupTestDB creates a standardized in-memory SQLite database for testing.
 // This replaces all duplicate setupTestDB, setupFilterTestDB functions across the codebase.
-func SetupTestDB(t *testing.T) *gorm.DB {
+func SetupTestDB(t testing.TB) *gorm.DB {
 	t.Helper()
 
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
@@ -41,8 +58,8 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("Failed to create test database: %v", err)
 	}
 
-	// Auto-migrate the unified test entity
-	if err := db.AutoMigrate(&TestEntity{}); err != nil {
+	// Auto-migrate the unified test entity and its relations
+	if err := db.AutoMigrate(&TestEntity{}, &TestOrder{}); err != nil {
 		t.Fatalf("Failed to migrate test entity: %v", err)
 	}
 
@@ -85,6 +102,13 @@ func CreateTestEntities() []*TestEntity {
 // MockUnitOfWork provides a unified mock implementation for IUnitOfWork testing.
 // This replaces all duplicate MockUnitOfWork implementations across the codebase.
 type MockUnitOfWork struct {
+	// Stateful makes Insert, BulkInsert, SoftDelete and Count operate on Entities instead of
+	// returning the configured results, so tests can assert counts after real mutations
+	Stateful bool
+
+	// Entities holds the stored entities when Stateful is enabled
+	Entities []*TestEntity
+
 	// Mock call tracking fields
 	FindAllCalled                  bool
 	FindAllWithPaginationCalled    bool
@@ -109,7 +133,34 @@ type MockUnitOfWork struct {
 	BeginTransactionCalled         bool
 	CommitTransactionCalled        bool
 	RollbackTransactionCalled      bool
+	RunInTransactionCalled         bool
 	ResolveIDByUniqueFieldCalled   bool
+	GetTrashedByIdentifierCalled   bool
+	RollbackTransactionECalled     bool
+	UpdateECalled                  bool
+	PruneWhereCalled               bool
+	UpdateIncludingTrashedCalled   bool
+	FindByIDsCalled                bool
+	SoftDeleteWithNoteCalled       bool
+	ResolveIDByFieldsCalled        bool
+	BulkSoftDeleteECalled          bool
+	DeleteECalled                  bool
+	CountIncludingTrashedCalled    bool
+	FindOneIncludingTrashedCalled  bool
+	InTransactionCalled            bool
+	PingCalled                     bool
+	ExistsWhereCalled              bool
+	SaveCalled                     bool
+	CountPagesCalled               bool
+	CountByCalled                  bool
+	FindChangedSinceCalled         bool
+	MergeJSONCalled                bool
+	DialectCalled                  bool
+	ReplaceCalled                  bool
+	RestoreAllMatchingCalled       bool
+	MustExistCalled                bool
+	SoftDeleteWhereCalled          bool
+	FindTopCalled                  bool
 
 	// Mock return values
 	FindAllResult                  []*TestEntity
@@ -131,6 +182,31 @@ type MockUnitOfWork struct {
 	CountResult                    int64
 	ExistsResult                   bool
 	ResolveIDByUniqueFieldResult   int
+	GetTrashedByIdentifierResult   []*TestEntity
+	UpdateEResult                  *TestEntity
+	UpdateERowsAffected            int64
+	PruneWhereResult               int64
+	UpdateIncludingTrashedResult   *TestEntity
+	FindByIDsResult                []*TestEntity
+	SoftDeleteWithNoteResult       *TestEntity
+	ResolveIDByFieldsResult        int
+	BulkSoftDeleteEResult          int64
+	DeleteEResult                  int64
+	CountIncludingTrashedResult    int64
+	FindOneIncludingTrashedResult  *TestEntity
+	InTransactionResult            bool
+	ExistsWhereResult              bool
+	SaveResult                     *TestEntity
+	CountPagesTotal                int64
+	CountPagesPages                int
+	CountByResult                  int64
+	FindChangedSinceResult         []*TestEntity
+	MergeJSONRowsAffected          int64
+	DialectResult                  string
+	ReplaceResult                  *TestEntity
+	RestoreAllMatchingResult       int64
+	SoftDeleteWhereResult          int64
+	FindTopResult                  []*TestEntity
 
 	// Mock error values
 	FindAllError                  error
@@ -155,7 +231,32 @@ type MockUnitOfWork struct {
 	ExistsError                   error
 	BeginTransactionError         error
 	CommitTransactionError        error
+	RunInTransactionError         error
 	ResolveIDByUniqueFieldError   error
+	GetTrashedByIdentifierError   error
+	RollbackTransactionEError     error
+	UpdateEError                  error
+	PruneWhereError               error
+	UpdateIncludingTrashedError   error
+	FindByIDsError                error
+	SoftDeleteWithNoteError       error
+	ResolveIDByFieldsError        error
+	BulkSoftDeleteEError          error
+	DeleteEError                  error
+	CountIncludingTrashedError    error
+	FindOneIncludingTrashedError  error
+	PingError                     error
+	ExistsWhereError              error
+	SaveError                     error
+	CountPagesError               error
+	CountByError                  error
+	FindChangedSinceError         error
+	MergeJSONError                error
+	ReplaceError                  error
+	RestoreAllMatchingError       error
+	MustExistError                error
+	SoftDeleteWhereError          error
+	FindTopError                  error
 }
 
 // MockUnitOfWork method implementations
@@ -186,6 +287,10 @@ func (m *MockUnitOfWork) FindOneByIdentifier(ctx context.Context, identifier ide
 
 func (m *MockUnitOfWork) Insert(ctx context.Context, entity *TestEntity) (*TestEntity, error) {
 	m.InsertCalled = true
+	if m.Stateful && m.InsertError == nil {
+		m.store(entity)
+		return entity, nil
+	}
 	return m.InsertResult, m.InsertError
 }
 
@@ -201,6 +306,9 @@ func (m *MockUnitOfWork) Delete(ctx context.Context, identifier identifier.IIden
 
 func (m *MockUnitOfWork) SoftDelete(ctx context.Context, identifier identifier.IIdentifier) (*TestEntity, error) {
 	m.SoftDeleteCalled = true
+	if m.Stateful && m.SoftDeleteError == nil {
+		return m.softDelete(identifier)
+	}
 	return m.SoftDeleteResult, m.SoftDeleteError
 }
 
@@ -211,6 +319,12 @@ func (m *MockUnitOfWork) HardDelete(ctx context.Context, identifier identifier.I
 
 func (m *MockUnitOfWork) BulkInsert(ctx context.Context, entities []*TestEntity) ([]*TestEntity, error) {
 	m.BulkInsertCalled = true
+	if m.Stateful && m.BulkInsertError == nil {
+		for _, entity := range entities {
+			m.store(entity)
+		}
+		return entities, nil
+	}
 	return m.BulkInsertResult, m.BulkInsertError
 }
 
@@ -251,6 +365,9 @@ func (m *MockUnitOfWork) RestoreAll(ctx context.Context) error {
 
 func (m *MockUnitOfWork) Count(ctx context.Context, params interface{}) (int64, error) {
 	m.CountCalled = true
+	if m.Stateful && m.CountError == nil {
+		return m.count(params), nil
+	}
 	return m.CountResult, m.CountError
 }
 
@@ -273,7 +390,145 @@ func (m *MockUnitOfWork) RollbackTransaction(ctx context.Context) {
 	m.RollbackTransactionCalled = true
 }
 
+func (m *MockUnitOfWork) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.RunInTransactionCalled = true
+	if m.RunInTransactionError != nil {
+		return m.RunInTransactionError
+	}
+	return fn(ctx)
+}
+
 func (m *MockUnitOfWork) ResolveIDByUniqueField(ctx context.Context, model types.IBaseModel, field string, value interface{}) (int, error) {
 	m.ResolveIDByUniqueFieldCalled = true
 	return m.ResolveIDByUniqueFieldResult, m.ResolveIDByUniqueFieldError
 }
+
+func (m *MockUnitOfWork) GetTrashedByIdentifier(ctx context.Context, identifier identifier.IIdentifier) ([]*TestEntity, error) {
+	m.GetTrashedByIdentifierCalled = true
+	return m.GetTrashedByIdentifierResult, m.GetTrashedByIdentifierError
+}
+
+func (m *MockUnitOfWork) RollbackTransactionE(ctx context.Context) error {
+	m.RollbackTransactionECalled = true
+	return m.RollbackTransactionEError
+}
+
+func (m *MockUnitOfWork) UpdateE(ctx context.Context, identifier identifier.IIdentifier, entity *TestEntity) (*TestEntity, int64, error) {
+	m.UpdateECalled = true
+	return m.UpdateEResult, m.UpdateERowsAffected, m.UpdateEError
+}
+
+func (m *MockUnitOfWork) PruneWhere(ctx context.Context, params interface{}) (int64, error) {
+	m.PruneWhereCalled = true
+	return m.PruneWhereResult, m.PruneWhereError
+}
+
+func (m *MockUnitOfWork) UpdateIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier, entity *TestEntity) (*TestEntity, error) {
+	m.UpdateIncludingTrashedCalled = true
+	return m.UpdateIncludingTrashedResult, m.UpdateIncludingTrashedError
+}
+
+func (m *MockUnitOfWork) FindByIDs(ctx context.Context, ids []int) ([]*TestEntity, error) {
+	m.FindByIDsCalled = true
+	return m.FindByIDsResult, m.FindByIDsError
+}
+
+func (m *MockUnitOfWork) SoftDeleteWithNote(ctx context.Context, identifier identifier.IIdentifier, note string) (*TestEntity, error) {
+	m.SoftDeleteWithNoteCalled = true
+	return m.SoftDeleteWithNoteResult, m.SoftDeleteWithNoteError
+}
+
+func (m *MockUnitOfWork) ResolveIDByFields(ctx context.Context, model types.IBaseModel, fields map[string]interface{}) (int, error) {
+	m.ResolveIDByFieldsCalled = true
+	return m.ResolveIDByFieldsResult, m.ResolveIDByFieldsError
+}
+
+func (m *MockUnitOfWork) BulkSoftDeleteE(ctx context.Context, identifiers []identifier.IIdentifier) (int64, error) {
+	m.BulkSoftDeleteECalled = true
+	return m.BulkSoftDeleteEResult, m.BulkSoftDeleteEError
+}
+
+func (m *MockUnitOfWork) DeleteE(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	m.DeleteECalled = true
+	return m.DeleteEResult, m.DeleteEError
+}
+
+func (m *MockUnitOfWork) CountIncludingTrashed(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	m.CountIncludingTrashedCalled = true
+	return m.CountIncludingTrashedResult, m.CountIncludingTrashedError
+}
+
+func (m *MockUnitOfWork) FindOneIncludingTrashed(ctx context.Context, filter *TestEntity) (*TestEntity, error) {
+	m.FindOneIncludingTrashedCalled = true
+	return m.FindOneIncludingTrashedResult, m.FindOneIncludingTrashedError
+}
+
+func (m *MockUnitOfWork) InTransaction() bool {
+	m.InTransactionCalled = true
+	return m.InTransactionResult
+}
+
+func (m *MockUnitOfWork) Ping(ctx context.Context) error {
+	m.PingCalled = true
+	return m.PingError
+}
+
+func (m *MockUnitOfWork) ExistsWhere(ctx context.Context, identifier identifier.IIdentifier, extra identifier.IIdentifier) (bool, error) {
+	m.ExistsWhereCalled = true
+	return m.ExistsWhereResult, m.ExistsWhereError
+}
+
+func (m *MockUnitOfWork) Save(ctx context.Context, entity *TestEntity) (*TestEntity, error) {
+	m.SaveCalled = true
+	return m.SaveResult, m.SaveError
+}
+
+func (m *MockUnitOfWork) CountPages(ctx context.Context, query interface{}) (int64, int, error) {
+	m.CountPagesCalled = true
+	return m.CountPagesTotal, m.CountPagesPages, m.CountPagesError
+}
+
+func (m *MockUnitOfWork) CountBy(ctx context.Context, identifier identifier.IIdentifier, opts interface{}) (int64, error) {
+	m.CountByCalled = true
+	return m.CountByResult, m.CountByError
+}
+
+func (m *MockUnitOfWork) FindChangedSince(ctx context.Context, since time.Time, query interface{}) ([]*TestEntity, error) {
+	m.FindChangedSinceCalled = true
+	return m.FindChangedSinceResult, m.FindChangedSinceError
+}
+
+func (m *MockUnitOfWork) MergeJSON(ctx context.Context, identifier identifier.IIdentifier, column string, patch map[string]interface{}) (int64, error) {
+	m.MergeJSONCalled = true
+	return m.MergeJSONRowsAffected, m.MergeJSONError
+}
+
+func (m *MockUnitOfWork) Dialect() string {
+	m.DialectCalled = true
+	return m.DialectResult
+}
+
+func (m *MockUnitOfWork) Replace(ctx context.Context, identifier identifier.IIdentifier, entity *TestEntity) (*TestEntity, error) {
+	m.ReplaceCalled = true
+	return m.ReplaceResult, m.ReplaceError
+}
+
+func (m *MockUnitOfWork) RestoreAllMatching(ctx context.Context, identifier identifier.IIdentifier) (int64, error) {
+	m.RestoreAllMatchingCalled = true
+	return m.RestoreAllMatchingResult, m.RestoreAllMatchingError
+}
+
+func (m *MockUnitOfWork) MustExist(ctx context.Context, identifier identifier.IIdentifier) error {
+	m.MustExistCalled = true
+	return m.MustExistError
+}
+
+func (m *MockUnitOfWork) SoftDeleteWhere(ctx context.Context, params interface{}) (int64, error) {
+	m.SoftDeleteWhereCalled = true
+	return m.SoftDeleteWhereResult, m.SoftDeleteWhereError
+}
+
+func (m *MockUnitOfWork) FindTop(ctx context.Context, n int, sort interface{}, identifier identifier.IIdentifier) ([]*TestEntity, error) {
+	m.FindTopCalled = true
+	return m.FindTopResult, m.FindTopError
+}